@@ -0,0 +1,115 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// invalidator is implemented by pools which can destroy an acquired resource
+// instead of taking it back
+type invalidator[T any] interface {
+	Invalidate(T) error
+}
+
+// AcquireGroup runs goroutines like an errgroup.Group and tracks the
+// resources acquired through it. Wait returns every resource still held to the pool once all
+// goroutines are done, so fan-out code can not leak resources. When a
+// goroutine failed, the held resources are invalidated instead, as they may
+// have been left mid-use.
+type AcquireGroup[T comparable] struct {
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	pool   Pool[T]
+	mutex  sync.Mutex
+	held   map[T]struct{}
+	err    error
+}
+
+// starts a function in a new goroutine; the first error cancels the group
+// context and is returned by Wait
+func (g *AcquireGroup[T]) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := f(); err != nil {
+			g.fail(err)
+		}
+	}()
+}
+
+// records the first error of a goroutine and cancels the group context
+func (g *AcquireGroup[T]) fail(err error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.err == nil {
+		g.err = err
+		g.cancel(err)
+	}
+}
+
+// acquires a resource with the group context and tracks it until Release or
+// Wait
+func (g *AcquireGroup[T]) Acquire() (T, error) {
+	resource, err := g.pool.Acquire(g.ctx)
+	if err != nil {
+		return *new(T), err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.held[resource] = struct{}{}
+	return resource, nil
+}
+
+// releases a resource acquired through the group before Wait
+func (g *AcquireGroup[T]) Release(resource T) {
+	g.mutex.Lock()
+	_, isHeld := g.held[resource]
+	delete(g.held, resource)
+	g.mutex.Unlock()
+
+	if isHeld {
+		g.pool.Release(resource)
+	}
+}
+
+// waits for all goroutines, then releases the resources still held, or
+// invalidates them if a goroutine failed; returns the first goroutine error
+func (g *AcquireGroup[T]) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mutex.Lock()
+	err := g.err
+	held := g.held
+	g.held = make(map[T]struct{})
+	g.mutex.Unlock()
+
+	pool, isInvalidator := g.pool.(invalidator[T])
+	for resource := range held {
+		if err != nil && isInvalidator {
+			pool.Invalidate(resource)
+		} else {
+			g.pool.Release(resource)
+		}
+	}
+
+	return err
+}
+
+// creates an acquire group acquiring from pool; the returned context is
+// cancelled when a goroutine of the group fails, with its error as the cause,
+// or when Wait returns
+func NewAcquireGroup[T comparable](ctx context.Context, pool Pool[T]) (*AcquireGroup[T], context.Context) {
+	groupCtx, cancel := context.WithCancelCause(ctx)
+	return &AcquireGroup[T]{
+		ctx:    groupCtx,
+		cancel: cancel,
+		pool:   pool,
+		held:   make(map[T]struct{}),
+	}, groupCtx
+}
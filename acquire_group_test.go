@@ -0,0 +1,76 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAcquireGroup_Wait(t *testing.T) {
+	errResponse := errors.New("error response")
+	testCases := []struct {
+		name              string
+		err               error
+		expectedCause     error
+		expectedIdle      int
+		expectedEvictions map[EvictReason]int64
+	}{
+		{
+			name:              "with successful goroutines releases held resources",
+			expectedCause:     context.Canceled,
+			expectedIdle:      3,
+			expectedEvictions: map[EvictReason]int64{},
+		},
+		{
+			name:              "with failed goroutine invalidates held resources",
+			err:               errResponse,
+			expectedCause:     errResponse,
+			expectedEvictions: map[EvictReason]int64{EvictInvalidated: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			group, ctx := NewAcquireGroup[MockResource](context.Background(), pool)
+
+			for i := 0; i < 3; i++ {
+				isLast := i == 2
+				group.Go(func() error {
+					if _, err := group.Acquire(); err != nil {
+						return err
+					}
+					if isLast {
+						return tc.err
+					}
+					return nil
+				})
+			}
+
+			assert.ErrorIs(t, group.Wait(), tc.err)
+			assert.Equal(t, tc.expectedCause, context.Cause(ctx))
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, 0, pool.Stats().Active)
+			assert.Equal(t, tc.expectedEvictions, pool.Stats().Evictions)
+		})
+	}
+}
+
+func TestAcquireGroup_Release(t *testing.T) {
+	pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	group, _ := NewAcquireGroup[MockResource](context.Background(), pool)
+
+	group.Go(func() error {
+		resource, err := group.Acquire()
+		if err != nil {
+			return err
+		}
+		group.Release(resource)
+		return nil
+	})
+
+	assert.NoError(t, group.Wait())
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Acquires)
+}
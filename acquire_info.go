@@ -0,0 +1,26 @@
+package pool
+
+import (
+	"time"
+)
+
+// AcquireInfo describes a resource returned by AcquireWithInfo, e.g. so
+// callers can skip initializing warm resources again or measure hit rates
+type AcquireInfo struct {
+	// IsReused is set when the resource came from the idle pool, the local
+	// cache or a reentrant scope, and unset when it was freshly created
+	IsReused bool
+	// Age is the time since the resource was created; zero when it came from
+	// the local cache, which does not track resources
+	Age time.Duration
+	// Waited is the time the acquire waited for a release or for capacity
+	Waited time.Duration
+}
+
+// returns the acquire info of a resource about to be handed out
+func (n *NewPool[T]) getAcquireInfo(entry *resourceEntry, isHeld bool) AcquireInfo {
+	return AcquireInfo{
+		IsReused: isHeld || !entry.releasedAt.IsZero(),
+		Age:      n.now().Sub(entry.createdAt),
+	}
+}
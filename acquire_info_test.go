@@ -0,0 +1,73 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithInfo(t *testing.T) {
+	testCases := []struct {
+		name         string
+		options      []Option[MockResource]
+		prepare      func(*NewPool[MockResource]) context.Context
+		expectedInfo AcquireInfo
+	}{
+		{
+			name:    "from empty pool is freshly created",
+			prepare: func(*NewPool[MockResource]) context.Context { return nil },
+		},
+		{
+			name: "from idle pool is reused with age",
+			prepare: func(pool *NewPool[MockResource]) context.Context {
+				resource, _ := pool.Acquire(nil)
+				pool.Release(resource)
+				return nil
+			},
+			expectedInfo: AcquireInfo{IsReused: true, Age: time.Second},
+		},
+		{
+			name: "within reentrant scope is reused with age",
+			options: []Option[MockResource]{
+				WithReentrantAcquire[MockResource](),
+			},
+			prepare: func(pool *NewPool[MockResource]) context.Context {
+				ctx := ReentrantScope(context.Background())
+				pool.Acquire(ctx)
+				return ctx
+			},
+			expectedInfo: AcquireInfo{IsReused: true, Age: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, append(tc.options, WithClock[MockResource](clock))...)
+			ctx := tc.prepare(pool)
+			clock.Advance(time.Second)
+
+			_, info, err := pool.AcquireWithInfo(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
+
+func TestNewPool_AcquireWithInfoWithLocalCache(t *testing.T) {
+	pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime, WithLocalCache[*MockBuffer]())
+	first, _ := pool.Acquire(nil)
+	pool.TryRelease(first)
+
+	// the cache may drop resources at any time, so a hit is not guaranteed
+	second, info, err := pool.AcquireWithInfo(nil)
+
+	assert.NoError(t, err)
+	if second == first {
+		assert.Equal(t, AcquireInfo{IsReused: true}, info)
+	} else {
+		assert.False(t, info.IsReused)
+	}
+}
@@ -0,0 +1,36 @@
+package pool
+
+// AcquirePolicy sets whether acquires reuse idle resources or create new ones
+// first
+type AcquirePolicy int
+
+const (
+	// PreferReuse takes an idle resource when there is one and only creates
+	// on a miss; it is the default
+	PreferReuse AcquirePolicy = iota
+	// PreferCreate creates new resources until the pool holds its target size,
+	// acquired and idle, before reusing idle ones, so the load fans out over
+	// many connections instead of hammering one hot connection
+	PreferCreate
+)
+
+// WithAcquirePolicy sets whether acquires reuse or create first. With
+// PreferCreate, targetSize is the number of resources created before idle
+// ones are reused; it should not exceed maxIdleSize, or released resources
+// past it are dropped and created again. Acquires waiting for a WithCreateRate
+// slot reuse an idle resource instead.
+func WithAcquirePolicy[T comparable](policy AcquirePolicy, targetSize int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.acquirePolicy = policy
+		n.targetSize = targetSize
+	}
+}
+
+// reports whether an acquire below capacity should create a resource even if
+// an idle one is available
+func (n *NewPool[T]) isCreatePreferred() bool {
+	if n.acquirePolicy != PreferCreate {
+		return false
+	}
+	return len(n.lock)+len(n.unlock)+n.hedging+n.missCreating < n.targetSize && n.createRate.getDelay(n.now()) <= 0
+}
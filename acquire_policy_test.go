@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_AcquireWithAcquirePolicy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []Option[MockResource]
+		expectedCreated int64
+	}{
+		{
+			name:            "without policy reuses idle resource",
+			expectedCreated: 1,
+		},
+		{
+			name:            "with prefer reuse reuses idle resource",
+			options:         []Option[MockResource]{WithAcquirePolicy[MockResource](PreferReuse, 2)},
+			expectedCreated: 1,
+		},
+		{
+			name:            "with prefer create creates up to target size",
+			options:         []Option[MockResource]{WithAcquirePolicy[MockResource](PreferCreate, 2)},
+			expectedCreated: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+
+			for i := 0; i < 3; i++ {
+				resource, _ := pool.Acquire(nil)
+				pool.Release(resource)
+			}
+
+			assert.Equal(t, tc.expectedCreated, pool.Stats().Created)
+		})
+	}
+}
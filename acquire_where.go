@@ -0,0 +1,16 @@
+package pool
+
+import (
+	"context"
+)
+
+// acquires like Acquire, but only reuses idle resources accepted by
+// predicate, e.g. connections supporting a server capability; without one it
+// creates a resource, which is returned without being checked. predicate runs
+// under the pool mutex, so it must not call the pool. The acquire bypasses
+// the cache of WithLocalCache, and reentrant scopes and version pins do not
+// apply to it.
+func (n *NewPool[T]) AcquireWhere(ctx context.Context, predicate func(T) bool) (T, error) {
+	resource, _, err := n.acquireWithInfo(ctx, predicate)
+	return resource, err
+}
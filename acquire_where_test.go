@@ -0,0 +1,57 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_AcquireWhere(t *testing.T) {
+	testCases := []struct {
+		name             string
+		predicate        func(MockResource) bool
+		expectedResource MockResource
+		expectedCreated  int64
+	}{
+		{
+			name:             "with matching idle resource reuses it",
+			predicate:        func(resource MockResource) bool { return resource.id == 2 },
+			expectedResource: MockResource{id: 2},
+			expectedCreated:  3,
+		},
+		{
+			name:             "without matching idle resource creates one",
+			predicate:        func(resource MockResource) bool { return resource.id > 3 },
+			expectedResource: MockResource{id: 4},
+			expectedCreated:  4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			resources, _ := pool.AcquireN(nil, 3)
+			pool.ReleaseAll(resources)
+
+			resource, err := pool.AcquireWhere(nil, tc.predicate)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedCreated, pool.Stats().Created)
+		})
+	}
+}
+
+func TestNewPool_AcquireWhereSkipsAffinity(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	ctx := Affinity(context.Background(), "user-1")
+	first, _ := pool.Acquire(ctx)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+	pool.Release(second)
+
+	resource, _ := pool.AcquireWhere(ctx, func(resource MockResource) bool { return resource != first })
+
+	assert.Equal(t, second, resource)
+	assert.Equal(t, int64(0), pool.Stats().AffinityHits)
+}
@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"context"
+)
+
+type affinityKey struct{}
+
+// Affinity returns a context whose acquires prefer the idle resource last
+// used with the same key, e.g. a user ID, for backends keeping per-connection
+// caches or session state. Without such an idle resource the acquire takes
+// any. It has no effect with WithLocalCache.
+func Affinity(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, affinityKey{}, key)
+}
+
+// returns the affinity key of ctx; empty if it has none
+func getAffinity(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	key, _ := ctx.Value(affinityKey{}).(string)
+	return key
+}
+
+// takes the idle resource last used with the affinity key of ctx, if it is
+// accepted by predicate
+func (n *NewPool[T]) getAffineResource(ctx context.Context, predicate func(T) bool) (T, bool) {
+	key := getAffinity(ctx)
+	if key == "" {
+		return *new(T), false
+	}
+
+	resource, isFound := n.affinities[key]
+	if !isFound {
+		return *new(T), false
+	}
+	entry, isIdle := n.unlock[resource]
+	if !isIdle || entry.affinity != key || n.isStale(entry) || !predicate(resource) {
+		return *new(T), false
+	}
+
+	delete(n.unlock, resource)
+	if !n.validate(ctx, resource) {
+		n.evict(resource, entry, EvictValidateFailed)
+		return *new(T), false
+	}
+	entry.acquiredAt = n.now()
+	n.markActive(resource, entry)
+	n.stats.affinityHits++
+	return resource, true
+}
+
+// records the affinity key a resource is handed out for, forgetting the one
+// it was last used with
+func (n *NewPool[T]) setAffinity(resource T, entry *resourceEntry, key string) {
+	n.clearAffinity(resource, entry)
+	entry.affinity = key
+	if key == "" {
+		return
+	}
+
+	if n.affinities == nil {
+		n.affinities = make(map[string]T)
+	}
+	n.affinities[key] = resource
+}
+
+// forgets the affinity key of a resource, e.g. when it is destroyed
+func (n *NewPool[T]) clearAffinity(resource T, entry *resourceEntry) {
+	if entry.affinity == "" {
+		return
+	}
+
+	if current, isFound := n.affinities[entry.affinity]; isFound && current == resource {
+		delete(n.affinities, entry.affinity)
+	}
+	entry.affinity = ""
+}
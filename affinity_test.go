@@ -0,0 +1,47 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_AcquireWithAffinity(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	first, _ := pool.Acquire(Affinity(context.Background(), "user-1"))
+	second, _ := pool.Acquire(Affinity(context.Background(), "user-2"))
+	pool.Release(first)
+	pool.Release(second)
+
+	resource, err := pool.Acquire(Affinity(context.Background(), "user-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, second, resource)
+
+	resource, err = pool.Acquire(Affinity(context.Background(), "user-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, first, resource)
+	assert.Equal(t, int64(2), pool.Stats().AffinityHits)
+}
+
+func TestNewPool_AcquireWithAffinityTakenByOtherKey(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(Affinity(context.Background(), "user-1"))
+	pool.Release(resource)
+	resource, _ = pool.Acquire(Affinity(context.Background(), "user-2"))
+	pool.Release(resource)
+
+	reacquired, _ := pool.Acquire(Affinity(context.Background(), "user-1"))
+
+	assert.Equal(t, resource, reacquired)
+	assert.Equal(t, int64(0), pool.Stats().AffinityHits)
+	assert.Len(t, pool.affinities, 1)
+}
+
+func TestNewPool_InvalidateWithAffinity(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(Affinity(context.Background(), "user-1"))
+
+	pool.Invalidate(resource)
+
+	assert.Empty(t, pool.affinities)
+}
@@ -0,0 +1,179 @@
+package pool
+
+import (
+	"time"
+)
+
+// defaults of AutoSizing
+const (
+	defaultMaxMissRate    = 0.1
+	defaultMaxWait        = 10 * time.Millisecond
+	defaultMinUtilization = 0.5
+	defaultCeilingFactor  = 2
+)
+
+// AutoSizing configures the max idle size controller of WithAutoSizing
+type AutoSizing struct {
+	// Floor is the smallest max idle size
+	Floor int
+	// Ceiling is the largest max idle size; zero means twice the maxIdleSize
+	// given to New
+	Ceiling int
+	// Interval is the period demand is measured over between adjustments
+	Interval time.Duration
+	// MaxMissRate is the fraction of acquires not served from the idle pool
+	// above which the max idle size grows; zero means 0.1
+	MaxMissRate float64
+	// MaxWait is the mean time acquires waited for a resource above which the
+	// max idle size grows; zero means 10ms
+	MaxWait time.Duration
+	// MinUtilization is the fraction of the max idle size the peak of acquired
+	// resources falls below for the max idle size to shrink; zero means 0.5
+	MinUtilization float64
+}
+
+// WithAutoSizing adjusts the max idle size to the demand every
+// sizing.Interval, within sizing.Floor and sizing.Ceiling: it grows by a
+// quarter when too many acquires had to create a resource or acquires waited
+// too long, and shrinks by a quarter when fewer resources were acquired at
+// once than the idle pool keeps, destroying the idle resources beyond the new
+// size. A WithWarmup size is the min idle target, which moves by the same
+// step, never below the warmup size given nor above the max idle size; the
+// warmup runs again to fill the idle pool up to a grown target. It has no
+// effect with CompatibilityV1.
+func WithAutoSizing[T comparable](sizing AutoSizing) Option[T] {
+	return func(n *NewPool[T]) {
+		n.sizing = sizing
+	}
+}
+
+// demandSample is the demand measured since the last adjustment
+type demandSample struct {
+	acquires     int64
+	reused       int64
+	waitDuration time.Duration
+	peakActive   int
+}
+
+// arms the timer of the next max idle size adjustment; the pool mutex must be
+// held
+func (n *NewPool[T]) scheduleAutoSize() {
+	if n.sizing.Interval <= 0 || n.isClosed {
+		return
+	}
+
+	if n.isSynchronous {
+		n.nextAutoSizeAt = n.now().Add(n.sizing.Interval)
+		return
+	}
+	n.autoSizeTimer = n.getClock().AfterFunc(n.sizing.Interval, func() {
+		n.scheduler.Go(func() {
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+
+			if n.autoSize() {
+				n.scheduler.Go(n.warmup)
+			}
+		})
+	})
+}
+
+// adjusts the max idle size and min idle target to the demand since the last
+// adjustment and schedules the next one; reports whether the warmup should
+// run to fill the idle pool up to the min idle target. The pool mutex must be
+// held.
+func (n *NewPool[T]) autoSize() bool {
+	if n.isClosed {
+		return false
+	}
+
+	acquires := n.stats.acquires - n.demand.acquires
+	misses := acquires - (n.stats.reused - n.demand.reused)
+	var meanWait time.Duration
+	if acquires > 0 {
+		meanWait = (n.stats.waitDuration - n.demand.waitDuration) / time.Duration(acquires)
+	}
+	size := getAutoSize(n.maxIdleSize, n.sizing, acquires, misses, meanWait, n.demand.peakActive)
+	if n.minWarmupSize > 0 {
+		n.warmupSize = getAutoWarmupSize(n.warmupSize+size-n.maxIdleSize, n.minWarmupSize, size)
+	}
+	n.maxIdleSize = size
+	n.demand = demandSample{
+		acquires:     n.stats.acquires,
+		reused:       n.stats.reused,
+		waitDuration: n.stats.waitDuration,
+		peakActive:   len(n.lock),
+	}
+
+	n.shrinkIdle(n.getMaxIdleCap(), EvictCapacity)
+	n.scheduleAutoSize()
+	if n.minWarmupSize <= 0 || n.warmupSize <= len(n.unlock) || n.isWarming {
+		return false
+	}
+	n.isWarming = true
+	return true
+}
+
+// records the peak of acquired resources of the demand sample; the pool mutex
+// must be held
+func (n *NewPool[T]) sampleActive() {
+	if len(n.lock) > n.demand.peakActive {
+		n.demand.peakActive = len(n.lock)
+	}
+}
+
+// stops the max idle size adjustments; the pool mutex must be held
+func (n *NewPool[T]) stopAutoSize() {
+	if n.autoSizeTimer != nil {
+		n.autoSizeTimer.Stop()
+		n.autoSizeTimer = nil
+	}
+}
+
+// returns the max idle size adjusted to acquires, of which misses created a
+// resource, waiting meanWait on average, and to peakActive resources acquired
+// at once
+func getAutoSize(size int, sizing AutoSizing, acquires int64, misses int64, meanWait time.Duration, peakActive int) int {
+	maxMissRate := sizing.MaxMissRate
+	if maxMissRate <= 0 {
+		maxMissRate = defaultMaxMissRate
+	}
+	maxWait := sizing.MaxWait
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
+	minUtilization := sizing.MinUtilization
+	if minUtilization <= 0 {
+		minUtilization = defaultMinUtilization
+	}
+
+	step := size / 4
+	if step < 1 {
+		step = 1
+	}
+	if acquires > 0 && (float64(misses)/float64(acquires) > maxMissRate || meanWait > maxWait) {
+		size += step
+	} else if float64(peakActive) < minUtilization*float64(size) {
+		size -= step
+	}
+
+	if size > sizing.Ceiling {
+		size = sizing.Ceiling
+	}
+	if size < sizing.Floor {
+		size = sizing.Floor
+	}
+	return size
+}
+
+// returns the min idle target size, kept within the warmup size given to New
+// and the max idle size
+func getAutoWarmupSize(size int, minSize int, maxIdleSize int) int {
+	if size > maxIdleSize {
+		size = maxIdleSize
+	}
+	if size < minSize {
+		size = minSize
+	}
+	return size
+}
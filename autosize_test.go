@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AutoSizing(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), 2, time.Hour,
+		WithSynchronous[MockResource](),
+		WithClock[MockResource](clock),
+		WithAutoSizing[MockResource](AutoSizing{Floor: 1, Ceiling: 4, Interval: time.Minute}),
+	)
+	resources, _ := pool.AcquireN(nil, 3)
+
+	// every acquire created a resource
+	clock.Advance(time.Minute)
+	for _, resource := range resources {
+		pool.Release(resource)
+	}
+	assert.Equal(t, 3, pool.Config().MaxIdleSize)
+	assert.Equal(t, 3, pool.NumIdle())
+
+	// two resources were still acquired at the adjustment
+	clock.Advance(time.Minute)
+	resource, _ := pool.Acquire(nil)
+	assert.Equal(t, 3, pool.Config().MaxIdleSize)
+
+	// a single resource was acquired at once
+	clock.Advance(time.Minute)
+	pool.Release(resource)
+	assert.Equal(t, 2, pool.Config().MaxIdleSize)
+	assert.Equal(t, 2, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictCapacity])
+}
+
+func TestNewPool_AutoSizingInBackground(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), 4, time.Hour,
+		WithClock[MockResource](clock),
+		WithWarmup[MockResource](4),
+		WithAutoSizing[MockResource](AutoSizing{Floor: 1, Interval: time.Minute}),
+	)
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 4 }, time.Second, time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		clock.Advance(time.Minute)
+		return pool.NumIdle() == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, pool.Config().MaxIdleSize)
+}
+
+func TestNewPool_AutoSizingGrowsWarmupTarget(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), 2, time.Hour,
+		WithSynchronous[MockResource](),
+		WithClock[MockResource](clock),
+		WithWarmup[MockResource](1),
+		WithAutoSizing[MockResource](AutoSizing{Floor: 1, Interval: time.Minute}),
+	)
+	resources, _ := pool.AcquireN(nil, 3)
+	assert.Equal(t, 4, pool.Config().AutoSizing.Ceiling)
+
+	// two of the three acquires created a resource
+	clock.Advance(time.Minute)
+	pool.Release(resources[0])
+
+	config := pool.Config()
+	assert.Equal(t, 3, config.MaxIdleSize)
+	assert.Equal(t, 2, config.WarmupSize)
+	assert.Equal(t, 2, pool.NumIdle())
+	assert.Equal(t, int64(4), pool.Stats().Created)
+}
+
+func TestGetAutoSize(t *testing.T) {
+	sizing := AutoSizing{Floor: 2, Ceiling: 10}
+
+	testCases := []struct {
+		name         string
+		size         int
+		acquires     int64
+		misses       int64
+		meanWait     time.Duration
+		peakActive   int
+		expectedSize int
+	}{
+		{
+			name:         "with high miss rate grows by a quarter",
+			size:         8,
+			acquires:     10,
+			misses:       2,
+			peakActive:   8,
+			expectedSize: 10,
+		},
+		{
+			name:         "with high miss rate grows up to ceiling",
+			size:         10,
+			acquires:     10,
+			misses:       10,
+			peakActive:   10,
+			expectedSize: 10,
+		},
+		{
+			name:         "with long waits grows by a quarter",
+			size:         8,
+			acquires:     10,
+			meanWait:     20 * time.Millisecond,
+			peakActive:   8,
+			expectedSize: 10,
+		},
+		{
+			name:         "with low miss rate and utilization keeps size",
+			size:         8,
+			acquires:     10,
+			misses:       1,
+			peakActive:   4,
+			expectedSize: 8,
+		},
+		{
+			name:         "with low utilization shrinks by a quarter",
+			size:         8,
+			acquires:     10,
+			peakActive:   3,
+			expectedSize: 6,
+		},
+		{
+			name:         "without demand shrinks down to floor",
+			size:         2,
+			expectedSize: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSize, getAutoSize(tc.size, sizing, tc.acquires, tc.misses, tc.meanWait, tc.peakActive))
+		})
+	}
+}
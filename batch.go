@@ -0,0 +1,139 @@
+package pool
+
+import (
+	"context"
+)
+
+// acquires count resources at once, or none. The pool waits until it has
+// capacity for all of them, instead of holding some while waiting for the
+// rest, so concurrent batch acquires can not deadlock each other. Returns
+// ErrPoolExhausted right away if count exceeds the pool capacity, and with a
+// quota, if the quota can not grant count slots at once. Reentrant scopes and
+// version pins do not apply to batch acquires.
+func (n *NewPool[T]) AcquireN(ctx context.Context, count int) ([]T, error) {
+	defer n.runDueTasks()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var wait acquireWait
+	defer n.recordWait(&wait)
+
+	n.stats.acquires += int64(count)
+	if n.isClosed {
+		return nil, ErrPoolClosed
+	}
+	if n.maxActive > 0 && count > n.maxActive {
+		return nil, ErrPoolExhausted
+	}
+	if err := n.waitResume(ctx, &wait); err != nil {
+		return nil, err
+	}
+
+	for !n.hasCapacityFor(count) {
+		if err := n.waitCapacity(ctx, &wait, count); err != nil {
+			return nil, err
+		}
+		if n.isClosed {
+			return nil, ErrPoolClosed
+		}
+	}
+	n.deleteInvalidIdleResources()
+
+	if !n.takeQuotaN(count) {
+		return nil, ErrPoolExhausted
+	}
+
+	resources := make([]T, 0, count)
+	for len(resources) < count {
+		resource, isSuccess := n.getIdleResource(ctx)
+		if isSuccess {
+			n.stats.reused++
+		} else {
+			var err error
+			if resource, err = n.createResource(ctx); err != nil {
+				n.rollbackAcquireN(resources, count)
+				return nil, err
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	tag := getHolderTag(ctx)
+	for _, resource := range resources {
+		n.handOut(resource, nil, tag)
+	}
+	return resources, nil
+}
+
+// takes count quota slots without waiting; takes none if any is missing
+func (n *NewPool[T]) takeQuotaN(count int) bool {
+	for i := 0; i < count; i++ {
+		if n.quota.take(nil) != nil {
+			for ; i > 0; i-- {
+				n.quota.give()
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// returns the resources of a failed batch acquire to the idle pool and gives
+// back its quota slots
+func (n *NewPool[T]) rollbackAcquireN(resources []T, count int) {
+	for _, resource := range resources {
+		entry := n.lock[resource]
+		n.markInactive(resource, entry)
+		n.returnIdle(resource, entry)
+	}
+	for i := 0; i < count; i++ {
+		n.quota.give()
+	}
+	n.notifyWaiters()
+}
+
+// releases resources back to the pool under a single lock acquisition; the
+// idle pool is swept once for the whole batch. Returns ErrNotAcquired if any
+// resource was not acquired from the pool, after releasing the others.
+func (n *NewPool[T]) ReleaseAll(resources []T) error {
+	defer n.runDueTasks()
+
+	if n.local != nil {
+		var err error
+		for _, resource := range resources {
+			if releaseErr := n.TryRelease(resource); releaseErr != nil {
+				err = releaseErr
+			}
+		}
+		return err
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var err error
+	for _, resource := range resources {
+		if releaseErr := n.release(resource); releaseErr != nil {
+			err = releaseErr
+		}
+	}
+	n.deleteInvalidIdleResources()
+	return err
+}
+
+// destroys acquired resources under a single lock acquisition, e.g. after they
+// failed together. Returns ErrNotAcquired if any resource was not acquired
+// from the pool, after destroying the others.
+func (n *NewPool[T]) DestroyAll(resources []T) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var err error
+	for _, resource := range resources {
+		if invalidateErr := n.invalidate(resource); invalidateErr != nil {
+			err = invalidateErr
+		}
+	}
+	return err
+}
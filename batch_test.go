@@ -0,0 +1,153 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireN(t *testing.T) {
+	testCases := []struct {
+		name              string
+		creator           func(context.Context) (MockResource, error)
+		maxActive         int
+		held              int
+		count             int
+		expectedResources []MockResource
+		expectedError     error
+		expectedActive    int
+	}{
+		{
+			name:              "with capacity acquires all",
+			creator:           getMockCreatorFunc(),
+			maxActive:         3,
+			count:             3,
+			expectedResources: []MockResource{{id: 1}, {id: 2}, {id: 3}},
+			expectedActive:    3,
+		},
+		{
+			name:           "without capacity for all acquires none",
+			creator:        getMockCreatorFunc(),
+			maxActive:      3,
+			held:           2,
+			count:          2,
+			expectedError:  ErrPoolExhausted,
+			expectedActive: 2,
+		},
+		{
+			name:           "above pool capacity fails fast",
+			creator:        getMockCreatorFunc(),
+			maxActive:      3,
+			count:          4,
+			expectedError:  ErrPoolExhausted,
+			expectedActive: 0,
+		},
+		{
+			name:           "with creator error acquires none",
+			creator:        getFailingAfterMockCreatorFunc(1),
+			count:          2,
+			expectedError:  errors.New("error response"),
+			expectedActive: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(tc.creator, maxIdleSize, maxIdleTime, WithMaxActive[MockResource](tc.maxActive))
+			for i := 0; i < tc.held; i++ {
+				pool.Acquire(nil)
+			}
+
+			resources, err := pool.AcquireN(nil, tc.count)
+
+			assert.Equal(t, tc.expectedResources, resources)
+			if tc.expectedError != nil {
+				assert.ErrorContains(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedActive, pool.Stats().Active)
+		})
+	}
+}
+
+func TestNewPool_AcquireNWaitsForCapacity(t *testing.T) {
+	pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](2))
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+
+	acquired := make(chan []MockResource)
+	go func() {
+		resources, _ := pool.AcquireN(context.Background(), 2)
+		acquired <- resources
+	}()
+
+	// one release is not enough for the batch
+	pool.Release(first)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 1, pool.NumIdle())
+
+	pool.Release(second)
+	assert.ElementsMatch(t, []MockResource{first, second}, <-acquired)
+}
+
+func getFailingAfterMockCreatorFunc(successes int) func(context.Context) (MockResource, error) {
+	id := 0
+	return func(ctx context.Context) (MockResource, error) {
+		if id >= successes {
+			return MockResource{}, errors.New("error response")
+		}
+		id += 1
+		return MockResource{id}, nil
+	}
+}
+
+func TestNewPool_ReleaseAll(t *testing.T) {
+	testCases := []struct {
+		name             string
+		extra            []MockResource
+		expectedError    error
+		expectedIdleSize int
+	}{
+		{
+			name:             "releases all resources",
+			expectedIdleSize: 3,
+		},
+		{
+			name:             "with foreign resource releases the others",
+			extra:            []MockResource{{id: 99}},
+			expectedError:    ErrNotAcquired,
+			expectedIdleSize: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mutex := &MockMutex{}
+			mutex.On("Lock").Return()
+			mutex.On("Unlock").Return()
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			resources, _ := pool.AcquireN(nil, 3)
+			pool.mutex = mutex
+
+			err := pool.ReleaseAll(append(resources, tc.extra...))
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedIdleSize, len(pool.unlock))
+			mutex.AssertNumberOfCalls(t, "Lock", 1)
+		})
+	}
+}
+
+func TestNewPool_DestroyAll(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resources, _ := pool.AcquireN(nil, 3)
+
+	err := pool.DestroyAll(append(resources, MockResource{id: 99}))
+
+	assert.ErrorIs(t, err, ErrNotAcquired)
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(3), pool.Stats().Evictions[EvictInvalidated])
+}
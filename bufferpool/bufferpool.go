@@ -0,0 +1,150 @@
+// Package bufferpool pools byte buffers in size classes on top of the
+// resource pool, e.g. for the request and response buffers of HTTP services.
+package bufferpool
+
+import (
+	"bytes"
+	"context"
+	pool "example/ptran"
+	"time"
+)
+
+// Pool pools *bytes.Buffer and *[]byte buffers in size classes doubling from
+// a minimum to a maximum size. Buffers are reset when they are put back and
+// kept in the largest class their capacity covers, so a buffer grown while in
+// use serves larger requests next. Buffers larger than the maximum size are
+// not pooled, so one huge request does not pin its memory.
+//
+// Each size class is a pool with WithLocalCache, so idle buffers are kept
+// until Close, and the content of []byte buffers is not cleared.
+type Pool struct {
+	buffers *classes[*bytes.Buffer]
+	bytes   *classes[*[]byte]
+}
+
+// returns a buffer with a capacity of at least size and no content
+func (p *Pool) Get(size int) *bytes.Buffer {
+	return p.buffers.get(size)
+}
+
+// resets buf and puts it back in the pool; oversized buffers are dropped
+func (p *Pool) Put(buf *bytes.Buffer) {
+	if buf == nil {
+		return
+	}
+
+	buf.Reset()
+	p.buffers.put(buf, buf.Cap())
+}
+
+// returns a slice of length size
+func (p *Pool) GetBytes(size int) *[]byte {
+	b := p.bytes.get(size)
+	*b = (*b)[:size]
+	return b
+}
+
+// truncates b and puts it back in the pool; oversized slices are dropped
+func (p *Pool) PutBytes(b *[]byte) {
+	if b == nil {
+		return
+	}
+
+	*b = (*b)[:0]
+	p.bytes.put(b, cap(*b))
+}
+
+// closes the pools of every size class
+func (p *Pool) Close() {
+	p.buffers.close()
+	p.bytes.close()
+}
+
+// classes is a pool per size class of buffers of type T
+type classes[T comparable] struct {
+	sizes []int
+	pools []*pool.NewPool[T]
+	// create returns a new buffer with a capacity of size
+	create func(size int) T
+}
+
+// returns a buffer of the smallest class covering size; allocates buffers
+// larger than the largest class
+func (c *classes[T]) get(size int) T {
+	for i, classSize := range c.sizes {
+		if classSize < size {
+			continue
+		}
+		if buffer, err := c.pools[i].Acquire(nil); err == nil {
+			return buffer
+		}
+		return c.create(classSize)
+	}
+	return c.create(size)
+}
+
+// puts a buffer back in the largest class its capacity covers; drops buffers
+// larger than the largest class or smaller than the smallest one
+func (c *classes[T]) put(buffer T, capacity int) {
+	if capacity > c.sizes[len(c.sizes)-1] {
+		return
+	}
+
+	for i := len(c.sizes) - 1; i >= 0; i-- {
+		if c.sizes[i] <= capacity {
+			c.pools[i].TryRelease(buffer)
+			return
+		}
+	}
+}
+
+func (c *classes[T]) close() {
+	for _, pool := range c.pools {
+		pool.Close()
+	}
+}
+
+// returns the class sizes doubling from minSize up to maxSize
+func getClassSizes(minSize int, maxSize int) []int {
+	if minSize < 1 {
+		minSize = 1
+	}
+
+	var sizes []int
+	for size := minSize; size < maxSize; size *= 2 {
+		sizes = append(sizes, size)
+	}
+	return append(sizes, maxSize)
+}
+
+func newClasses[T comparable](sizes []int, create func(int) T) *classes[T] {
+	c := &classes[T]{sizes: sizes, create: create}
+	for _, size := range sizes {
+		size := size
+		creator := func(ctx context.Context) (T, error) {
+			return create(size), nil
+		}
+		// idle buffers live in the local cache only, so the idle pool is unused
+		c.pools = append(c.pools, pool.New(creator, -1, time.Minute, pool.WithLocalCache[T]()))
+	}
+	return c
+}
+
+// creates a buffer pool with size classes doubling from minSize to maxSize
+func New(
+	// minSize is the capacity of the smallest size class
+	minSize int,
+	// maxSize is the capacity of the largest size class; larger buffers are not pooled
+	maxSize int,
+) *Pool {
+	sizes := getClassSizes(minSize, maxSize)
+	return &Pool{
+		buffers: newClasses(sizes, func(size int) *bytes.Buffer {
+			return bytes.NewBuffer(make([]byte, 0, size))
+		}),
+		bytes: newClasses(sizes, func(size int) *[]byte {
+			b := make([]byte, 0, size)
+			return &b
+		}),
+	}
+}
@@ -0,0 +1,146 @@
+package bufferpool
+
+import (
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetClassSizes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		minSize       int
+		maxSize       int
+		expectedSizes []int
+	}{
+		{
+			name:          "with power of two bounds doubles up to max size",
+			minSize:       512,
+			maxSize:       4096,
+			expectedSizes: []int{512, 1024, 2048, 4096},
+		},
+		{
+			name:          "with uneven max size ends at max size",
+			minSize:       512,
+			maxSize:       3000,
+			expectedSizes: []int{512, 1024, 2048, 3000},
+		},
+		{
+			name:          "with equal bounds has one class",
+			minSize:       1024,
+			maxSize:       1024,
+			expectedSizes: []int{1024},
+		},
+		{
+			name:          "with non-positive min size starts at one",
+			maxSize:       4,
+			expectedSizes: []int{1, 2, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSizes, getClassSizes(tc.minSize, tc.maxSize))
+		})
+	}
+}
+
+func TestPool_Get(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size        int
+		expectedCap int
+	}{
+		{
+			name:        "below min size gets smallest class",
+			size:        10,
+			expectedCap: 512,
+		},
+		{
+			name:        "between classes gets next class",
+			size:        513,
+			expectedCap: 1024,
+		},
+		{
+			name:        "above max size gets unpooled buffer",
+			size:        10000,
+			expectedCap: 10000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffers := New(512, 4096)
+
+			buf := buffers.Get(tc.size)
+			b := buffers.GetBytes(tc.size)
+
+			assert.Equal(t, 0, buf.Len())
+			assert.Equal(t, tc.expectedCap, buf.Cap())
+			assert.Len(t, *b, tc.size)
+			assert.Equal(t, tc.expectedCap, cap(*b))
+		})
+	}
+}
+
+func TestPool_Put(t *testing.T) {
+	buffers := New(512, 4096)
+	buf := buffers.Get(512)
+	buf.WriteString("GET / HTTP/1.1\r\n")
+
+	buffers.Put(buf)
+
+	assert.Equal(t, 0, buf.Len())
+	// the local cache may drop buffers at any time, so a hit is not guaranteed
+	if reused := buffers.Get(512); reused == buf {
+		assert.Equal(t, 0, reused.Len())
+	}
+}
+
+func TestClasses_Put(t *testing.T) {
+	testCases := []struct {
+		name          string
+		capacity      int
+		expectedClass int
+	}{
+		{
+			name:          "within class keeps its class",
+			capacity:      1024,
+			expectedClass: 1,
+		},
+		{
+			name:          "grown past class moves to largest covered class",
+			capacity:      3000,
+			expectedClass: 2,
+		},
+		{
+			name:          "above max size is dropped",
+			capacity:      5000,
+			expectedClass: -1,
+		},
+		{
+			name:          "below min size is dropped",
+			capacity:      100,
+			expectedClass: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffers := New(512, 4096)
+			buffers.Close()
+			b := make([]byte, 0, tc.capacity)
+
+			// puts into closed class pools are evicted, which stats record per class
+			buffers.PutBytes(&b)
+
+			for i, classPool := range buffers.bytes.pools {
+				var expectedEvictions int64
+				if i == tc.expectedClass {
+					expectedEvictions = 1
+				}
+				assert.Equal(t, expectedEvictions, classPool.Stats().Evictions[pool.EvictClosed])
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ Pool[PoolResource] = &ChannelPool[PoolResource]{}
+
+// ChannelPool is a lightweight pool for simple fixed-size pools: idle
+// resources wait in a buffered channel and capacity is a semaphore, so there
+// is no per-resource bookkeeping and waiting acquires block on the channels
+// directly. Resources are not tracked while acquired, so releasing a resource
+// which was not acquired from the pool is not detected.
+type ChannelPool[T comparable] struct {
+	creator     func(context.Context) (T, error)
+	maxIdleTime time.Duration
+	destroyer   func(T) error
+	clock       Clock
+	idle        chan idleResource[T]
+	tokens      semaphore
+	mutex       sync.RWMutex
+	isClosed    bool
+}
+
+// idleResource is a resource waiting in the idle channel
+type idleResource[T any] struct {
+	resource   T
+	releasedAt time.Time
+}
+
+// returns an idle resource, or creates one if none is available, waiting for
+// a release while the pool is at capacity
+func (c *ChannelPool[T]) Acquire(ctx context.Context) (T, error) {
+	if c.getIsClosed() {
+		return *new(T), ErrPoolClosed
+	}
+	if err := c.tokens.take(ctx); err != nil {
+		return *new(T), err
+	}
+
+	validTimestamp := c.clock.Now().Add(-1 * c.maxIdleTime)
+	for {
+		select {
+		case idle := <-c.idle:
+			if idle.releasedAt.Before(validTimestamp) {
+				c.destroy(idle.resource)
+				continue
+			}
+			return idle.resource, nil
+		default:
+		}
+		break
+	}
+
+	resource, err := c.creator(ctx)
+	if err != nil {
+		c.tokens.give()
+		return *new(T), &CreateError{Err: err}
+	}
+	return resource, nil
+}
+
+// releases an active resource back to the idle channel, destroying it if the
+// channel is full or the pool is closed
+func (c *ChannelPool[T]) Release(resource T) {
+	defer c.tokens.give()
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.isClosed {
+		c.destroy(resource)
+		return
+	}
+
+	select {
+	case c.idle <- idleResource[T]{resource: resource, releasedAt: c.clock.Now()}:
+	default:
+		c.destroy(resource)
+	}
+}
+
+// returns the number of idle items
+func (c *ChannelPool[T]) NumIdle() int {
+	return len(c.idle)
+}
+
+// destroys the idle resources and rejects further acquires; resources
+// released afterwards are destroyed
+func (c *ChannelPool[T]) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isClosed = true
+	for {
+		select {
+		case idle := <-c.idle:
+			c.destroy(idle.resource)
+		default:
+			return
+		}
+	}
+}
+
+func (c *ChannelPool[T]) getIsClosed() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.isClosed
+}
+
+func (c *ChannelPool[T]) destroy(resource T) {
+	if c.destroyer != nil {
+		c.destroyer(resource)
+	}
+}
+
+// NewChannel creates a ChannelPool. Of the options, it supports WithMaxActive,
+// WithDestroyer and WithClock, and CompatibilityV1 only keeps io.Closer
+// resources from being closed; other options have no effect.
+func NewChannel[T comparable](
+	// creator is a function called by the pool to create a resource.
+	creator func(context.Context) (T, error),
+	// maxIdleSize is the number of maximum idle items kept in the pool; zero
+	// is derived from GOMAXPROCS, a negative size keeps no idle items
+	maxIdleSize int,
+	// maxIdleTime is the maximum idle time for an idle item to be swept from the pool
+	maxIdleTime time.Duration,
+	// options configure optional behavior
+	options ...Option[T],
+) *ChannelPool[T] {
+	config := &NewPool[T]{}
+	for _, option := range options {
+		option(config)
+	}
+	destroyer := config.destroyer
+	if !config.compatibilityV1 {
+		destroyer = config.getDestroyer()
+	}
+
+	return &ChannelPool[T]{
+		creator:     creator,
+		maxIdleTime: maxIdleTime,
+		destroyer:   destroyer,
+		clock:       config.getClock(),
+		idle:        make(chan idleResource[T], getMaxIdleSize(maxIdleSize)),
+		tokens:      newSemaphore(config.maxActive),
+	}
+}
@@ -0,0 +1,140 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestChannelPool_Acquire(t *testing.T) {
+	testCases := []struct {
+		name             string
+		advance          time.Duration
+		expectedResource MockResource
+		expectedDestroys int
+	}{
+		{
+			name:             "reuses idle resource",
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "destroys expired idle resource",
+			advance:          maxIdleTime + time.Nanosecond,
+			expectedResource: MockResource{id: 2},
+			expectedDestroys: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			destroys := 0
+			pool := NewChannel(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithDestroyer(func(MockResource) error { destroys++; return nil }),
+			)
+
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			clock.Advance(tc.advance)
+
+			resource, err := pool.Acquire(nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedDestroys, destroys)
+		})
+	}
+}
+
+func TestChannelPool_Release(t *testing.T) {
+	destroys := 0
+	pool := NewChannel(getAtomicMockCreatorFunc(), 1, maxIdleTime,
+		WithDestroyer(func(MockResource) error { destroys++; return nil }),
+	)
+
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+	pool.Release(second)
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, 1, destroys)
+}
+
+func TestChannelPool_MaxActive(t *testing.T) {
+	pool := NewChannel(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+	first, _ := pool.Acquire(nil)
+
+	_, err := pool.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pool.Release(first)
+
+	assert.Equal(t, first, <-acquired)
+}
+
+func TestChannelPool_Close(t *testing.T) {
+	destroys := 0
+	pool := NewChannel(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithDestroyer(func(MockResource) error { destroys++; return nil }),
+	)
+	idle, _ := pool.Acquire(nil)
+	active, _ := pool.Acquire(nil)
+	pool.Release(idle)
+
+	pool.Close()
+	pool.Release(active)
+	_, err := pool.Acquire(nil)
+
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, 2, destroys)
+}
+
+func BenchmarkChannelPool_AcquireRelease(b *testing.B) {
+	pool := NewChannel(getAtomicMockCreatorFunc(), 1024, time.Minute)
+	benchmarkAcquireRelease(b, pool)
+}
+
+func TestNewChannel_ClosesCloserResources(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        []Option[*closableResource]
+		expectedCloses int
+	}{
+		{
+			name:           "without destroyer closes resources",
+			expectedCloses: 1,
+		},
+		{
+			name:           "with compatibility mode does not close resources",
+			options:        []Option[*closableResource]{CompatibilityV1[*closableResource]()},
+			expectedCloses: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closes := 0
+			creator := func(context.Context) (*closableResource, error) {
+				return &closableResource{closes: &closes}, nil
+			}
+			pool := NewChannel(creator, maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			pool.Close()
+
+			assert.Equal(t, tc.expectedCloses, closes)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package pool
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Chaos configures the faults injected by WithChaos. Rates are probabilities
+// between 0 and 1; a zero rate disables the fault.
+type Chaos struct {
+	// CreateDelay is the time creations are delayed by, at CreateDelayRate
+	CreateDelay time.Duration
+	// CreateDelayRate is the probability of a creation being delayed
+	CreateDelayRate float64
+	// ValidationFailureRate is the probability of a released resource
+	// failing its validation, so it is destroyed instead of being reused
+	ValidationFailureRate float64
+	// ExpireRate is the probability of an idle resource being expired when
+	// an acquire comes across it, so the acquire moves on to another one
+	ExpireRate float64
+	// Random returns a random number in [0, 1) and must be safe for
+	// concurrent use; defaults to rand.Float64
+	Random func() float64
+}
+
+// WithChaos injects faults into the pool, e.g. to prove a service degrades
+// gracefully when the pool misbehaves: creations are delayed, released
+// resources fail validation and idle resources expire early, at the rates of
+// chaos. Destroyed resources are evicted with EvictChaos. It is meant for
+// tests and game days, not production traffic.
+func WithChaos[T comparable](chaos Chaos) Option[T] {
+	return func(n *NewPool[T]) {
+		if chaos.Random == nil {
+			chaos.Random = rand.Float64
+		}
+		n.chaos = &chaos
+	}
+}
+
+// reports whether a creation is delayed
+func (c *Chaos) isCreateDelayed() bool {
+	return c != nil && c.isInjected(c.CreateDelayRate)
+}
+
+// reports whether a released resource fails its validation
+func (c *Chaos) isValidationFailed() bool {
+	return c != nil && c.isInjected(c.ValidationFailureRate)
+}
+
+// reports whether an idle resource expires early
+func (c *Chaos) isExpired() bool {
+	return c != nil && c.isInjected(c.ExpireRate)
+}
+
+// reports whether a fault of the given rate is injected
+func (c *Chaos) isInjected(rate float64) bool {
+	return rate > 0 && c.Random() < rate
+}
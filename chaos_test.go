@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Chaos(t *testing.T) {
+	testCases := []struct {
+		name            string
+		chaos           Chaos
+		expectedCreated int64
+		expectedChaos   int64
+	}{
+		{
+			name:            "without faults reuses released resource",
+			chaos:           Chaos{},
+			expectedCreated: 1,
+		},
+		{
+			name:            "with validation failures destroys released resource",
+			chaos:           Chaos{ValidationFailureRate: 0.5},
+			expectedCreated: 2,
+			expectedChaos:   1,
+		},
+		{
+			name:            "with early expiry destroys idle resource",
+			chaos:           Chaos{ExpireRate: 0.5},
+			expectedCreated: 2,
+			expectedChaos:   1,
+		},
+		{
+			name:            "with rate below random number injects nothing",
+			chaos:           Chaos{ValidationFailureRate: 0.1, ExpireRate: 0.1},
+			expectedCreated: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.chaos.Random = func() float64 { return 0.25 }
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithChaos[MockResource](tc.chaos))
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			pool.Acquire(nil)
+
+			stats := pool.Stats()
+			assert.Equal(t, tc.expectedCreated, stats.Created)
+			assert.Equal(t, tc.expectedChaos, stats.Evictions[EvictChaos])
+		})
+	}
+}
+
+func TestNewPool_ChaosCreateDelay(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithChaos[MockResource](Chaos{
+			CreateDelay:     time.Second,
+			CreateDelayRate: 1,
+			Random:          func() float64 { return 0 },
+		}),
+	)
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(nil)
+		acquired <- resource
+	}()
+
+	assert.Eventually(t, func() bool {
+		clock.Advance(100 * time.Millisecond)
+		select {
+		case <-acquired:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(t, clock.Now().Sub(time.Unix(0, 0)), time.Second)
+}
@@ -0,0 +1,76 @@
+package pool
+
+import (
+	"time"
+)
+
+// Clock is the source of time used by the pool for expiry, scheduling and
+// stats; replace it to test time-dependent behavior without sleeping
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	// AfterFunc calls f in its own goroutine after d; see time.AfterFunc
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by a Clock
+type Timer interface {
+	// C returns the channel receiving the time when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing; see time.Timer.Stop
+	Stop() bool
+}
+
+// WithClock sets the clock of the pool; by default it uses the system clock
+func WithClock[T comparable](clock Clock) Option[T] {
+	return func(n *NewPool[T]) {
+		n.clock = clock
+	}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (n *NewPool[T]) getClock() Clock {
+	if n.clock == nil {
+		return realClock{}
+	}
+
+	return n.clock
+}
+
+func (n *NewPool[T]) now() time.Time {
+	return n.getClock().Now()
+}
+
+// blocks for d on the pool clock
+func (n *NewPool[T]) sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	<-n.getClock().NewTimer(d).C()
+}
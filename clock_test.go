@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type MockClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*mockTimer
+}
+
+type mockTimer struct {
+	clock    *MockClock
+	c        chan time.Time
+	f        func()
+	deadline time.Time
+	isDone   bool
+}
+
+func (t *mockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *mockTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	isActive := !t.isDone
+	t.isDone = true
+	return isActive
+}
+
+func (c *MockClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *MockClock) NewTimer(d time.Duration) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	timer := &mockTimer{clock: c, c: make(chan time.Time, 1), deadline: c.now.Add(d)}
+	c.timers = append(c.timers, timer)
+	c.fire()
+	return timer
+}
+
+func (c *MockClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	timer := &mockTimer{clock: c, f: f, deadline: c.now.Add(d)}
+	c.timers = append(c.timers, timer)
+	c.fire()
+	return timer
+}
+
+func (c *MockClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+func (c *MockClock) fire() {
+	for _, timer := range c.timers {
+		if !timer.isDone && !timer.deadline.After(c.now) {
+			timer.isDone = true
+			if timer.f != nil {
+				go timer.f()
+			} else {
+				timer.c <- c.now
+			}
+		}
+	}
+}
+
+func TestNewPool_AcquireWithClock(t *testing.T) {
+	testCases := []struct {
+		name             string
+		advance          time.Duration
+		expectedResource MockResource
+		expectedEvicted  int64
+	}{
+		{
+			name:             "before max idle time reuses idle resource",
+			advance:          maxIdleTime - time.Nanosecond,
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "after max idle time evicts idle resource",
+			advance:          maxIdleTime + time.Nanosecond,
+			expectedResource: MockResource{id: 2},
+			expectedEvicted:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			clock.Advance(tc.advance)
+
+			resource, _ = pool.Acquire(nil)
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedEvicted, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
+
+func TestNewPool_WarmupWithClock(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(
+		getMockCreatorFunc(),
+		maxIdleSize,
+		maxIdleTime,
+		WithClock[MockResource](clock),
+		WithWarmup[MockResource](2),
+		WithStartupRamp[MockResource](time.Minute),
+	)
+
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, pool.NumIdle(), 1)
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 2 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"context"
+	"time"
+)
+
+// closes the pool like Close, then waits until every acquired resource is
+// released or ctx is done. With a nil ctx it returns ErrPoolExhausted instead
+// of waiting if resources are still acquired. Emits EventClosedGraceful.
+func (n *NewPool[T]) CloseGraceful(ctx context.Context) error {
+	n.Close()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	err := n.waitReleases(ctx)
+	n.publish(EventClosedGraceful, &resourceEntry{}, err)
+	return err
+}
+
+// closes the pool like Close, then waits up to d for every acquired resource
+// to be released, like Shutdown with a d timeout
+func (n *NewPool[T]) CloseWithin(d time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := n.getClock().AfterFunc(d, cancel)
+	defer timer.Stop()
+
+	n.Shutdown(ctx)
+}
+
+// closes the pool like Close, then waits until every acquired resource is
+// released or ctx is done, e.g. for a rolling deploy. Resources still acquired
+// once ctx is done are destroyed as orphaned and their leases ended, which
+// tells the holders through the lease contexts to stop using them; the ctx
+// error is returned then. With a nil ctx they are destroyed right away.
+// Emits EventClosedBounded.
+func (n *NewPool[T]) Shutdown(ctx context.Context) error {
+	n.Close()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	err := n.waitReleases(ctx)
+	if err != nil {
+		n.orphanActive()
+	}
+	n.publish(EventClosedBounded, &resourceEntry{}, err)
+	return err
+}
+
+// closes the pool like Close, and destroys the acquired resources right away
+// as orphaned, ending their leases. Emits EventClosedImmediate.
+func (n *NewPool[T]) CloseNow() {
+	n.Close()
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.orphanActive()
+	n.publish(EventClosedImmediate, &resourceEntry{}, nil)
+}
+
+// waits until no resource is acquired; needs the pool mutex held
+func (n *NewPool[T]) waitReleases(ctx context.Context) error {
+	for len(n.lock) > 0 {
+		if err := n.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destroys the acquired resources with EvictOrphaned and ends their leases
+// with ErrPoolClosed; their later release is ignored
+func (n *NewPool[T]) orphanActive() {
+	for resource, entry := range n.lock {
+		if lease, isFound := n.leases[resource]; isFound {
+			delete(n.leases, resource)
+			lease.expire(ErrPoolClosed)
+		}
+		n.deactivate(resource, entry)
+		if n.orphaned == nil {
+			n.orphaned = make(map[T]struct{})
+		}
+		n.orphaned[resource] = struct{}{}
+		n.evict(resource, entry, EvictOrphaned)
+	}
+	n.notifyWaiters()
+}
@@ -0,0 +1,187 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_CloseGraceful(t *testing.T) {
+	testCases := []struct {
+		name                string
+		ctx                 context.Context
+		isReleased          bool
+		expectedError       error
+		expectedEvictReason EvictReason
+	}{
+		{
+			name:                "waits for releases",
+			ctx:                 context.Background(),
+			isReleased:          true,
+			expectedEvictReason: EvictClosed,
+		},
+		{
+			name:          "with nil ctx and acquired resource fails fast",
+			expectedError: ErrPoolExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			events := pool.Subscribe()
+			resource, _ := pool.Acquire(nil)
+			if tc.isReleased {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					pool.Release(resource)
+				}()
+			}
+
+			err := pool.CloseGraceful(tc.ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, EventClosedGraceful, getLastEvent(events).Type)
+			if tc.expectedEvictReason != "" {
+				assert.Equal(t, int64(1), pool.Stats().Evictions[tc.expectedEvictReason])
+			}
+		})
+	}
+}
+
+func TestNewPool_CloseWithin(t *testing.T) {
+	testCases := []struct {
+		name                string
+		bound               time.Duration
+		isReleased          bool
+		expectedEvictReason EvictReason
+	}{
+		{
+			name:                "with release in time destroys released resource",
+			bound:               time.Second,
+			isReleased:          true,
+			expectedEvictReason: EvictClosed,
+		},
+		{
+			name:                "past bound orphans acquired resource",
+			bound:               10 * time.Millisecond,
+			expectedEvictReason: EvictOrphaned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := &MockLogger{}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithLogger[MockResource](logger))
+			events := pool.Subscribe()
+			lease, _ := pool.AcquireLease(nil)
+			if tc.isReleased {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					lease.Release()
+				}()
+			}
+
+			pool.CloseWithin(tc.bound)
+			lease.Release()
+
+			assert.Equal(t, EventClosedBounded, getLastEvent(events).Type)
+			assert.Equal(t, int64(1), pool.Stats().Evictions[tc.expectedEvictReason])
+			assert.Error(t, lease.Context().Err())
+			assert.Empty(t, logger.entries)
+		})
+	}
+}
+
+func TestNewPool_CloseNow(t *testing.T) {
+	logger := &MockLogger{}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithLogger[MockResource](logger))
+	events := pool.Subscribe()
+	resource, _ := pool.Acquire(nil)
+	lease, _ := pool.AcquireLease(nil)
+
+	pool.CloseNow()
+
+	assert.Equal(t, EventClosedImmediate, getLastEvent(events).Type)
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictOrphaned])
+	assert.Equal(t, 0, pool.Stats().Active)
+	assert.Error(t, lease.Context().Err())
+	assert.ErrorIs(t, lease.Extend(time.Minute), ErrLeaseExpired)
+	assert.NoError(t, pool.TryRelease(resource))
+	assert.ErrorIs(t, pool.TryRelease(resource), ErrNotAcquired)
+	lease.Release()
+	assert.Len(t, logger.entries, 1)
+}
+
+// returns the last event buffered in events
+func getLastEvent(events <-chan Event) Event {
+	var event Event
+	for len(events) > 0 {
+		event = <-events
+	}
+	return event
+}
+
+func TestNewPool_Shutdown(t *testing.T) {
+	testCases := []struct {
+		name                string
+		getContext          func() (context.Context, context.CancelFunc)
+		isReleased          bool
+		expectedError       error
+		expectedEvictReason EvictReason
+	}{
+		{
+			name: "with release before deadline destroys released resource",
+			getContext: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Second)
+			},
+			isReleased:          true,
+			expectedEvictReason: EvictClosed,
+		},
+		{
+			name: "past deadline orphans acquired resource",
+			getContext: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			expectedError:       ErrAcquireTimeout,
+			expectedEvictReason: EvictOrphaned,
+		},
+		{
+			name: "with nil ctx orphans acquired resource",
+			getContext: func() (context.Context, context.CancelFunc) {
+				return nil, func() {}
+			},
+			expectedError:       ErrPoolExhausted,
+			expectedEvictReason: EvictOrphaned,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			events := pool.Subscribe()
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			resource, _ = pool.Acquire(nil)
+			if tc.isReleased {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					pool.Release(resource)
+				}()
+			}
+			ctx, cancel := tc.getContext()
+			defer cancel()
+
+			err := pool.Shutdown(ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			event := getLastEvent(events)
+			assert.Equal(t, EventClosedBounded, event.Type)
+			assert.ErrorIs(t, event.Err, tc.expectedError)
+			assert.Equal(t, int64(1), pool.Stats().Evictions[tc.expectedEvictReason])
+			_, err = pool.Acquire(nil)
+			assert.ErrorIs(t, err, ErrPoolClosed)
+		})
+	}
+}
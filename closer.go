@@ -0,0 +1,34 @@
+package pool
+
+import (
+	"io"
+	"reflect"
+)
+
+var closerType = reflect.TypeOf((*io.Closer)(nil)).Elem()
+
+// returns the destroyer used without WithDestroyer: one calling Close when T
+// implements io.Closer, nil otherwise. A Close with a pointer receiver on a
+// value resource would close a copy of it, so it is not detected.
+func getCloser[T any]() func(T) error {
+	if !reflect.TypeOf((*T)(nil)).Elem().Implements(closerType) {
+		return nil
+	}
+
+	return func(resource T) error {
+		// a nil interface resource holds nothing to close
+		if closer, isCloser := any(resource).(io.Closer); isCloser {
+			return closer.Close()
+		}
+		return nil
+	}
+}
+
+// returns the destroyer set by WithDestroyer, or one closing io.Closer
+// resources
+func (n *NewPool[T]) getDestroyer() func(T) error {
+	if n.destroyer != nil {
+		return n.destroyer
+	}
+	return getCloser[T]()
+}
@@ -0,0 +1,111 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+// closableResource is a resource implementing io.Closer
+type closableResource struct {
+	closes *int
+}
+
+func (r *closableResource) Close() error {
+	*r.closes++
+	return nil
+}
+
+func TestNew_ClosesCloserResources(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        []Option[*closableResource]
+		expectedCloses int
+	}{
+		{
+			name:           "without destroyer closes evicted, invalidated and closed resources",
+			expectedCloses: 3,
+		},
+		{
+			name: "with destroyer does not close resources",
+			options: []Option[*closableResource]{
+				WithDestroyer(func(*closableResource) error { return nil }),
+			},
+			expectedCloses: 0,
+		},
+		{
+			name:           "with compatibility mode does not close resources",
+			options:        []Option[*closableResource]{CompatibilityV1[*closableResource]()},
+			expectedCloses: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closes := 0
+			creator := func(context.Context) (*closableResource, error) {
+				return &closableResource{closes: &closes}, nil
+			}
+			pool := New(creator, 1, maxIdleTime, tc.options...)
+			var held []*closableResource
+			for i := 0; i < 3; i++ {
+				resource, _ := pool.Acquire(nil)
+				held = append(held, resource)
+			}
+
+			pool.Release(held[0])
+			pool.Release(held[1])
+			pool.Invalidate(held[2])
+			pool.Close()
+
+			assert.Equal(t, tc.expectedCloses, closes)
+		})
+	}
+}
+
+func TestGetCloser(t *testing.T) {
+	testCases := []struct {
+		name           string
+		destroy        func(closes *int) error
+		expectedCloses int
+	}{
+		{
+			name: "with closer pointer closes resource",
+			destroy: func(closes *int) error {
+				return getCloser[*closableResource]()(&closableResource{closes: closes})
+			},
+			expectedCloses: 1,
+		},
+		{
+			name: "with closer interface closes resource",
+			destroy: func(closes *int) error {
+				return getCloser[io.Closer]()(&closableResource{closes: closes})
+			},
+			expectedCloses: 1,
+		},
+		{
+			name: "with nil closer interface does nothing",
+			destroy: func(*int) error {
+				return getCloser[io.Closer]()(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closes := 0
+
+			err := tc.destroy(&closes)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCloses, closes)
+		})
+	}
+}
+
+func TestGetCloser_WithoutCloser(t *testing.T) {
+	assert.Nil(t, getCloser[MockResource]())
+	// Close of *closableResource would only close a copy of the value
+	assert.Nil(t, getCloser[closableResource]())
+}
@@ -0,0 +1,111 @@
+package pool
+
+import (
+	"context"
+)
+
+// WithCreateCoalescing keeps concurrent misses from stampeding the backend:
+// at most one creation of a miss is in flight at a time, run without the pool
+// mutex, and the misses meanwhile wait for it instead of dialing their own.
+// The miss which started the creation takes the created resource; the others
+// take the next release, or once the creation is done, return its error if it
+// failed, or start the next creation. Misses while warmup creations are under
+// way wait for them to land in the idle pool, one miss per creation. For a
+// KeyedPool, see WithKeyCoalescing.
+func WithCreateCoalescing[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isCoalescing = true
+	}
+}
+
+// WithKeyCoalescing coalesces the creations of each key, as set by
+// WithCreateCoalescing, so concurrent misses of a key share one creation
+func WithKeyCoalescing[K comparable, T comparable]() KeyedOption[K, T] {
+	return WithKeyOptions[K, T](WithCreateCoalescing[T]())
+}
+
+// records the creations finished so far by acquires, before an acquire
+// queues for the pool mutex
+func (n *NewPool[T]) startCoalescing(wait *acquireWait) {
+	if n.isCoalescing {
+		wait.createSeq = n.createSeq.Load()
+	}
+}
+
+// returns the error of a creation which failed while the acquire queued for
+// the pool mutex, as the last creation; nil if the acquire should create
+func (n *NewPool[T]) getCoalescedError(wait *acquireWait) error {
+	if !n.isCoalescing || n.lastCreateErr == nil || n.lastCreateSeq <= wait.createSeq {
+		return nil
+	}
+
+	n.recordCoalesced(wait)
+	return n.lastCreateErr
+}
+
+// waits for the creation of another miss to finish, or for a warmup creation
+// to land in the idle pool, if one is under way which no other miss waits
+// for; reports whether the acquire waited
+func (n *NewPool[T]) joinCreation(ctx context.Context, wait *acquireWait) (bool, error) {
+	if !n.isCoalescing || ctx == nil {
+		return false, nil
+	}
+	if n.missCreating > 0 {
+		n.recordCoalesced(wait)
+		return true, n.waitAcquire(ctx, wait)
+	}
+	if n.joined >= n.idleCreating {
+		return false, nil
+	}
+
+	n.recordCoalesced(wait)
+	n.joined++
+	defer func() { n.joined-- }()
+	return true, n.waitAcquire(ctx, wait)
+}
+
+// records the outcome of a creation of an acquire for the acquires queued
+// meanwhile
+func (n *NewPool[T]) recordCoalescedCreate(err error) {
+	n.lastCreateSeq = n.createSeq.Add(1)
+	n.lastCreateErr = err
+}
+
+// counts an acquire as coalesced, once however often it joins creations
+func (n *NewPool[T]) recordCoalesced(wait *acquireWait) {
+	if !wait.isCoalesced {
+		wait.isCoalesced = true
+		n.stats.coalesced++
+	}
+}
+
+// creates a resource for a miss and marks it as acquired, releasing the pool
+// mutex meanwhile, so that the misses queued behind it join the creation
+func (n *NewPool[T]) createCoalesced(ctx context.Context) (T, error) {
+	start := n.now()
+	n.createRate.take(start)
+	creator, generation := n.creator, n.generation
+	n.missCreating++
+	n.mutex.Unlock()
+	resource, err := n.create(ctx, creator)
+	n.mutex.Lock()
+	n.missCreating--
+	n.notifyWaiters()
+
+	if err != nil {
+		createErr := n.recordCreateFailure(err, n.now().Sub(start))
+		n.recordCoalescedCreate(createErr)
+		return *new(T), createErr
+	}
+	n.recordCoalescedCreate(nil)
+
+	entry := &resourceEntry{createdAt: n.now(), generation: generation}
+	n.recordCreate(resource, entry, entry.createdAt.Sub(start))
+	if n.isClosed {
+		n.evict(resource, entry, EvictClosed)
+		return *new(T), ErrPoolClosed
+	}
+	entry.acquiredAt = entry.createdAt
+	n.markActive(resource, entry)
+	return resource, nil
+}
@@ -0,0 +1,173 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithCreateCoalescingSharesFailure(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var calls atomic.Int64
+	creator := func(context.Context) (MockResource, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-unblock
+		}
+		return MockResource{}, errors.New("error response")
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime, WithCreateCoalescing[MockResource]())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+
+	close(unblock)
+
+	first, second := <-errs, <-errs
+	assert.Error(t, first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Equal(t, int64(1), pool.Stats().Coalesced)
+
+	_, err := pool.Acquire(nil)
+	assert.Error(t, err)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestNewPool_AcquireWithCreateCoalescingJoinsWarmup(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []Option[MockResource]
+		expected        MockResource
+		expectedCreated int64
+	}{
+		{
+			name:            "without coalescing creates",
+			expected:        MockResource{id: 2},
+			expectedCreated: 2,
+		},
+		{
+			name:            "with coalescing takes warmup resource",
+			options:         []Option[MockResource]{WithCreateCoalescing[MockResource]()},
+			expected:        MockResource{id: 1},
+			expectedCreated: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unblock := make(chan struct{})
+			mockCreator := getAtomicMockCreatorFunc()
+			creator := func(ctx context.Context) (MockResource, error) {
+				resource, err := mockCreator(ctx)
+				if resource.id == 1 {
+					<-unblock
+				}
+				return resource, err
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime,
+				append([]Option[MockResource]{WithWarmup[MockResource](1)}, tc.options...)...,
+			)
+			assert.Eventually(t, func() bool { return pool.Stats().Creating == 1 }, time.Second, time.Millisecond)
+			acquired := make(chan MockResource)
+			go func() {
+				resource, _ := pool.Acquire(context.Background())
+				acquired <- resource
+			}()
+			if len(tc.options) > 0 {
+				assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+			}
+
+			if len(tc.options) == 0 {
+				assert.Equal(t, tc.expected, <-acquired)
+				close(unblock)
+			} else {
+				close(unblock)
+				assert.Equal(t, tc.expected, <-acquired)
+			}
+			assert.Eventually(t, func() bool { return pool.Stats().Created == tc.expectedCreated }, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestNewPool_AcquireWithCreateCoalescingTakesRelease(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var calls atomic.Int64
+	creator := func(context.Context) (MockResource, error) {
+		id := calls.Add(1)
+		if id == 2 {
+			close(started)
+			<-unblock
+		}
+		return MockResource{id: int(id)}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime, WithCreateCoalescing[MockResource]())
+	held, _ := pool.Acquire(nil)
+	leader := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		leader <- resource
+	}()
+	<-started
+	follower := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		follower <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int64(2), calls.Load())
+
+	pool.Release(held)
+
+	assert.Equal(t, MockResource{id: 1}, <-follower)
+	close(unblock)
+	assert.Equal(t, MockResource{id: 2}, <-leader)
+	assert.Equal(t, int64(2), calls.Load())
+	assert.Equal(t, int64(1), pool.Stats().Coalesced)
+}
+
+func TestNewPool_AcquireWithCreateCoalescingCreatesAfterCreation(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var calls atomic.Int64
+	creator := func(context.Context) (MockResource, error) {
+		id := calls.Add(1)
+		if id == 1 {
+			close(started)
+			<-unblock
+		}
+		return MockResource{id: int(id)}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime, WithCreateCoalescing[MockResource]())
+	acquired := make(chan MockResource, 2)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	<-started
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int64(1), calls.Load())
+
+	close(unblock)
+
+	assert.NotEqual(t, <-acquired, <-acquired)
+	assert.Equal(t, int64(2), calls.Load())
+	assert.Equal(t, 2, pool.Stats().Active)
+}
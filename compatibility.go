@@ -0,0 +1,32 @@
+package pool
+
+// CompatibilityV1 keeps the semantics of the original pool whatever other
+// options are given: Acquire never blocks and creates resources without limit,
+// expired idle resources are only swept by Acquire, and Release never reports
+// failures. Options that would change these semantics are ignored; new
+// behaviors have to be opted into by dropping this option.
+func CompatibilityV1[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.compatibilityV1 = true
+	}
+}
+
+// resets the options which conflict with the v1 semantics
+func (n *NewPool[T]) applyCompatibilityV1() {
+	// pinned acquires wait for a resource of the pinned version
+	n.versioner = nil
+	// acquires wait while the pool is at capacity
+	n.maxActive = 0
+	n.maxCost = 0
+	n.quota = nil
+	// acquires wait for a creation slot
+	n.createRate = nil
+	// nested acquires in a scope share one resource
+	n.isReentrant = false
+	// released resources skip the idle pool and its expiry
+	n.isLocalCached = false
+	// the idle pool is only swept by Acquire
+	n.sizing = AutoSizing{}
+	n.reapInterval = 0
+	n.isPressured = nil
+}
@@ -0,0 +1,103 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCompatibilityV1(t *testing.T) {
+	testCases := []struct {
+		name              string
+		options           []Option[VersionedResource]
+		expectedResource  VersionedResource
+		expectedError     error
+		expectedVersioner bool
+	}{
+		{
+			name:              "without compatibility mode waits for pinned version",
+			options:           []Option[VersionedResource]{WithVersion(getVersionedResourceVersion)},
+			expectedError:     ErrAcquireTimeout,
+			expectedVersioner: true,
+		},
+		{
+			name: "with compatibility mode ignores version pinning",
+			options: []Option[VersionedResource]{
+				WithVersion(getVersionedResourceVersion),
+				CompatibilityV1[VersionedResource](),
+			},
+			expectedResource: VersionedResource{id: 1, version: "v2"},
+		},
+		{
+			name: "with compatibility mode before other options ignores version pinning",
+			options: []Option[VersionedResource]{
+				CompatibilityV1[VersionedResource](),
+				WithVersion(getVersionedResourceVersion),
+			},
+			expectedResource: VersionedResource{id: 1, version: "v2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getVersionedCreatorFunc("v2"), maxIdleSize, maxIdleTime, tc.options...)
+
+			ctx, cancel := context.WithTimeout(PinVersion(context.Background()), 10*time.Millisecond)
+			defer cancel()
+			ctx.Value(versionPinKey{}).(*versionPin).set("v1")
+
+			resource, err := pool.Acquire(ctx)
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedVersioner, pool.versioner != nil)
+		})
+	}
+}
+
+func TestCompatibilityV1MaxIdleSize(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		options                []Option[MockResource]
+		expectedIdlePoolLength int
+	}{
+		{
+			name:                   "without compatibility mode zero keeps default idle size",
+			expectedIdlePoolLength: 1,
+		},
+		{
+			name:                   "with compatibility mode zero keeps no idle resources",
+			options:                []Option[MockResource]{CompatibilityV1[MockResource]()},
+			expectedIdlePoolLength: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), 0, maxIdleTime, tc.options...)
+
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+		})
+	}
+}
+
+func TestCompatibilityV1BackgroundSweeps(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithReaper[MockResource](time.Second, 0),
+		WithMemoryPressure[MockResource](func() bool { return true }, time.Second, 0),
+		CompatibilityV1[MockResource](),
+	)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	clock.Advance(maxIdleTime + time.Second)
+
+	assert.Never(t, func() bool { return pool.NumIdle() == 0 }, 10*time.Millisecond, time.Millisecond)
+	assert.Empty(t, pool.Stats().Evictions)
+}
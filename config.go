@@ -0,0 +1,208 @@
+package pool
+
+import (
+	"time"
+)
+
+// Config is a snapshot of the effective configuration of a pool, after
+// defaults and compatibility overrides were applied
+type Config struct {
+	// MaxIdleSize is the maximum number of idle resources
+	MaxIdleSize int
+	// MaxOverflowSize is the hard cap of idle resources with an overflow
+	// buffer; zero means MaxIdleSize is the cap
+	MaxOverflowSize int
+	// OverflowIdleTime is the time resources of the overflow buffer are
+	// swept after
+	OverflowIdleTime time.Duration
+	// AutoSizing is the max idle size controller; MaxIdleSize is its current
+	// size
+	AutoSizing AutoSizing
+	// MaxIdleTime is the time after which idle resources are swept
+	MaxIdleTime time.Duration
+	// ReapInterval is the interval of the background sweeps of WithReaper
+	ReapInterval time.Duration
+	// ReapBatchSize caps the evictions per sweep; zero means no limit
+	ReapBatchSize int
+	// PressureInterval is the interval the WithMemoryPressure signal is
+	// polled at
+	PressureInterval time.Duration
+	// PressuredIdleSize is the idle cap under memory pressure
+	PressuredIdleSize int
+	// IdleTimeMode is what MaxIdleTime is measured from
+	IdleTimeMode IdleTimeMode
+	// MaxLifetime is the time after creation resources expire; zero means no
+	// limit
+	MaxLifetime time.Duration
+	// MaxActive caps the acquired resources; zero means no limit
+	MaxActive int
+	// SlowStartInitial is the cap MaxActive is ramped up from by WithSlowStart
+	SlowStartInitial int
+	// SlowStartWindow is the time the cap is ramped up over
+	SlowStartWindow time.Duration
+	// MaxWaiters caps the acquires waiting for a resource; zero means no limit
+	MaxWaiters int
+	// TenantLimited is set when tenants are capped by WithTenantMaxActive
+	TenantLimited bool
+	// SlowAcquireThreshold is the wait past which acquires are reported by
+	// WithSlowAcquireThreshold
+	SlowAcquireThreshold time.Duration
+	// SaturationHigh is the saturation reported by WithSaturationWatermarks
+	// once reached
+	SaturationHigh float64
+	// SaturationLow is the saturation below which the pool is reported as no
+	// longer saturated
+	SaturationLow float64
+	// MaxCost caps the total cost of acquired resources; zero means no limit
+	MaxCost int64
+	// ExpiryJitter is the fraction the max idle time of resources is spread by
+	ExpiryJitter float64
+	// CreateRate is the number of creations allowed per second; zero means no
+	// limit
+	CreateRate float64
+	// CreateBurst is the number of creations allowed at once within CreateRate
+	CreateBurst int
+	// CreateTimeout is the time after which creations are abandoned; zero
+	// means no limit
+	CreateTimeout time.Duration
+	// AcquirePolicy is whether acquires reuse or create first
+	AcquirePolicy AcquirePolicy
+	// TargetSize is the number of resources created first with PreferCreate
+	TargetSize int
+	// Coalescing is set when concurrent misses share creations, with
+	// WithCreateCoalescing
+	Coalescing bool
+	// Hedged is set when acquires create while waiting for a release
+	Hedged bool
+	// Identity is set when resources are tracked by WithIdentity
+	Identity bool
+	// LeaseReclaim is set when expired leases are reclaimed by
+	// WithLeaseReclaim
+	LeaseReclaim bool
+	// StateChange is set when state changes are notified by WithStateChange
+	StateChange bool
+	// LatencyBuckets are the bounds of the acquire latency histograms set by
+	// WithAcquireLatency; nil when latencies are not recorded
+	LatencyBuckets []time.Duration
+	// Validated is set when idle resources are validated before they are
+	// handed out
+	Validated bool
+	// WarmupSize is the number of resources created at construction
+	WarmupSize int
+	// StartupRamp is the window the warmup creations are spread over
+	StartupRamp time.Duration
+	// Endpoints are the endpoints dialed instead of calling the creator
+	Endpoints []string
+	// HappyEyeballs is set when creations race across endpoints
+	HappyEyeballs bool
+	// HappyEyeballsStagger is the delay before the next endpoint is dialed
+	HappyEyeballsStagger time.Duration
+	// EndpointQuarantine is the time a failing endpoint is first skipped for
+	EndpointQuarantine time.Duration
+	// MaxEndpointQuarantine caps the time a failing endpoint is skipped for
+	MaxEndpointQuarantine time.Duration
+	// HealthScored is set when resources are graded by a health scorer
+	HealthScored bool
+	// MinHealthScore is the score below which resources are evicted
+	MinHealthScore float64
+	// Reset is set when released resources are sanitized by a WithReset function
+	Reset bool
+	// RetireOnSetCreator is set when SetCreator retires the existing resources
+	RetireOnSetCreator bool
+	// Versioned is set when resources report a version for PinVersion
+	Versioned bool
+	// Reentrant is set when acquires in a ReentrantScope share a resource
+	Reentrant bool
+	// LeaseTTL is the hold time granted to leases
+	LeaseTTL time.Duration
+	// MaxHoldTime caps the total hold time of a lease
+	MaxHoldTime time.Duration
+	// StatsReportInterval is the interval of WithStatsReporter reports
+	StatsReportInterval time.Duration
+	// PanicContained is set when hook panics are recovered
+	PanicContained bool
+	// HookPanicLimit is the number of panics after which a hook is disabled
+	HookPanicLimit int
+	// Synchronous is set when the pool runs without background goroutines
+	Synchronous bool
+	// CompatibilityV1 is set when the pool keeps the v1 semantics
+	CompatibilityV1 bool
+	// GoroutineLimit is the goroutine budget of the pool's scheduler
+	GoroutineLimit int
+}
+
+// returns the effective configuration of the pool
+func (n *NewPool[T]) Config() Config {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.getConfig()
+}
+
+// returns the effective configuration of the pool; the pool mutex must be
+// held
+func (n *NewPool[T]) getConfig() Config {
+	config := Config{
+		MaxIdleSize:          n.maxIdleSize,
+		MaxOverflowSize:      n.maxOverflowSize,
+		OverflowIdleTime:     n.overflowIdleTime,
+		AutoSizing:           n.sizing,
+		MaxIdleTime:          n.maxIdleTime,
+		ReapInterval:         n.reapInterval,
+		ReapBatchSize:        n.reapBatchSize,
+		PressureInterval:     n.pressureInterval,
+		PressuredIdleSize:    n.pressuredIdleSize,
+		IdleTimeMode:         n.idleTimeMode,
+		MaxLifetime:          n.maxLifetime,
+		MaxActive:            n.maxActive,
+		SlowStartInitial:     n.slowStartInitial,
+		SlowStartWindow:      n.slowStartWindow,
+		MaxWaiters:           n.maxWaiters,
+		TenantLimited:        n.tenantLimit != nil,
+		SlowAcquireThreshold: n.slowAcquireThreshold,
+		SaturationHigh:       n.saturationHigh,
+		SaturationLow:        n.saturationLow,
+		MaxCost:              n.maxCost,
+		ExpiryJitter:         n.expiryJitter,
+		CreateTimeout:        n.createTimeout,
+		AcquirePolicy:        n.acquirePolicy,
+		TargetSize:           n.targetSize,
+		Coalescing:           n.isCoalescing,
+		Hedged:               n.isHedged,
+		Identity:             n.identity != nil,
+		Validated:            n.validator != nil,
+		LeaseReclaim:         n.isLeaseReclaimed,
+		LatencyBuckets:       n.latencyBounds,
+		StateChange:          n.onStateChange != nil,
+		WarmupSize:           n.warmupSize,
+		StartupRamp:          n.rampWindow,
+		Endpoints:            append([]string(nil), n.endpoints...),
+		HappyEyeballs:        n.isHappyEyeballs,
+		HappyEyeballsStagger: n.happyEyeballsStagger,
+		HealthScored:         n.healthScorer != nil,
+		MinHealthScore:       n.minHealthScore,
+		Reset:                n.resetter != nil,
+		RetireOnSetCreator:   n.isRetiringOnSetCreator,
+		Versioned:            n.versioner != nil,
+		Reentrant:            n.isReentrant,
+		LeaseTTL:             n.leaseTTL,
+		MaxHoldTime:          n.maxHoldTime,
+		StatsReportInterval:  n.reportInterval,
+		PanicContained:       n.isPanicContained,
+		HookPanicLimit:       n.hookPanicLimit,
+		Synchronous:          n.isSynchronous,
+		CompatibilityV1:      n.compatibilityV1,
+	}
+	if n.quarantine != nil {
+		config.EndpointQuarantine = n.quarantine.initial
+		config.MaxEndpointQuarantine = n.quarantine.max
+	}
+	if n.createRate != nil {
+		config.CreateRate = n.createRate.rate
+		config.CreateBurst = int(n.createRate.burst)
+	}
+	if n.scheduler != nil {
+		config.GoroutineLimit = n.scheduler.Stats().Limit
+	}
+	return config
+}
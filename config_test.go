@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Config(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        []Option[MockResource]
+		expectedConfig Config
+	}{
+		{
+			name: "without options reports defaults",
+			expectedConfig: Config{
+				MaxIdleSize:    maxIdleSize,
+				MaxIdleTime:    maxIdleTime,
+				GoroutineLimit: defaultGoroutineLimit,
+			},
+		},
+		{
+			name: "with options reports them",
+			options: []Option[MockResource]{
+				WithMaxActive[MockResource](10),
+				WithStartupRamp[MockResource](time.Second),
+				WithReentrantAcquire[MockResource](),
+				WithScheduler[MockResource](NewScheduler(2)),
+				WithCreateRate[MockResource](5, 2),
+			},
+			expectedConfig: Config{
+				MaxIdleSize:    maxIdleSize,
+				MaxIdleTime:    maxIdleTime,
+				MaxActive:      10,
+				StartupRamp:    time.Second,
+				Reentrant:      true,
+				CreateRate:     5,
+				CreateBurst:    2,
+				GoroutineLimit: 2,
+			},
+		},
+		{
+			name: "with compatibility mode reports overridden options",
+			options: []Option[MockResource]{
+				WithMaxActive[MockResource](10),
+				WithCreateRate[MockResource](5, 2),
+				CompatibilityV1[MockResource](),
+			},
+			expectedConfig: Config{
+				MaxIdleSize:     maxIdleSize,
+				MaxIdleTime:     maxIdleTime,
+				CompatibilityV1: true,
+				GoroutineLimit:  defaultGoroutineLimit,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+
+			assert.Equal(t, tc.expectedConfig, pool.Config())
+		})
+	}
+}
+
+func TestNewPool_ConfigIsSnapshot(t *testing.T) {
+	endpoints := []string{"a:1", "b:1"}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithEndpoints(endpoints, func(context.Context, string) (MockResource, error) {
+			return MockResource{}, nil
+		}),
+	)
+
+	config := pool.Config()
+	config.Endpoints[0] = "c:1"
+
+	assert.Equal(t, []string{"a:1", "b:1"}, pool.Config().Endpoints)
+}
@@ -0,0 +1,19 @@
+package pool
+
+// WithCost weighs each resource by cost, e.g. the size of a buffer or the
+// weight of a backend session, computed once when the resource is created
+func WithCost[T comparable](cost func(T) int64) Option[T] {
+	return func(n *NewPool[T]) {
+		n.coster = cost
+	}
+}
+
+// WithMaxCost caps the total cost of the acquired resources instead of their
+// number. Acquires wait like at WithMaxActive capacity while the acquired cost
+// is at or above maxCost; since the cost of a new resource is only known once
+// it is created, the budget can be exceeded by the cost of one resource.
+func WithMaxCost[T comparable](maxCost int64) Option[T] {
+	return func(n *NewPool[T]) {
+		n.maxCost = maxCost
+	}
+}
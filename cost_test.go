@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithMaxCost(t *testing.T) {
+	costs := map[MockResource]int64{{id: 1}: 6, {id: 2}: 3, {id: 3}: 1}
+	testCases := []struct {
+		name               string
+		maxCost            int64
+		acquires           int
+		expectedError      error
+		expectedActiveCost int64
+	}{
+		{
+			name:               "below budget acquires",
+			maxCost:            10,
+			acquires:           3,
+			expectedActiveCost: 10,
+		},
+		{
+			name:               "at budget fails fast",
+			maxCost:            9,
+			acquires:           3,
+			expectedError:      ErrPoolExhausted,
+			expectedActiveCost: 9,
+		},
+		{
+			name:               "below budget may exceed it by one resource",
+			maxCost:            7,
+			acquires:           2,
+			expectedActiveCost: 9,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithCost(func(resource MockResource) int64 { return costs[resource] }),
+				WithMaxCost[MockResource](tc.maxCost),
+			)
+
+			var err error
+			for i := 0; i < tc.acquires; i++ {
+				_, err = pool.Acquire(nil)
+			}
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedActiveCost, pool.Stats().ActiveCost)
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithMaxCost(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithCost(func(MockResource) int64 { return 5 }),
+		WithMaxCost[MockResource](5),
+		WithWarmup[MockResource](2),
+	)
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 2 }, time.Second, time.Millisecond)
+
+	first, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+
+	// idle resources are not handed out while the budget is used up
+	_, err = pool.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+	assert.Equal(t, 1, pool.NumIdle())
+
+	pool.Release(first)
+	second, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, pool.Invalidate(second))
+	assert.Equal(t, int64(0), pool.Stats().ActiveCost)
+}
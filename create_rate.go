@@ -0,0 +1,116 @@
+package pool
+
+import (
+	"context"
+	"time"
+)
+
+// WithCreateRate limits creations to rate per second, with bursts of up to
+// burst creations, so a cold or freshly drained pool can not stampede the
+// backend. An Acquire missing the idle pool beyond the rate waits for the next
+// creation slot until ctx is done, or takes a resource released meanwhile;
+// with a nil ctx it returns ErrPoolExhausted instead of waiting. Warmup
+// creations wait for their slot too. AcquireN and version pinned acquires do
+// not wait, but their creations are charged to the following ones.
+func WithCreateRate[T comparable](rate float64, burst int) Option[T] {
+	return func(n *NewPool[T]) {
+		if burst < 1 {
+			burst = 1
+		}
+		n.createRate = &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+	}
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second up to
+// burst tokens; a nil bucket has no limit
+type tokenBucket struct {
+	rate      float64
+	burst     float64
+	tokens    float64
+	updatedAt time.Time
+}
+
+// returns the time until a token is available at now
+func (b *tokenBucket) getDelay(now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// takes a token at now; the bucket goes into debt if it has none
+func (b *tokenBucket) take(now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.refill(now)
+	b.tokens--
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.updatedAt.IsZero() && now.After(b.updatedAt) {
+		b.tokens += now.Sub(b.updatedAt).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	if now.After(b.updatedAt) {
+		b.updatedAt = now
+	}
+}
+
+// waits delay for a creation slot, or for a ramped WithSlowStart cap, unless
+// a resource is released first; the mutex is released while waiting
+func (n *NewPool[T]) waitCreateRate(ctx context.Context, wait *acquireWait, delay time.Duration) error {
+	if ctx == nil {
+		return ErrPoolExhausted
+	}
+	if err := n.enterWait(); err != nil {
+		return err
+	}
+	defer func() {
+		n.waiters--
+		n.checkSaturation()
+		n.checkState()
+	}()
+
+	start := n.now()
+	defer func() { wait.add(n.now().Sub(start)) }()
+
+	timer := n.getClock().NewTimer(delay)
+	defer timer.Stop()
+	notify := n.getNotify()
+
+	n.mutex.Unlock()
+	defer n.mutex.Lock()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-notify:
+		return nil
+	case <-ctx.Done():
+		return getWaitError(ctx)
+	}
+}
+
+// reserves a creation slot for a background creation; returns the time to
+// wait for it
+func (n *NewPool[T]) reserveCreate() time.Duration {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	now := n.now()
+	delay := n.createRate.getDelay(now)
+	n.createRate.take(now)
+	return delay
+}
@@ -0,0 +1,148 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_GetDelay(t *testing.T) {
+	testCases := []struct {
+		name          string
+		takes         int
+		advance       time.Duration
+		expectedDelay time.Duration
+	}{
+		{
+			name:  "within burst has no delay",
+			takes: 1,
+		},
+		{
+			name:          "past burst waits for next token",
+			takes:         2,
+			expectedDelay: 500 * time.Millisecond,
+		},
+		{
+			name:          "in debt waits for debt and next token",
+			takes:         3,
+			expectedDelay: time.Second,
+		},
+		{
+			name:    "after refill has no delay",
+			takes:   2,
+			advance: 500 * time.Millisecond,
+		},
+		{
+			name:    "after long idle refills up to burst only",
+			takes:   2,
+			advance: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			bucket := &tokenBucket{rate: 2, burst: 2, tokens: 2}
+			for i := 0; i < tc.takes; i++ {
+				bucket.take(now)
+			}
+
+			assert.Equal(t, tc.expectedDelay, bucket.getDelay(now.Add(tc.advance)))
+		})
+	}
+}
+
+func TestNewPool_AcquireWithCreateRate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           func() (context.Context, context.CancelFunc)
+		expectedError error
+	}{
+		{
+			name:          "with nil ctx fails fast",
+			ctx:           func() (context.Context, context.CancelFunc) { return nil, func() {} },
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name: "with ctx done before next slot fails",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			expectedError: ErrAcquireTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithCreateRate[MockResource](1, 1),
+			)
+			pool.Acquire(nil)
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			_, err := pool.Acquire(ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, int64(1), pool.Stats().Created)
+		})
+	}
+}
+
+func TestNewPool_AcquireWithCreateRateWaitsForSlot(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithCreateRate[MockResource](1, 1),
+	)
+	pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	clock.Advance(time.Second)
+
+	assert.Equal(t, MockResource{id: 2}, <-acquired)
+	assert.Equal(t, int64(2), pool.Stats().Created)
+}
+
+func TestNewPool_AcquireWithCreateRateTakesReleasedResource(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithCreateRate[MockResource](1, 1),
+	)
+	first, _ := pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	pool.Release(first)
+
+	assert.Equal(t, first, <-acquired)
+	assert.Equal(t, int64(1), pool.Stats().Created)
+}
+
+func TestNewPool_WarmupWithCreateRate(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithCreateRate[MockResource](1, 1),
+		WithWarmup[MockResource](2),
+	)
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 1 }, time.Second, time.Millisecond)
+
+	assert.Never(t, func() bool { return pool.NumIdle() == 2 }, 10*time.Millisecond, time.Millisecond)
+	clock.Advance(time.Second)
+
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 2 }, time.Second, time.Millisecond)
+}
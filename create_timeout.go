@@ -0,0 +1,114 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithCreateTimeout abandons creations taking longer than timeout, e.g. a
+// dial to a blackholed address, so a hung creator fails the acquire with
+// ErrCreateTimeout, wrapped in a CreateError, instead of spending the whole
+// acquire deadline; the caller can retry with the time left. The context
+// passed to the creator is canceled at the timeout.
+//
+// Creations run on a goroutine of the scheduler. An abandoned creation keeps
+// it until the creator returns, counted in Stats.Creating meanwhile, and the
+// resource it returns late is destroyed. Without a free goroutine the creator
+// is called directly with its context canceled at the timeout, so only a
+// creator honouring ctx is bounded then.
+func WithCreateTimeout[T comparable](timeout time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.createTimeout = timeout
+	}
+}
+
+// createResult is the outcome of a creation run by createWithTimeout
+type createResult[T any] struct {
+	resource T
+	err      error
+}
+
+// calls creator, abandoning it after the create timeout, if any
+func (n *NewPool[T]) callCreator(ctx context.Context, creator func(context.Context) (T, error)) (T, error) {
+	if n.createTimeout <= 0 {
+		return creator(ctx)
+	}
+	return n.createWithTimeout(ctx, creator)
+}
+
+// runs creator on a scheduler goroutine and waits for it until the create
+// timeout; the resource of an abandoned creation is destroyed once created
+func (n *NewPool[T]) createWithTimeout(ctx context.Context, creator func(context.Context) (T, error)) (T, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attemptCtx, cancel := context.WithCancel(ctx)
+
+	destroyer := n.destroyer
+	results := make(chan createResult[T], 1)
+	var mutex sync.Mutex
+	isAbandoned := false
+	isStarted := n.scheduler.TryGo(func() {
+		resource, err := creator(attemptCtx)
+		cancel()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if !isAbandoned {
+			results <- createResult[T]{resource: resource, err: err}
+			return
+		}
+
+		n.creating.Add(-1)
+		if err == nil && destroyer != nil {
+			destroyer(resource)
+		}
+	})
+	if !isStarted {
+		return n.createDirectly(attemptCtx, cancel, creator)
+	}
+
+	timer := n.getClock().NewTimer(n.createTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result.resource, result.err
+	case <-timer.C():
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	select {
+	case result := <-results:
+		// the creation finished along with the timeout
+		return result.resource, result.err
+	default:
+	}
+
+	isAbandoned = true
+	n.creating.Add(1)
+	cancel()
+	return *new(T), ErrCreateTimeout
+}
+
+// calls creator on the calling goroutine, canceling ctx at the create
+// timeout; a creator failing after the timeout returns ErrCreateTimeout
+func (n *NewPool[T]) createDirectly(ctx context.Context, cancel context.CancelFunc, creator func(context.Context) (T, error)) (T, error) {
+	defer cancel()
+
+	var isExpired atomic.Bool
+	timer := n.getClock().AfterFunc(n.createTimeout, func() {
+		isExpired.Store(true)
+		cancel()
+	})
+	defer timer.Stop()
+
+	resource, err := creator(ctx)
+	if err != nil && isExpired.Load() {
+		return *new(T), ErrCreateTimeout
+	}
+	return resource, err
+}
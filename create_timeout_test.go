@@ -0,0 +1,91 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithCreateTimeout(t *testing.T) {
+	testCases := []struct {
+		name            string
+		delay           time.Duration
+		isSchedulerBusy bool
+		expectedError   error
+	}{
+		{
+			name: "creation within timeout acquires",
+		},
+		{
+			name:          "creation past timeout returns create timeout",
+			delay:         time.Second,
+			expectedError: ErrCreateTimeout,
+		},
+		{
+			name:            "creation past timeout without free goroutine returns create timeout",
+			delay:           time.Second,
+			isSchedulerBusy: true,
+			expectedError:   ErrCreateTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creator := func(ctx context.Context) (MockResource, error) {
+				select {
+				case <-time.After(tc.delay):
+					return MockResource{id: 1}, nil
+				case <-ctx.Done():
+					return MockResource{}, ctx.Err()
+				}
+			}
+			scheduler := NewScheduler(1)
+			if tc.isSchedulerBusy {
+				unblock := make(chan struct{})
+				defer close(unblock)
+				scheduler.Go(func() { <-unblock })
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime,
+				WithCreateTimeout[MockResource](10*time.Millisecond),
+				WithScheduler[MockResource](scheduler),
+			)
+
+			_, err := pool.Acquire(context.Background())
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			var createErr *CreateError
+			assert.ErrorAs(t, err, &createErr)
+		})
+	}
+}
+
+func TestNewPool_AcquireWithCreateTimeoutDestroysLateResource(t *testing.T) {
+	unblock := make(chan struct{})
+	creator := func(context.Context) (MockResource, error) {
+		<-unblock
+		return MockResource{id: 1}, nil
+	}
+	var destroyed atomic.Int64
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithCreateTimeout[MockResource](10*time.Millisecond),
+		WithDestroyer(func(MockResource) error {
+			destroyed.Add(1)
+			return nil
+		}),
+	)
+
+	_, err := pool.Acquire(nil)
+	assert.ErrorIs(t, err, ErrCreateTimeout)
+	assert.Equal(t, 1, pool.Stats().Creating)
+
+	close(unblock)
+	assert.Eventually(t, func() bool { return destroyed.Load() == 1 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return pool.Stats().Creating == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, pool.NumIdle())
+}
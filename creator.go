@@ -0,0 +1,28 @@
+package pool
+
+import (
+	"context"
+)
+
+// WithRetireOnSetCreator retires the resources created before a SetCreator
+// call, e.g. with credentials or TLS certificates that were rotated: idle
+// ones are destroyed right away, acquired ones when they are released, with
+// EvictRetired. It has no effect on resources in the cache of WithLocalCache.
+func WithRetireOnSetCreator[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isRetiringOnSetCreator = true
+	}
+}
+
+// replaces the function creating resources, e.g. to pick up rotated
+// credentials, without recreating the pool; it also replaces the dialing of
+// WithEndpoints. Creations in progress finish with the previous creator.
+func (n *NewPool[T]) SetCreator(creator func(context.Context) (T, error)) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.creator = creator
+	if n.isRetiringOnSetCreator {
+		n.retireAll()
+	}
+}
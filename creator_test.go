@@ -0,0 +1,57 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_SetCreator(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []Option[MockResource]
+		expectedIdle    int
+		expectedRetired int64
+	}{
+		{
+			name:         "without retirement keeps existing resources",
+			expectedIdle: 2,
+		},
+		{
+			name:            "with retirement destroys existing resources",
+			options:         []Option[MockResource]{WithRetireOnSetCreator[MockResource]()},
+			expectedIdle:    1,
+			expectedRetired: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			idle, _ := pool.Acquire(nil)
+			held, _ := pool.Acquire(nil)
+			pool.Release(idle)
+
+			pool.SetCreator(getOffsetMockCreatorFunc(100))
+			pool.Release(held)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, tc.expectedRetired, pool.Stats().Evictions[EvictRetired])
+		})
+	}
+}
+
+func TestNewPool_SetCreatorCreatesWithNewCreator(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	pool.Acquire(nil)
+
+	pool.SetCreator(func(context.Context) (MockResource, error) {
+		return MockResource{id: 100}, nil
+	})
+	resource, err := pool.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 100}, resource)
+}
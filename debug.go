@@ -0,0 +1,113 @@
+package pool
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+)
+
+// debugPool is the live state of a registered pool rendered by the debug
+// handler; only the stats of pools without a State method are rendered
+type debugPool struct {
+	Name string `json:"name"`
+	// IsDumped is set when the state comes from the State method of the pool
+	IsDumped bool `json:"-"`
+	State
+}
+
+// stateDumper is a pool with a detailed State, such as a *NewPool
+type stateDumper interface {
+	State() State
+}
+
+// Handler returns an http.Handler rendering the live state of the registered
+// pools, such as their stats, the age and use of their resources and their
+// recent errors, e.g. mounted on /debug/pools. It renders HTML, or JSON with
+// the format=json query parameter.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		pools := r.getDebugPools()
+		if req.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pools)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		debugTemplate.Execute(w, pools)
+	})
+}
+
+// returns the state of the registered pools in name order
+func (r *Registry) getDebugPools() []debugPool {
+	pools := r.getPools()
+	names := r.Names()
+	debugPools := make([]debugPool, 0, len(names))
+	for _, name := range names {
+		p, isFound := pools[name]
+		if !isFound {
+			continue
+		}
+
+		debugPool := debugPool{Name: name}
+		if dumper, isDumper := p.(stateDumper); isDumper {
+			debugPool.State = dumper.State()
+			debugPool.IsDumped = true
+		} else {
+			debugPool.Stats = p.Stats()
+		}
+		debugPools = append(debugPools, debugPool)
+	}
+	return debugPools
+}
+
+var debugTemplate = template.Must(template.New("pools").Parse(`<!DOCTYPE html>
+<html>
+<head><title>pools</title></head>
+<body>
+{{range .}}
+<h2>{{.Name}}</h2>
+<table>
+<tr><td>idle</td><td>{{.Stats.Idle}}</td></tr>
+<tr><td>active</td><td>{{.Stats.Active}}</td></tr>
+<tr><td>cap</td><td>{{.Stats.Cap}}</td></tr>
+<tr><td>idle cap</td><td>{{.Stats.IdleCap}}</td></tr>
+<tr><td>waiters</td><td>{{.Stats.Waiters}}</td></tr>
+<tr><td>waits</td><td>{{.Stats.Waits}}</td></tr>
+<tr><td>wait duration</td><td>{{.Stats.WaitDuration}}</td></tr>
+<tr><td>max wait</td><td>{{.Stats.MaxWait}}</td></tr>
+<tr><td>acquires</td><td>{{.Stats.Acquires}}</td></tr>
+<tr><td>created</td><td>{{.Stats.Created}}</td></tr>
+<tr><td>create failures</td><td>{{.Stats.CreateFailures}}</td></tr>
+</table>
+{{if .IsDumped}}
+<h3>config</h3>
+<table>
+<tr><td>max idle size</td><td>{{.Config.MaxIdleSize}}</td></tr>
+<tr><td>max idle time</td><td>{{.Config.MaxIdleTime}}</td></tr>
+<tr><td>max active</td><td>{{.Config.MaxActive}}</td></tr>
+<tr><td>max waiters</td><td>{{.Config.MaxWaiters}}</td></tr>
+<tr><td>max lifetime</td><td>{{.Config.MaxLifetime}}</td></tr>
+<tr><td>warmup size</td><td>{{.Config.WarmupSize}}</td></tr>
+<tr><td>synchronous</td><td>{{.Config.Synchronous}}</td></tr>
+<tr><td>compatibility v1</td><td>{{.Config.CompatibilityV1}}</td></tr>
+<tr><td>goroutine limit</td><td>{{.Config.GoroutineLimit}}</td></tr>
+</table>
+{{end}}
+{{if .Resources}}
+<h3>resources</h3>
+<table>
+<tr><th>state</th><th>age</th><th>since used</th><th>uses</th><th>held</th></tr>
+{{range .Resources}}<tr><td>{{if .IsAcquired}}acquired{{else}}idle{{end}}</td><td>{{.Age}}</td><td>{{.SinceUsed}}</td><td>{{.UseCount}}</td><td>{{.HeldDuration}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .RecentErrors}}
+<h3>recent errors</h3>
+<table>
+{{range .RecentErrors}}<tr><td>{{.Time.Format "2006-01-02T15:04:05Z07:00"}}</td><td>{{.Operation}}</td><td>{{.Err}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,129 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Handler(t *testing.T) {
+	testCases := []struct {
+		name                string
+		url                 string
+		expectedContentType string
+	}{
+		{
+			name:                "by default renders html",
+			url:                 "/debug/pools",
+			expectedContentType: "text/html; charset=utf-8",
+		},
+		{
+			name:                "with json format renders json",
+			url:                 "/debug/pools?format=json",
+			expectedContentType: "application/json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.Register("db", New(getMockCreatorFunc(), maxIdleSize, maxIdleTime))
+			recorder := httptest.NewRecorder()
+
+			registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, 200, recorder.Code)
+			assert.Equal(t, tc.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Contains(t, recorder.Body.String(), "db")
+		})
+	}
+}
+
+func TestRegistry_HandlerReportsResourcesAndErrors(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	isFailing := false
+	mockCreator := getMockCreatorFunc()
+	creator := func(ctx context.Context) (MockResource, error) {
+		if isFailing {
+			return MockResource{}, errors.New("error response")
+		}
+		return mockCreator(ctx)
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+	registry := NewRegistry()
+	registry.Register("db", pool)
+	resource, _ := pool.Acquire(nil)
+	clock.Advance(time.Second)
+	pool.Release(resource)
+	pool.Acquire(nil)
+	isFailing = true
+	pool.Acquire(nil)
+	clock.Advance(time.Second)
+	recorder := httptest.NewRecorder()
+
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/pools?format=json", nil))
+
+	var pools []debugPool
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &pools))
+	assert.Len(t, pools, 1)
+	assert.Equal(t, []ResourceState{{
+		Age:          2 * time.Second,
+		SinceUsed:    time.Second,
+		UseCount:     2,
+		HeldDuration: 2 * time.Second,
+		IsAcquired:   true,
+	}}, pools[0].Resources)
+	assert.Len(t, pools[0].RecentErrors, 1)
+	recentError := pools[0].RecentErrors[0]
+	assert.True(t, time.Unix(1, 0).Equal(recentError.Time))
+	assert.Equal(t, "create", recentError.Operation)
+	assert.Equal(t, "pool: create resource: error response", recentError.Err)
+}
+
+func TestRegistry_HandlerReportsConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pool     RegisteredPool
+		url      string
+		expected string
+	}{
+		{
+			name:     "with html renders config of pool",
+			pool:     New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](7)),
+			url:      "/debug/pools",
+			expected: "<tr><td>max active</td><td>7</td></tr>",
+		},
+		{
+			name:     "with json renders config of pool",
+			pool:     New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](7)),
+			url:      "/debug/pools?format=json",
+			expected: `"MaxActive":7`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry()
+			registry.Register("db", tc.pool)
+			recorder := httptest.NewRecorder()
+
+			registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Contains(t, recorder.Body.String(), tc.expected)
+		})
+	}
+}
+
+func TestRegistry_HandlerWithoutStateOmitsConfig(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("db", NewSharded(getMockCreatorFunc(), maxIdleSize, maxIdleTime))
+	recorder := httptest.NewRecorder()
+
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/pools", nil))
+
+	assert.NotContains(t, recorder.Body.String(), "<h3>config</h3>")
+}
@@ -0,0 +1,36 @@
+package pool
+
+import (
+	"runtime"
+)
+
+const (
+	// defaultIdlePerProc is the default max idle size per GOMAXPROCS
+	defaultIdlePerProc = 2
+	// defaultActivePerProc is the default max active per GOMAXPROCS
+	defaultActivePerProc = 4
+)
+
+// returns the max idle size used when New is given zero, scaled with
+// GOMAXPROCS like the idle connections of many connection pools
+func getDefaultMaxIdleSize() int {
+	return defaultIdlePerProc * runtime.GOMAXPROCS(0)
+}
+
+// returns the cap used by WithMaxActive when given zero, scaled with
+// GOMAXPROCS
+func getDefaultMaxActive() int {
+	return defaultActivePerProc * runtime.GOMAXPROCS(0)
+}
+
+// returns maxIdleSize, or its default when zero; a negative size keeps no
+// idle resources
+func getMaxIdleSize(maxIdleSize int) int {
+	if maxIdleSize == 0 {
+		return getDefaultMaxIdleSize()
+	}
+	if maxIdleSize < 0 {
+		return 0
+	}
+	return maxIdleSize
+}
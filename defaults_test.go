@@ -0,0 +1,129 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"testing"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	testCases := []struct {
+		name            string
+		maxIdleSize     int
+		options         []Option[MockResource]
+		expectedIdleCap int
+		expectedCap     int
+	}{
+		{
+			name:            "with zero max idle size derives it from GOMAXPROCS",
+			expectedIdleCap: defaultIdlePerProc * runtime.GOMAXPROCS(0),
+		},
+		{
+			name:            "with negative max idle size keeps no idle resources",
+			maxIdleSize:     -1,
+			expectedIdleCap: 0,
+		},
+		{
+			name:            "with max idle size keeps it",
+			maxIdleSize:     maxIdleSize,
+			expectedIdleCap: maxIdleSize,
+		},
+		{
+			name:            "with zero max active derives it from GOMAXPROCS",
+			maxIdleSize:     maxIdleSize,
+			options:         []Option[MockResource]{WithMaxActive[MockResource](0)},
+			expectedIdleCap: maxIdleSize,
+			expectedCap:     defaultActivePerProc * runtime.GOMAXPROCS(0),
+		},
+		{
+			name:            "with negative max active does not cap acquires",
+			maxIdleSize:     maxIdleSize,
+			options:         []Option[MockResource]{WithMaxActive[MockResource](-1)},
+			expectedIdleCap: maxIdleSize,
+			expectedCap:     -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), tc.maxIdleSize, maxIdleTime, tc.options...)
+
+			stats := pool.Stats()
+			assert.Equal(t, tc.expectedIdleCap, stats.IdleCap)
+			assert.Equal(t, tc.expectedCap, stats.Cap)
+		})
+	}
+}
+
+func TestNew_NegativeMaxIdleSizeDropsReleases(t *testing.T) {
+	pool := New(getMockCreatorFunc(), -1, maxIdleTime)
+	resource, _ := pool.Acquire(nil)
+
+	pool.Release(resource)
+
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictCapacity])
+}
+
+func TestNewSharded_DefaultMaxIdleSize(t *testing.T) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 0, maxIdleTime, WithShards[MockResource](2))
+
+	assert.Equal(t, 2*((defaultIdlePerProc*runtime.GOMAXPROCS(0)+1)/2), pool.Stats().IdleCap)
+}
+
+func TestNewChannel_MaxIdleSize(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		maxIdleSize            int
+		expectedIdleCap        int
+		expectedIdlePoolLength int
+	}{
+		{
+			name:                   "zero is derived from GOMAXPROCS",
+			expectedIdleCap:        defaultIdlePerProc * runtime.GOMAXPROCS(0),
+			expectedIdlePoolLength: 1,
+		},
+		{
+			name:            "negative keeps no idle resources",
+			maxIdleSize:     -1,
+			expectedIdleCap: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := NewChannel(getAtomicMockCreatorFunc(), tc.maxIdleSize, maxIdleTime)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			assert.Equal(t, tc.expectedIdleCap, cap(pool.idle))
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+		})
+	}
+}
+
+func TestNewStack_MaxIdleSize(t *testing.T) {
+	testCases := []struct {
+		name                string
+		maxIdleSize         int
+		expectedMaxIdleSize int
+	}{
+		{
+			name:                "zero has the default of New",
+			expectedMaxIdleSize: New(getMockCreatorFunc(), 0, maxIdleTime).Stats().IdleCap,
+		},
+		{
+			name:                "negative keeps no idle resources",
+			maxIdleSize:         -1,
+			expectedMaxIdleSize: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := NewStack(getAtomicMockCreatorFunc(), tc.maxIdleSize, maxIdleTime)
+
+			assert.Equal(t, int64(tc.expectedMaxIdleSize), pool.maxIdleSize.Load())
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package pool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// WithEndpoints makes the pool create resources by dialing endpoints in round
+// robin order instead of calling the creator
+func WithEndpoints[T comparable](endpoints []string, dial func(ctx context.Context, endpoint string) (T, error)) Option[T] {
+	return func(n *NewPool[T]) {
+		n.endpoints = endpoints
+		n.dial = dial
+	}
+}
+
+// WithHappyEyeballs races creations across endpoints: when a dial has not
+// completed after stagger, or as soon as it fails, the next endpoint is dialed
+// too and the first resource created wins. The losing resource is handed to
+// the destroyer. It has no effect with fewer than two endpoints.
+func WithHappyEyeballs[T comparable](stagger time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.happyEyeballsStagger = stagger
+		n.isHappyEyeballs = true
+	}
+}
+
+type dialResult[T any] struct {
+	resource T
+	err      error
+	attempt  int
+}
+
+// returns a creator dialing the configured endpoints
+func (n *NewPool[T]) getEndpointCreator() func(context.Context) (T, error) {
+	var next uint64
+	endpoints := n.endpoints
+	quarantine := n.quarantine
+	clock := n.getClock()
+	dial := func(ctx context.Context, endpoint string) (T, error) {
+		resource, err := n.dial(ctx, endpoint)
+		if err != nil {
+			// the dial of a canceled race loser did not fail on its own
+			if ctx == nil || ctx.Err() == nil {
+				quarantine.fail(endpoint, clock.Now(), err)
+			}
+			return resource, &endpointError{endpoint: endpoint, err: err}
+		}
+		quarantine.succeed(endpoint)
+		return resource, nil
+	}
+	destroyer := n.destroyer
+	scheduler := n.scheduler
+	stagger := n.happyEyeballsStagger
+	isRacing := n.isHappyEyeballs && len(endpoints) > 1
+
+	return func(ctx context.Context) (T, error) {
+		now := clock.Now()
+		index, isAvailable := quarantine.pick(endpoints, int(atomic.AddUint64(&next, 1)-1), now)
+		if !isAvailable {
+			return *new(T), &endpointError{endpoint: endpoints[index], err: ErrEndpointQuarantined}
+		}
+		secondary, isAvailable := quarantine.pick(endpoints, index+1, now)
+		if !isRacing || !isAvailable || secondary == index {
+			return dial(ctx, endpoints[index])
+		}
+
+		return raceDial(ctx, endpoints[index], endpoints[secondary], stagger, dial, destroyer, scheduler, clock)
+	}
+}
+
+// dials primary, then secondary after stagger or on primary failure, and
+// returns the first resource created. Dials race on scheduler goroutines; when
+// none is free the endpoints are dialed one after the other instead.
+func raceDial[T any](
+	ctx context.Context,
+	primary string,
+	secondary string,
+	stagger time.Duration,
+	dial func(context.Context, string) (T, error),
+	destroyer func(T) error,
+	scheduler *Scheduler,
+	clock Clock,
+) (T, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	results := make(chan dialResult[T], 2)
+	var cancels []context.CancelFunc
+	start := func(endpoint string) bool {
+		attemptCtx, cancel := context.WithCancel(ctx)
+		attempt := len(cancels)
+
+		isStarted := scheduler.TryGo(func() {
+			resource, err := dial(attemptCtx, endpoint)
+			results <- dialResult[T]{resource: resource, err: err, attempt: attempt}
+		})
+		if !isStarted {
+			cancel()
+			return false
+		}
+
+		cancels = append(cancels, cancel)
+		return true
+	}
+
+	if !start(primary) {
+		resource, err := dial(ctx, primary)
+		if err != nil {
+			if resource, secondaryErr := dial(ctx, secondary); secondaryErr == nil {
+				return resource, nil
+			}
+		}
+		return resource, err
+	}
+
+	timer := clock.NewTimer(stagger)
+	defer timer.Stop()
+
+	var firstErr error
+	pending := 1
+	for {
+		select {
+		case <-timer.C():
+			if len(cancels) == 1 && start(secondary) {
+				pending++
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				for attempt, cancel := range cancels {
+					if attempt != result.attempt {
+						cancel()
+					}
+				}
+				if pending > 0 && !scheduler.TryGo(func() { destroyLoser(results, destroyer) }) {
+					destroyLoser(results, destroyer)
+				}
+				return result.resource, nil
+			}
+
+			cancels[result.attempt]()
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			if len(cancels) > 1 {
+				if pending == 0 {
+					return *new(T), firstErr
+				}
+				continue
+			}
+
+			if start(secondary) {
+				pending++
+				continue
+			}
+			if resource, err := dial(ctx, secondary); err == nil {
+				return resource, nil
+			}
+			return *new(T), firstErr
+		}
+	}
+}
+
+// destroys the resource of the dial which lost the race, if it succeeded
+func destroyLoser[T any](results <-chan dialResult[T], destroyer func(T) error) {
+	result := <-results
+	if result.err == nil && destroyer != nil {
+		destroyer(result.resource)
+	}
+}
@@ -0,0 +1,117 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type EndpointResource struct {
+	endpoint string
+	id       int
+}
+
+func TestNewPool_AcquireWithEndpoints(t *testing.T) {
+	dialErr := errors.New("dial error")
+
+	testCases := []struct {
+		name              string
+		endpoints         []string
+		options           []Option[EndpointResource]
+		dialDelays        map[string]time.Duration
+		dialErrors        map[string]error
+		acquireCount      int
+		expectedEndpoints []string
+		expectedError     error
+		expectedDestroyed []string
+	}{
+		{
+			name:              "without happy eyeballs dials endpoints in round robin order",
+			endpoints:         []string{"a", "b"},
+			acquireCount:      3,
+			expectedEndpoints: []string{"a", "b", "a"},
+		},
+		{
+			name:              "with happy eyeballs and single endpoint dials it",
+			endpoints:         []string{"a"},
+			options:           []Option[EndpointResource]{WithHappyEyeballs[EndpointResource](time.Millisecond)},
+			dialDelays:        map[string]time.Duration{"a": 10 * time.Millisecond},
+			acquireCount:      1,
+			expectedEndpoints: []string{"a"},
+		},
+		{
+			name:              "with happy eyeballs and slow endpoint keeps whichever connects first",
+			endpoints:         []string{"a", "b"},
+			options:           []Option[EndpointResource]{WithHappyEyeballs[EndpointResource](time.Millisecond)},
+			dialDelays:        map[string]time.Duration{"a": 50 * time.Millisecond},
+			acquireCount:      1,
+			expectedEndpoints: []string{"b"},
+			expectedDestroyed: []string{"a"},
+		},
+		{
+			name:              "with happy eyeballs and failing endpoint dials next one immediately",
+			endpoints:         []string{"a", "b"},
+			options:           []Option[EndpointResource]{WithHappyEyeballs[EndpointResource](time.Hour)},
+			dialErrors:        map[string]error{"a": dialErr},
+			acquireCount:      1,
+			expectedEndpoints: []string{"b"},
+		},
+		{
+			name:          "with happy eyeballs and failing endpoints returns first error",
+			endpoints:     []string{"a", "b"},
+			options:       []Option[EndpointResource]{WithHappyEyeballs[EndpointResource](time.Millisecond)},
+			dialErrors:    map[string]error{"a": dialErr, "b": errors.New("other dial error")},
+			acquireCount:  1,
+			expectedError: dialErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var destroyed []string
+			id := 0
+			dial := func(ctx context.Context, endpoint string) (EndpointResource, error) {
+				time.Sleep(tc.dialDelays[endpoint])
+				if err := tc.dialErrors[endpoint]; err != nil {
+					return EndpointResource{}, err
+				}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+				id += 1
+				return EndpointResource{endpoint: endpoint, id: id}, nil
+			}
+			destroyer := func(resource EndpointResource) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				destroyed = append(destroyed, resource.endpoint)
+				return nil
+			}
+
+			options := append([]Option[EndpointResource]{WithEndpoints(tc.endpoints, dial), WithDestroyer(destroyer)}, tc.options...)
+			pool := New(nil, maxIdleSize, maxIdleTime, options...)
+
+			var endpoints []string
+			var err error
+			for i := 0; i < tc.acquireCount; i++ {
+				var resource EndpointResource
+				resource, err = pool.Acquire(context.Background())
+				if err == nil {
+					endpoints = append(endpoints, resource.endpoint)
+				}
+			}
+
+			assert.Equal(t, tc.expectedEndpoints, endpoints)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Eventually(t, func() bool {
+				mutex.Lock()
+				defer mutex.Unlock()
+				return assert.ObjectsAreEqual(tc.expectedDestroyed, destroyed)
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPoolClosed is returned by operations on a closed pool
+	ErrPoolClosed = errors.New("pool: closed")
+	// ErrPoolExhausted is returned when the pool is at capacity and the
+	// acquire can not wait for a resource, such as with a nil context
+	ErrPoolExhausted = errors.New("pool: exhausted")
+	// ErrAcquireTimeout is returned when the acquire context deadline passed
+	// while waiting for a resource; the error also matches
+	// context.DeadlineExceeded
+	ErrAcquireTimeout = errors.New("pool: acquire timeout")
+	// ErrPoolPaused is returned by acquires on a paused pool which can not
+	// wait for Resume
+	ErrPoolPaused = errors.New("pool: paused")
+	// ErrCreateTimeout is returned, wrapped in a CreateError, when a creation
+	// ran past its WithCreateTimeout
+	ErrCreateTimeout = errors.New("pool: create timeout")
+	// ErrEndpointQuarantined is returned, wrapped in a CreateError, when every
+	// endpoint is quarantined by WithEndpointQuarantine
+	ErrEndpointQuarantined = errors.New("pool: endpoint quarantined")
+	// ErrTenantQuotaExceeded is returned by acquires of a tenant holding as
+	// many resources as its WithTenantMaxActive cap
+	ErrTenantQuotaExceeded = errors.New("pool: tenant quota exceeded")
+	// ErrPoolRegistered is returned when registering a pool with a Registry
+	// under a name already taken
+	ErrPoolRegistered = errors.New("pool: name already registered")
+	// ErrNotAcquired is returned when releasing a resource which was not
+	// acquired from the pool
+	ErrNotAcquired = errors.New("pool: resource not acquired")
+	// ErrLeaseExpired is returned when extending a lease which expired or
+	// was released
+	ErrLeaseExpired = errors.New("pool: lease expired")
+	// ErrMaxHoldTime is returned when extending a lease past the pool's
+	// maximum hold time
+	ErrMaxHoldTime = errors.New("pool: maximum hold time exceeded")
+	// ErrNotAlive is the validation error of resources whose IsAlive
+	// reported false
+	ErrNotAlive = errors.New("pool: resource not alive")
+)
+
+// CreateError is returned by Acquire when the creator failed; the creator
+// error is available through errors.Is and errors.As
+type CreateError struct {
+	Err error
+	// Endpoint is the endpoint dialed with WithEndpoints; with happy eyeballs,
+	// the first one which failed
+	Endpoint string
+	// Attempt is the number of consecutive failed creations of the pool,
+	// including this one
+	Attempt int
+	// Elapsed is the time the creation took to fail
+	Elapsed time.Duration
+}
+
+func (e *CreateError) Error() string {
+	if e.Endpoint != "" {
+		return "pool: create resource at " + e.Endpoint + ": " + e.Err.Error()
+	}
+	return "pool: create resource: " + e.Err.Error()
+}
+
+func (e *CreateError) Unwrap() error {
+	return e.Err
+}
+
+// endpointError is a dial error of an endpoint creator, unwrapped into a
+// CreateError
+type endpointError struct {
+	endpoint string
+	err      error
+}
+
+func (e *endpointError) Error() string {
+	return e.endpoint + ": " + e.err.Error()
+}
+
+func (e *endpointError) Unwrap() error {
+	return e.err
+}
+
+// timeoutError is ErrAcquireTimeout wrapping the context error
+type timeoutError struct {
+	cause error
+}
+
+func (e *timeoutError) Error() string {
+	return ErrAcquireTimeout.Error() + ": " + e.cause.Error()
+}
+
+func (e *timeoutError) Is(target error) bool {
+	return target == ErrAcquireTimeout
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.cause
+}
+
+// causeError is a context error along with the context.Cause it was
+// canceled with
+type causeError struct {
+	err   error
+	cause error
+}
+
+func (e *causeError) Error() string {
+	return e.err.Error() + ": " + e.cause.Error()
+}
+
+func (e *causeError) Unwrap() []error {
+	return []error{e.err, e.cause}
+}
+
+// returns the error of an acquire whose wait ended because ctx is done; the
+// error matches both the ctx error and its context.Cause
+func getWaitError(ctx context.Context) error {
+	err := ctx.Err()
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		err = &causeError{err: err, cause: cause}
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &timeoutError{cause: err}
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireErrors(t *testing.T) {
+	errDrain := errors.New("operator drain")
+
+	testCases := []struct {
+		name          string
+		creator       func(context.Context) (MockResource, error)
+		options       []Option[MockResource]
+		getContext    func() (context.Context, context.CancelFunc)
+		isClosed      bool
+		expectedError []error
+	}{
+		{
+			name:          "with creator error returns create error wrapping it",
+			creator:       getErrorMockCreatorFunc(),
+			expectedError: []error{&CreateError{}},
+		},
+		{
+			name:          "with closed pool returns pool closed",
+			isClosed:      true,
+			expectedError: []error{ErrPoolClosed},
+		},
+		{
+			name:          "at capacity with nil context returns pool exhausted",
+			options:       []Option[MockResource]{WithMaxActive[MockResource](1)},
+			expectedError: []error{ErrPoolExhausted},
+		},
+		{
+			name:    "at capacity with passed deadline returns acquire timeout",
+			options: []Option[MockResource]{WithMaxActive[MockResource](1)},
+			getContext: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Millisecond)
+			},
+			expectedError: []error{ErrAcquireTimeout, context.DeadlineExceeded},
+		},
+		{
+			name:    "at capacity with cancelled context returns context error",
+			options: []Option[MockResource]{WithMaxActive[MockResource](1)},
+			getContext: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			expectedError: []error{context.Canceled},
+		},
+		{
+			name:    "at capacity with context cancelled with cause returns both",
+			options: []Option[MockResource]{WithMaxActive[MockResource](1)},
+			getContext: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(errDrain)
+				return ctx, func() {}
+			},
+			expectedError: []error{context.Canceled, errDrain},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			if pool.maxActive > 0 {
+				_, err := pool.Acquire(nil)
+				assert.NoError(t, err)
+			}
+			if tc.creator != nil {
+				pool.creator = tc.creator
+			}
+			if tc.isClosed {
+				pool.Close()
+			}
+
+			var ctx context.Context
+			if tc.getContext != nil {
+				var cancel context.CancelFunc
+				ctx, cancel = tc.getContext()
+				defer cancel()
+			}
+
+			_, err := pool.Acquire(ctx)
+
+			for _, expectedError := range tc.expectedError {
+				var createError *CreateError
+				if errors.As(expectedError, &createError) {
+					assert.ErrorAs(t, err, &createError)
+					assert.EqualError(t, createError.Err, "error response")
+					continue
+				}
+				assert.ErrorIs(t, err, expectedError)
+			}
+		})
+	}
+}
+
+func TestNewPool_TryRelease(t *testing.T) {
+	testCases := []struct {
+		name          string
+		isAcquired    bool
+		expectedError error
+	}{
+		{
+			name:       "with acquired resource returns no error",
+			isAcquired: true,
+		},
+		{
+			name:          "with non-acquired resource returns not acquired",
+			expectedError: ErrNotAcquired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			resource := MockResource{id: 5}
+			if tc.isAcquired {
+				resource, _ = pool.Acquire(nil)
+			}
+
+			assert.ErrorIs(t, pool.TryRelease(resource), tc.expectedError)
+		})
+	}
+}
+
+func TestNewPool_AcquireCreateErrorContext(t *testing.T) {
+	dialErr := errors.New("dial error")
+
+	testCases := []struct {
+		name             string
+		dialErrors       map[string]error
+		acquireCount     int
+		expectedEndpoint string
+		expectedAttempt  int
+	}{
+		{
+			name:             "with failing endpoint reports endpoint and attempt",
+			dialErrors:       map[string]error{"a": dialErr, "b": dialErr},
+			acquireCount:     1,
+			expectedEndpoint: "a",
+			expectedAttempt:  1,
+		},
+		{
+			name:             "with consecutive failures counts attempts",
+			dialErrors:       map[string]error{"a": dialErr, "b": dialErr},
+			acquireCount:     3,
+			expectedEndpoint: "a",
+			expectedAttempt:  3,
+		},
+		{
+			name:             "after successful creation restarts attempts",
+			dialErrors:       map[string]error{"b": dialErr},
+			acquireCount:     4,
+			expectedEndpoint: "b",
+			expectedAttempt:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			dial := func(ctx context.Context, endpoint string) (EndpointResource, error) {
+				clock.Advance(time.Second)
+				return EndpointResource{endpoint: endpoint}, tc.dialErrors[endpoint]
+			}
+			pool := New(nil, maxIdleSize, maxIdleTime,
+				WithClock[EndpointResource](clock),
+				WithEndpoints([]string{"a", "b"}, dial),
+			)
+
+			var err error
+			for i := 0; i < tc.acquireCount; i++ {
+				_, err = pool.Acquire(nil)
+			}
+
+			var createError *CreateError
+			assert.ErrorAs(t, err, &createError)
+			assert.ErrorIs(t, err, dialErr)
+			assert.Equal(t, tc.expectedEndpoint, createError.Endpoint)
+			assert.Equal(t, tc.expectedAttempt, createError.Attempt)
+			assert.Equal(t, time.Second, createError.Elapsed)
+			assert.EqualError(t, err, "pool: create resource at "+tc.expectedEndpoint+": dial error")
+		})
+	}
+}
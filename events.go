@@ -0,0 +1,106 @@
+package pool
+
+import (
+	"time"
+)
+
+// subscriberBufferSize is the number of events buffered per subscriber;
+// events are dropped for subscribers whose buffer is full
+const subscriberBufferSize = 100
+
+// EventType identifies the pool activity an Event reports
+type EventType string
+
+const (
+	// EventCreated is emitted when a resource is created
+	EventCreated EventType = "created"
+	// EventReused is emitted when Acquire hands out an idle resource
+	EventReused EventType = "reused"
+	// EventEvictedExpired is emitted when a resource is dropped for exceeding maxIdleTime
+	EventEvictedExpired EventType = "evicted-expired"
+	// EventEvictedCapacity is emitted when a released resource did not fit in the idle pool
+	EventEvictedCapacity EventType = "evicted-capacity"
+	// EventLeaseReclaimed is emitted when WithLeaseReclaim reclaims a lease
+	// which expired without being released; Err is ErrLeaseExpired
+	EventLeaseReclaimed EventType = "lease-reclaimed"
+	// EventSaturated is emitted when the saturation rises to the high
+	// watermark of WithSaturationWatermarks
+	EventSaturated EventType = "saturated"
+	// EventSaturationCleared is emitted when the saturation falls back to the
+	// low watermark of WithSaturationWatermarks
+	EventSaturationCleared EventType = "saturation-cleared"
+	// EventDestroyFailed is emitted when the destroyer returned an error
+	EventDestroyFailed EventType = "destroy-failed"
+	// EventClosedGraceful is emitted when CloseGraceful finished waiting for
+	// releases; Err is set if it gave up
+	EventClosedGraceful EventType = "closed-graceful"
+	// EventClosedBounded is emitted when Shutdown or CloseWithin finished; Err
+	// is set if they had to force the resources still acquired
+	EventClosedBounded EventType = "closed-bounded"
+	// EventClosedImmediate is emitted when CloseNow destroyed the resources
+	EventClosedImmediate EventType = "closed-immediate"
+)
+
+// Event is a structured record of pool activity
+type Event struct {
+	Type EventType
+	// Time is when the event happened
+	Time time.Time
+	// Age is the time since the resource concerned was created
+	Age time.Duration
+	// Err is the error of failure events
+	Err error
+}
+
+// returns a channel receiving the events of the pool. The pool never blocks on
+// subscribers: events are dropped while the channel buffer is full.
+func (n *NewPool[T]) Subscribe() <-chan Event {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	subscriber := make(chan Event, subscriberBufferSize)
+	n.subscribers = append(n.subscribers, subscriber)
+	return subscriber
+}
+
+// stops sending events to a channel returned by Subscribe, and closes it
+func (n *NewPool[T]) Unsubscribe(events <-chan Event) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	for i, subscriber := range n.subscribers {
+		if subscriber == events {
+			n.subscribers = append(n.subscribers[:i], n.subscribers[i+1:]...)
+			close(subscriber)
+			return
+		}
+	}
+}
+
+// sends an event to every subscriber with room in its buffer
+func (n *NewPool[T]) publish(eventType EventType, entry *resourceEntry, err error) {
+	if len(n.subscribers) == 0 {
+		return
+	}
+
+	now := n.now()
+	event := Event{
+		Type: eventType,
+		Time: now,
+		Err:  err,
+	}
+	// resources released to the local cache have no bookkeeping
+	if !entry.createdAt.IsZero() {
+		event.Age = now.Sub(entry.createdAt)
+	}
+	for _, subscriber := range n.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+func getEvictedEventType(reason EvictReason) EventType {
+	return EventType("evicted-" + reason)
+}
@@ -0,0 +1,104 @@
+package pool
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Subscribe(t *testing.T) {
+	testCases := []struct {
+		name               string
+		idle               map[MockResource]*resourceEntry
+		destroyer          func(MockResource) error
+		run                func(*NewPool[MockResource])
+		expectedEventTypes []EventType
+	}{
+		{
+			name: "with empty pool emits created event",
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedEventTypes: []EventType{EventCreated},
+		},
+		{
+			name: "with idle resource emits reused event",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {releasedAt: time.Now()},
+			},
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedEventTypes: []EventType{EventReused},
+		},
+		{
+			name: "with expired idle resource emits evicted-expired event",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {releasedAt: time.Now().Add(-2 * maxIdleTime)},
+			},
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedEventTypes: []EventType{EventEvictedExpired, EventCreated},
+		},
+		{
+			name: "with full idle pool emits evicted-capacity and destroy-failed events",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 5}: {releasedAt: time.Now()},
+				MockResource{id: 6}: {releasedAt: time.Now()},
+				MockResource{id: 7}: {releasedAt: time.Now()},
+			},
+			destroyer: func(MockResource) error { return errors.New("destroy error") },
+			run: func(pool *NewPool[MockResource]) {
+				pool.lock[MockResource{id: 2}] = &resourceEntry{acquiredAt: time.Now()}
+				pool.Release(MockResource{id: 2})
+			},
+			expectedEventTypes: []EventType{EventEvictedCapacity, EventDestroyFailed},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.idle == nil {
+				tc.idle = make(map[MockResource]*resourceEntry)
+			}
+
+			mockMutex := &MockMutex{}
+			mockMutex.On("Lock")
+			mockMutex.On("Unlock")
+
+			pool := NewPool[MockResource]{
+				creator:     getMockCreatorFunc(),
+				maxIdleTime: maxIdleTime,
+				maxIdleSize: maxIdleSize,
+				mutex:       mockMutex,
+				lock:        make(map[MockResource]*resourceEntry),
+				unlock:      tc.idle,
+				destroyer:   tc.destroyer,
+			}
+			events := pool.Subscribe()
+
+			tc.run(&pool)
+			pool.Unsubscribe(events)
+
+			var eventTypes []EventType
+			for event := range events {
+				eventTypes = append(eventTypes, event.Type)
+			}
+			assert.Equal(t, tc.expectedEventTypes, eventTypes)
+			mockMutex.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewPool_SubscribeDropsEventsWhenFull(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	events := pool.Subscribe()
+
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		pool.Acquire(nil)
+	}
+
+	assert.Len(t, events, subscriberBufferSize)
+}
@@ -0,0 +1,144 @@
+package pool
+
+import (
+	"math/rand"
+	"time"
+)
+
+// EvictionCandidate describes an idle resource an EvictionPolicy may pick
+type EvictionCandidate struct {
+	// CreatedAt is when the resource was created
+	CreatedAt time.Time
+	// ReleasedAt is when the resource entered the idle pool
+	ReleasedAt time.Time
+	// UseCount is the number of times the resource was handed out
+	UseCount int64
+	// Metadata is the metadata attached to the resource
+	Metadata *Metadata
+}
+
+// EvictionPolicy picks the idle resource dropped when the idle pool is over
+// capacity. It runs under the pool mutex, so it must not call the pool.
+type EvictionPolicy interface {
+	// returns the index of the candidate to evict; candidates is never empty,
+	// and an index out of its range evicts the last candidate
+	Choose(candidates []EvictionCandidate) int
+}
+
+// EvictionPolicyFunc is a function used as an EvictionPolicy
+type EvictionPolicyFunc func(candidates []EvictionCandidate) int
+
+func (f EvictionPolicyFunc) Choose(candidates []EvictionCandidate) int {
+	return f(candidates)
+}
+
+var (
+	// OldestIdle evicts the resource idle for the longest time
+	OldestIdle EvictionPolicy = EvictionPolicyFunc(func(candidates []EvictionCandidate) int {
+		chosen := 0
+		for i, candidate := range candidates {
+			if candidate.ReleasedAt.Before(candidates[chosen].ReleasedAt) {
+				chosen = i
+			}
+		}
+		return chosen
+	})
+	// LeastUsed evicts the resource handed out the fewest times
+	LeastUsed EvictionPolicy = EvictionPolicyFunc(func(candidates []EvictionCandidate) int {
+		chosen := 0
+		for i, candidate := range candidates {
+			if candidate.UseCount < candidates[chosen].UseCount {
+				chosen = i
+			}
+		}
+		return chosen
+	})
+	// RandomEviction evicts a resource picked at random
+	RandomEviction EvictionPolicy = EvictionPolicyFunc(func(candidates []EvictionCandidate) int {
+		return rand.Intn(len(candidates))
+	})
+)
+
+// WithEvictionPolicy sets the policy picking the idle resource dropped when a
+// release finds the idle pool full, with the released resource among the
+// candidates, and when auto sizing shrinks it; each eviction scans the idle
+// pool. Without a policy, a release finding the idle pool full drops the
+// released resource, and shrinking drops arbitrary idle resources.
+func WithEvictionPolicy[T comparable](policy EvictionPolicy) Option[T] {
+	return func(n *NewPool[T]) {
+		n.evictionPolicy = policy
+	}
+}
+
+// makes room in the full idle pool for a released resource, evicting the
+// resource chosen by the eviction policy; returns false if the released
+// resource is the one to drop
+func (n *NewPool[T]) makeIdleRoom(resource T, entry *resourceEntry) bool {
+	if n.evictionPolicy == nil || n.getMaxIdleCap() <= 0 {
+		return false
+	}
+
+	resources, candidates := n.getEvictionCandidates()
+	resources = append(resources, resource)
+	candidates = append(candidates, n.getEvictionCandidate(entry, n.now()))
+
+	chosen := resources[n.chooseEviction(candidates)]
+	if chosen == resource {
+		return false
+	}
+	n.evictIdle(chosen, EvictCapacity)
+	return true
+}
+
+// evicts idle resources until at most size are left, picking them with the
+// eviction policy, or arbitrarily without one
+func (n *NewPool[T]) shrinkIdle(size int, reason EvictReason) {
+	for len(n.unlock) > size {
+		if n.evictionPolicy == nil {
+			for resource := range n.unlock {
+				n.evictIdle(resource, reason)
+				break
+			}
+			continue
+		}
+
+		resources, candidates := n.getEvictionCandidates()
+		n.evictIdle(resources[n.chooseEviction(candidates)], reason)
+	}
+}
+
+// returns the idle resources along with their eviction candidates
+func (n *NewPool[T]) getEvictionCandidates() ([]T, []EvictionCandidate) {
+	resources := make([]T, 0, len(n.unlock)+1)
+	candidates := make([]EvictionCandidate, 0, len(n.unlock)+1)
+	for resource, entry := range n.unlock {
+		resources = append(resources, resource)
+		candidates = append(candidates, n.getEvictionCandidate(entry, entry.releasedAt))
+	}
+	return resources, candidates
+}
+
+func (n *NewPool[T]) getEvictionCandidate(entry *resourceEntry, releasedAt time.Time) EvictionCandidate {
+	return EvictionCandidate{
+		CreatedAt:  entry.createdAt,
+		ReleasedAt: releasedAt,
+		UseCount:   entry.useCount,
+		Metadata:   entry.getMetadata(),
+	}
+}
+
+// returns the index chosen by the eviction policy, clamped to candidates
+func (n *NewPool[T]) chooseEviction(candidates []EvictionCandidate) int {
+	chosen := n.evictionPolicy.Choose(candidates)
+	if chosen < 0 || chosen >= len(candidates) {
+		return len(candidates) - 1
+	}
+	return chosen
+}
+
+// drops an idle resource from the pool and destroys it
+func (n *NewPool[T]) evictIdle(resource T, reason EvictReason) {
+	entry := n.unlock[resource]
+	delete(n.unlock, resource)
+	n.evict(resource, entry, reason)
+}
@@ -0,0 +1,68 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_EvictionPolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       EvictionPolicy
+		expectedIdle []MockResource
+	}{
+		{
+			name:         "without policy drops released resource",
+			expectedIdle: []MockResource{{id: 1}, {id: 2}},
+		},
+		{
+			name:         "with oldest idle drops longest idle resource",
+			policy:       OldestIdle,
+			expectedIdle: []MockResource{{id: 1}, {id: 3}},
+		},
+		{
+			name:         "with least used drops resource handed out the fewest times",
+			policy:       LeastUsed,
+			expectedIdle: []MockResource{{id: 1}, {id: 2}},
+		},
+		{
+			name:         "with out of range choice drops released resource",
+			policy:       EvictionPolicyFunc(func([]EvictionCandidate) int { return -1 }),
+			expectedIdle: []MockResource{{id: 1}, {id: 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			options := []Option[MockResource]{WithClock[MockResource](clock)}
+			if tc.policy != nil {
+				options = append(options, WithEvictionPolicy[MockResource](tc.policy))
+			}
+			pool := New(getMockCreatorFunc(), 2, maxIdleTime, options...)
+			resources, _ := pool.AcquireN(nil, 3)
+			pool.Release(resources[0])
+			pool.Release(resources[1])
+			// resource 1 is used 3 times and released last, resource 2 twice,
+			// resource 3 once
+			for _, resource := range []MockResource{resources[1], resources[0], resources[0]} {
+				clock.Advance(time.Second)
+				acquired, _ := pool.AcquireWhere(nil, func(idle MockResource) bool { return idle == resource })
+				pool.Release(acquired)
+			}
+			clock.Advance(time.Second)
+
+			pool.Release(resources[2])
+
+			idle := make([]MockResource, 0, len(pool.unlock))
+			for _, resource := range resources {
+				if _, isIdle := pool.unlock[resource]; isIdle {
+					idle = append(idle, resource)
+				}
+			}
+			assert.Equal(t, tc.expectedIdle, idle)
+			assert.Equal(t, int64(1), pool.Stats().Evictions[EvictCapacity])
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Command upstream is a small HTTP service pooling TCP connections to an
+// upstream line-based server, e.g. a Redis-like service answering PING with
+// PONG. It shows the pool wired into a service:
+//   - leased connections guarding against handlers holding them too long
+//   - Prometheus metrics at /metrics, the pool state at /debug/pools, from
+//     the pool registry, and the request paths holding connections at
+//     /debug/pools/holders
+//   - a graceful drain of the HTTP server and the pool on SIGTERM
+//
+// Usage:
+//
+//	upstream -listen :8080 -upstream localhost:6379
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	pool "example/ptran"
+	"example/ptran/poolprom"
+	"flag"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// drainTimeout bounds the graceful drain on SIGTERM
+const drainTimeout = 10 * time.Second
+
+func main() {
+	listen := flag.String("listen", ":8080", "address the HTTP server listens on")
+	upstream := flag.String("upstream", "localhost:6379", "address of the upstream server")
+	flag.Parse()
+
+	var dialer net.Dialer
+	connections := pool.New(
+		func(ctx context.Context) (net.Conn, error) {
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return dialer.DialContext(ctx, "tcp", *upstream)
+		},
+		8,
+		time.Minute,
+		pool.WithDestroyer(func(conn net.Conn) error { return conn.Close() }),
+		pool.WithMaxActive[net.Conn](32),
+		pool.WithLeaseTTL[net.Conn](5*time.Second),
+		pool.WithWarmup[net.Conn](2),
+	)
+
+	pools := pool.NewRegistry()
+	if err := pools.Register("upstream", connections); err != nil {
+		log.Fatalf("register pool: %v", err)
+	}
+
+	metrics := prometheus.NewRegistry()
+	collector := poolprom.NewCollector("upstream")
+	collector.Add("upstream", connections)
+	metrics.MustRegister(collector)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", &pingHandler{connections: connections})
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
+	mux.Handle("/debug/pools", pools.Handler())
+	// the registry handler renders the pool state, but not who holds what
+	mux.HandleFunc("/debug/pools/holders", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(connections.Holders())
+	})
+	server := &http.Server{Addr: *listen, Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("listen: %v", err)
+		}
+	}()
+	log.Printf("listening on %s, upstream %s", *listen, *upstream)
+
+	<-ctx.Done()
+	log.Print("draining")
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := server.Shutdown(drainCtx); err != nil {
+		log.Printf("shutdown server: %v", err)
+	}
+	// connections still leased once the drain timed out are destroyed
+	if err := connections.Shutdown(drainCtx); err != nil {
+		log.Printf("shutdown pool: %v", err)
+	}
+}
+
+// pingHandler answers each request with the upstream reply to PING
+type pingHandler struct {
+	connections *pool.NewPool[net.Conn]
+}
+
+func (h *pingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	lease, err := h.connections.AcquireLease(pool.HolderTag(r.Context(), r.URL.Path))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	reply, err := ping(lease)
+	if err != nil {
+		lease.Invalidate()
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	// a handler outliving its lease is a leak candidate
+	if errors.Is(context.Cause(lease.Context()), pool.ErrLeaseExpired) {
+		log.Printf("connection held past its lease by %s", r.URL.Path)
+	}
+	lease.Release()
+
+	w.Write([]byte(reply))
+}
+
+// sends PING on the leased connection and returns the reply line; the
+// connection deadline follows the lease deadline
+func ping(lease *pool.Lease[net.Conn]) (string, error) {
+	conn := lease.Resource()
+	if deadline := lease.Deadline(); !deadline.IsZero() {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte("PING\r\n")); err != nil {
+		return "", err
+	}
+	return bufio.NewReader(conn).ReadString('\n')
+}
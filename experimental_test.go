@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const experimentalImportPath = "example/ptran/x"
+
+func TestStablePackagesDoNotImportX(t *testing.T) {
+	err := filepath.WalkDir(".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && (path == "x" || strings.HasPrefix(entry.Name(), ".")) && path != "." {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range file.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			isExperimental := importPath == experimentalImportPath || strings.HasPrefix(importPath, experimentalImportPath+"/")
+			assert.False(t, isExperimental, "%s imports experimental package %s", path, importPath)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,83 @@
+package pool
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expiry is the scheduled expiry of an idle resource. It is stale once the
+// resource left the idle pool or was released again since it was pushed.
+type expiry[T comparable] struct {
+	resource   T
+	entry      *resourceEntry
+	releasedAt time.Time
+	deadline   time.Time
+}
+
+// expiryHeap orders idle resources by expiry, soonest first, so sweeps only
+// touch expired resources instead of scanning the idle pool. Resources leaving
+// the idle pool are not removed from it; their expiries are dropped as stale
+// when they reach the top.
+type expiryHeap[T comparable] []expiry[T]
+
+func (h expiryHeap[T]) Len() int {
+	return len(h)
+}
+
+func (h expiryHeap[T]) Less(i, j int) bool {
+	return h[i].deadline.Before(h[j].deadline)
+}
+
+func (h expiryHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *expiryHeap[T]) Push(x any) {
+	*h = append(*h, x.(expiry[T]))
+}
+
+func (h *expiryHeap[T]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	old[len(old)-1] = expiry[T]{}
+	*h = old[:len(old)-1]
+	return last
+}
+
+// schedules the expiry of a resource entering the idle pool
+func (n *NewPool[T]) pushExpiry(resource T, entry *resourceEntry) {
+	heap.Push(&n.expiries, n.getExpiry(resource, entry))
+}
+
+// returns the expiry of an idle resource
+func (n *NewPool[T]) getExpiry(resource T, entry *resourceEntry) expiry[T] {
+	return expiry[T]{
+		resource:   resource,
+		entry:      entry,
+		releasedAt: entry.releasedAt,
+		deadline:   n.getIdleDeadline(entry),
+	}
+}
+
+// reports whether the expiry still belongs to an idle resource
+func (n *NewPool[T]) isCurrent(e expiry[T]) bool {
+	entry, isIdle := n.unlock[e.resource]
+	return isIdle && entry == e.entry && entry.releasedAt.Equal(e.releasedAt)
+}
+
+// rebuilds the expiries from the idle pool, dropping stale ones; a no-op while
+// they are consistent with it
+func (n *NewPool[T]) syncExpiries() {
+	if len(n.expiries) >= len(n.unlock) && len(n.expiries) <= 2*len(n.unlock)+expiryHeapSlack {
+		return
+	}
+
+	n.expiries = n.expiries[:0]
+	for resource, entry := range n.unlock {
+		n.expiries = append(n.expiries, n.getExpiry(resource, entry))
+	}
+	heap.Init(&n.expiries)
+}
+
+// stale expiries tolerated before the heap is rebuilt
+const expiryHeapSlack = 16
@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_DeleteInvalidIdleResources(t *testing.T) {
+	testCases := []struct {
+		name            string
+		advance         time.Duration
+		expectedIdle    int
+		expectedEvicted int64
+	}{
+		{
+			name:         "before any expiry keeps idle resources",
+			advance:      maxIdleTime,
+			expectedIdle: 3,
+		},
+		{
+			name:            "past soonest expiry evicts only expired resource",
+			advance:         maxIdleTime + time.Nanosecond,
+			expectedIdle:    2,
+			expectedEvicted: 1,
+		},
+		{
+			name:            "past every expiry evicts all idle resources",
+			advance:         maxIdleTime + 2*time.Second + time.Nanosecond,
+			expectedEvicted: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+			resources, _ := pool.AcquireN(nil, 3)
+			for _, resource := range resources {
+				pool.Release(resource)
+				clock.Advance(time.Second)
+			}
+
+			clock.Advance(tc.advance - 3*time.Second)
+			pool.mutex.Lock()
+			pool.deleteInvalidIdleResources()
+			pool.mutex.Unlock()
+
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, tc.expectedEvicted, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
+
+func TestNewPool_DeleteInvalidIdleResourcesSkipsStaleExpiry(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+	clock.Advance(maxIdleTime / 2)
+	resource, _ = pool.Acquire(nil)
+	pool.Release(resource)
+
+	clock.Advance(maxIdleTime / 2)
+	resource, _ = pool.Acquire(nil)
+
+	assert.Equal(t, MockResource{id: 1}, resource)
+	assert.Equal(t, int64(0), pool.Stats().Evictions[EvictExpired])
+}
+
+func TestNewPool_DeleteInvalidIdleResourcesBoundsExpiries(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	for i := 0; i < 100; i++ {
+		resource, _ := pool.Acquire(nil)
+		pool.Release(resource)
+	}
+
+	pool.mutex.Lock()
+	pool.deleteInvalidIdleResources()
+	pool.mutex.Unlock()
+
+	assert.LessOrEqual(t, len(pool.expiries), 2*pool.NumIdle()+expiryHeapSlack)
+}
+
+func BenchmarkNewPool_AcquireReleaseWithManyIdle(b *testing.B) {
+	pool := New(getAtomicMockCreatorFunc(), 4096, time.Minute)
+	resources, _ := pool.AcquireN(nil, 4096)
+	pool.ReleaseAll(resources)
+	benchmarkAcquireRelease(b, pool)
+}
@@ -0,0 +1,13 @@
+package pool
+
+import (
+	"expvar"
+)
+
+// publishes the live pool stats under name, so they are served on /debug/vars;
+// like expvar.Publish, it panics if name is already published
+func (n *NewPool[T]) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return n.Stats()
+	}))
+}
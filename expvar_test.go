@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+)
+
+// expvarRuns makes published names unique across runs of a test binary, as
+// expvar names can not be unpublished
+var expvarRuns atomic.Int64
+
+func TestNewPool_PublishExpvar(t *testing.T) {
+	name := fmt.Sprintf("%s-%d", t.Name(), expvarRuns.Add(1))
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	pool.PublishExpvar(name)
+
+	resource, err := pool.Acquire(nil)
+	require.NoError(t, err)
+	pool.Release(resource)
+
+	var stats Stats
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &stats))
+
+	assert.Equal(t, Stats{
+		Acquires:   1,
+		Created:    1,
+		Evictions:  map[EvictReason]int64{},
+		HookPanics: map[string]int64{},
+		Idle:       1,
+		Total:      1,
+		IdleCap:    maxIdleSize,
+		Goroutines: SchedulerStats{
+			Limit: defaultGoroutineLimit,
+		},
+	}, stats)
+	assert.Panics(t, func() { pool.PublishExpvar(name) })
+}
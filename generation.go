@@ -0,0 +1,46 @@
+package pool
+
+// invalidates every resource created so far, e.g. after a failover, in
+// constant time: the pool's generation is incremented, and resources of older
+// generations are destroyed when they are released, or when an acquire comes
+// across them in the idle pool, instead of being handed out. It has no effect
+// on resources in the cache of WithLocalCache. Returns the new generation.
+func (n *NewPool[T]) BumpGeneration() uint64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.generation++
+	n.minGeneration = n.generation
+	return n.generation
+}
+
+// returns the generation new resources are created under; it starts at zero
+// and is incremented whenever the resources of the pool are retired
+func (n *NewPool[T]) Generation() uint64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.generation
+}
+
+// reports whether an idle resource was created before the generation was
+// last bumped, so it must not be handed out
+func (n *NewPool[T]) isStale(entry *resourceEntry) bool {
+	return entry.generation < n.minGeneration
+}
+
+// retires the resources of the pool: idle ones are destroyed, acquired ones
+// when they are released
+func (n *NewPool[T]) retireAll() {
+	n.generation++
+	for resource, entry := range n.unlock {
+		delete(n.unlock, resource)
+		n.evict(resource, entry, EvictRetired)
+	}
+}
+
+// reports whether a resource was created before the resources of the pool
+// were last retired
+func (n *NewPool[T]) isRetired(entry *resourceEntry) bool {
+	return entry.generation != n.generation
+}
@@ -0,0 +1,50 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_BumpGeneration(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resources, _ := pool.AcquireN(nil, 2)
+	pool.Release(resources[0])
+
+	assert.Equal(t, uint64(1), pool.BumpGeneration())
+	resource, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 3}, resource)
+	pool.Release(resources[1])
+	pool.Release(resource)
+
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictRetired])
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, uint64(1), pool.Generation())
+}
+
+func TestNewPool_BumpGenerationSkipsAffinity(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	ctx := Affinity(context.Background(), "user-1")
+	resource, _ := pool.Acquire(ctx)
+	pool.Release(resource)
+
+	pool.BumpGeneration()
+	reacquired, _ := pool.Acquire(ctx)
+
+	assert.NotEqual(t, resource, reacquired)
+	assert.Equal(t, int64(0), pool.Stats().AffinityHits)
+}
+
+func TestNewPool_GenerationWithRecycle(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	pool.Recycle(context.Background())
+	pool.BumpGeneration()
+	pool.Recycle(context.Background())
+
+	assert.Equal(t, uint64(3), pool.Generation())
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictRetired])
+}
@@ -0,0 +1,29 @@
+package pool
+
+// WithHandleCount reports how many OS handles (file descriptors on unix,
+// handles on Windows) a resource holds, e.g. 1 for a TCP connection. The
+// pool counts the handles of a resource when it is created and each time it
+// returns to the idle pool, and reports the total in Stats.Handles next to
+// the process limit in Stats.HandleLimit.
+func WithHandleCount[T comparable](count func(T) int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.handleCounter = count
+	}
+}
+
+// recounts the handles held by a resource
+func (n *NewPool[T]) countHandles(resource T, entry *resourceEntry) {
+	if n.handleCounter == nil {
+		return
+	}
+
+	handles := n.handleCounter(resource)
+	n.handles += handles - entry.handles
+	entry.handles = handles
+}
+
+// drops the handles of a resource leaving the pool from the total
+func (n *NewPool[T]) uncountHandles(entry *resourceEntry) {
+	n.handles -= entry.handles
+	entry.handles = 0
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package pool
+
+// returns 0: Windows and other platforms have no per-process handle limit
+// to report
+func getProcessHandleLimit() int {
+	return 0
+}
@@ -0,0 +1,32 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_StatsHandles(t *testing.T) {
+	handles := map[MockResource]int{}
+	pool := New(getMockCreatorFunc(), 1, maxIdleTime,
+		WithHandleCount(func(resource MockResource) int { return handles[resource] }),
+	)
+	handles[MockResource{id: 1}] = 1
+	handles[MockResource{id: 2}] = 2
+
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	assert.Equal(t, 3, pool.Stats().Handles)
+
+	// recounted when returning to the idle pool
+	handles[first] = 4
+	pool.Release(first)
+	assert.Equal(t, 6, pool.Stats().Handles)
+
+	// evicted for capacity
+	pool.Release(second)
+	assert.Equal(t, 4, pool.Stats().Handles)
+
+	pool.Close()
+	assert.Equal(t, 0, pool.Stats().Handles)
+	assert.LessOrEqual(t, 0, pool.Stats().HandleLimit)
+}
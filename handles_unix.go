@@ -0,0 +1,31 @@
+//go:build unix
+
+package pool
+
+import (
+	"math"
+	"syscall"
+)
+
+// returns the soft limit of open file descriptors of the process; 0 when it
+// is unlimited
+func getProcessHandleLimit() int {
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return 0
+	}
+
+	return getHandleLimit(uint64(limit.Cur))
+}
+
+// returns a soft limit as an int: RLIM_INFINITY, all bits set, is 0 and
+// limits beyond math.MaxInt are clamped to it
+func getHandleLimit(limit uint64) int {
+	if limit == math.MaxUint64 {
+		return 0
+	}
+	if limit > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(limit)
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestGetHandleLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    uint64
+		expected int
+	}{
+		{
+			name:     "with finite limit returns it",
+			limit:    1024,
+			expected: 1024,
+		},
+		{
+			name:     "with infinite limit returns zero",
+			limit:    math.MaxUint64,
+			expected: 0,
+		},
+		{
+			name:     "beyond max int clamps",
+			limit:    math.MaxUint64 - 1,
+			expected: math.MaxInt,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getHandleLimit(tc.limit))
+		})
+	}
+}
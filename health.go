@@ -0,0 +1,38 @@
+package pool
+
+// healthScoreSmoothing is the weight of the latest score in a resource's
+// health score; older scores decay so that one bad reading is not fatal
+const healthScoreSmoothing = 0.3
+
+// WithHealthScore grades resources with score, a value between 0 (unusable)
+// and 1 (healthy), each time they are returned to the idle pool. The pool
+// keeps an exponentially smoothed score per resource, hands out the healthiest
+// idle resource first, and evicts resources whose score falls below minScore.
+// The scorer runs while the pool mutex is held.
+func WithHealthScore[T comparable](score func(T) float64, minScore float64) Option[T] {
+	return func(n *NewPool[T]) {
+		n.healthScorer = score
+		n.minHealthScore = minScore
+	}
+}
+
+// scores a resource returning to the idle pool; returns false if it is unhealthy
+func (n *NewPool[T]) updateHealthScore(resource T, entry *resourceEntry) bool {
+	if n.healthScorer == nil {
+		return true
+	}
+
+	score := n.healthScorer(resource)
+	if entry.isScored {
+		score = healthScoreSmoothing*score + (1-healthScoreSmoothing)*entry.healthScore
+	}
+	entry.healthScore = score
+	entry.isScored = true
+
+	return score >= n.minHealthScore
+}
+
+// reports whether the idle resource a should be handed out before b
+func (n *NewPool[T]) isHealthier(a *resourceEntry, b *resourceEntry) bool {
+	return n.healthScorer != nil && a.healthScore > b.healthScore
+}
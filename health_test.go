@@ -0,0 +1,98 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireHealthiest(t *testing.T) {
+	mockMutex := &MockMutex{}
+	mockMutex.On("Lock")
+	mockMutex.On("Unlock")
+
+	pool := NewPool[MockResource]{
+		creator:     getMockCreatorFunc(),
+		maxIdleTime: maxIdleTime,
+		maxIdleSize: maxIdleSize,
+		mutex:       mockMutex,
+		lock:        make(map[MockResource]*resourceEntry),
+		unlock: map[MockResource]*resourceEntry{
+			MockResource{id: 5}: {releasedAt: time.Now(), healthScore: 0.6, isScored: true},
+			MockResource{id: 6}: {releasedAt: time.Now(), healthScore: 0.9, isScored: true},
+			MockResource{id: 7}: {releasedAt: time.Now(), healthScore: 0.7, isScored: true},
+		},
+		healthScorer:   func(MockResource) float64 { return 1 },
+		minHealthScore: 0.5,
+	}
+
+	var acquired []MockResource
+	for i := 0; i < 3; i++ {
+		resource, _ := pool.Acquire(nil)
+		acquired = append(acquired, resource)
+	}
+
+	assert.Equal(t, []MockResource{{id: 6}, {id: 7}, {id: 5}}, acquired)
+	mockMutex.AssertExpectations(t)
+}
+
+func TestNewPool_ReleaseScoresHealth(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		entry                  *resourceEntry
+		score                  float64
+		expectedHealthScore    float64
+		expectedIdlePoolLength int
+		expectedEvictions      map[EvictReason]int64
+	}{
+		{
+			name:                   "with first healthy score returns resource to idle pool",
+			entry:                  &resourceEntry{acquiredAt: time.Now()},
+			score:                  0.8,
+			expectedHealthScore:    0.8,
+			expectedIdlePoolLength: 1,
+			expectedEvictions:      map[EvictReason]int64{},
+		},
+		{
+			name:                   "with single low score smooths previous health score",
+			entry:                  &resourceEntry{acquiredAt: time.Now(), healthScore: 1, isScored: true},
+			score:                  0,
+			expectedHealthScore:    0.7,
+			expectedIdlePoolLength: 1,
+			expectedEvictions:      map[EvictReason]int64{},
+		},
+		{
+			name:                "with chronically low score evicts resource",
+			entry:               &resourceEntry{acquiredAt: time.Now(), healthScore: 0.5, isScored: true},
+			score:               0.2,
+			expectedHealthScore: 0.41,
+			expectedEvictions:   map[EvictReason]int64{EvictUnhealthy: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockMutex := &MockMutex{}
+			mockMutex.On("Lock")
+			mockMutex.On("Unlock")
+
+			pool := NewPool[MockResource]{
+				creator:        getMockCreatorFunc(),
+				maxIdleTime:    maxIdleTime,
+				maxIdleSize:    maxIdleSize,
+				mutex:          mockMutex,
+				lock:           map[MockResource]*resourceEntry{MockResource{id: 2}: tc.entry},
+				unlock:         make(map[MockResource]*resourceEntry),
+				healthScorer:   func(MockResource) float64 { return tc.score },
+				minHealthScore: 0.5,
+			}
+
+			pool.Release(MockResource{id: 2})
+
+			assert.InDelta(t, tc.expectedHealthScore, tc.entry.healthScore, 1e-9)
+			assert.Equal(t, tc.expectedIdlePoolLength, len(pool.unlock))
+			assert.Equal(t, tc.expectedEvictions, pool.Stats().Evictions)
+			mockMutex.AssertExpectations(t)
+		})
+	}
+}
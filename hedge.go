@@ -0,0 +1,143 @@
+package pool
+
+import (
+	"context"
+	"time"
+)
+
+// WithHedgedAcquire cuts the tail latency of acquires during release
+// droughts: an acquire missing the idle pool below capacity starts the
+// creation on a scheduler goroutine and meanwhile waits for a release, taking
+// whichever resource is ready first. A resource created after its acquire
+// took a released one goes to the idle pool. Hedged creations count toward
+// WithMaxActive while they run, and the creator gets a background context,
+// as the creation may outlive the acquire.
+//
+// Acquires with a nil ctx, which can not wait, and acquires finding no free
+// scheduler goroutine create as usual.
+func WithHedgedAcquire[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isHedged = true
+	}
+}
+
+// hedge is a hedged creation; guarded by the pool mutex
+type hedge[T comparable] struct {
+	// done is closed once the creation finished, unless the acquire is
+	// abandoned; resource is then marked as acquired, or err is set
+	done        chan struct{}
+	isDone      bool
+	isAbandoned bool
+	resource    T
+	err         error
+}
+
+// creates a resource on a scheduler goroutine while waiting for a release,
+// returning whichever is ready first marked as acquired; the pool mutex is
+// released while waiting
+func (n *NewPool[T]) acquireHedged(ctx context.Context, predicate func(T) bool, wait *acquireWait) (T, error) {
+	h := &hedge[T]{done: make(chan struct{})}
+	start := n.now()
+	creator, generation := n.creator, n.generation
+	n.hedging++
+	isStarted := n.scheduler.TryGo(func() {
+		resource, err := n.create(context.Background(), creator)
+		n.mutex.Lock()
+		defer n.mutex.Unlock()
+		n.finishHedge(h, resource, err, generation, start)
+	})
+	if !isStarted {
+		n.hedging--
+		return n.createResource(ctx)
+	}
+	// the hedged creation blocks on the pool mutex until the token is taken
+	n.createRate.take(start)
+	n.stats.hedges++
+
+	if err := n.enterWait(); err != nil {
+		return n.abandonHedge(h, err)
+	}
+	defer func() {
+		n.waiters--
+		n.checkSaturation()
+		n.checkState()
+	}()
+
+	waitStart := n.now()
+	defer func() { wait.add(n.now().Sub(waitStart)) }()
+
+	for {
+		notify := n.getNotify()
+		n.mutex.Unlock()
+		var err error
+		select {
+		case <-h.done:
+		case <-notify:
+		case <-ctx.Done():
+			err = getWaitError(ctx)
+		}
+		n.mutex.Lock()
+
+		if h.isDone {
+			return h.resource, h.err
+		}
+		if err != nil {
+			return n.abandonHedge(h, err)
+		}
+		if n.isClosed {
+			return n.abandonHedge(h, ErrPoolClosed)
+		}
+		if resource, isSuccess := n.getIdleResourceWhere(ctx, predicate); isSuccess {
+			n.stats.reused++
+			n.stats.hedgeReleaseWins++
+			h.isAbandoned = true
+			return resource, nil
+		}
+	}
+}
+
+// gives up waiting for a hedged creation, which goes to the idle pool
+func (n *NewPool[T]) abandonHedge(h *hedge[T], err error) (T, error) {
+	h.isAbandoned = true
+	return *new(T), err
+}
+
+// records a hedged creation and hands it to its acquire, or to the idle pool
+// if the acquire is abandoned; the pool mutex must be held
+func (n *NewPool[T]) finishHedge(h *hedge[T], resource T, err error, generation uint64, start time.Time) {
+	n.hedging--
+	n.notifyWaiters()
+
+	elapsed := n.now().Sub(start)
+	if err != nil {
+		createErr := n.recordCreateFailure(err, elapsed)
+		if !h.isAbandoned {
+			h.finish(*new(T), createErr)
+			return
+		}
+		n.log(LogWarn, "failed to create hedged resource", Field{Key: "error", Value: createErr})
+		return
+	}
+
+	entry := &resourceEntry{createdAt: n.now(), generation: generation}
+	n.recordCreate(resource, entry, elapsed)
+	switch {
+	case !h.isAbandoned:
+		entry.acquiredAt = entry.createdAt
+		n.markActive(resource, entry)
+		h.finish(resource, nil)
+	case n.isClosed:
+		n.evict(resource, entry, EvictClosed)
+	case n.isRetired(entry):
+		n.evict(resource, entry, EvictRetired)
+	default:
+		n.returnIdle(resource, entry)
+	}
+}
+
+// hands the outcome of the creation to the waiting acquire
+func (h *hedge[T]) finish(resource T, err error) {
+	h.resource, h.err = resource, err
+	h.isDone = true
+	close(h.done)
+}
@@ -0,0 +1,142 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithHedgedAcquire(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		isReleased               bool
+		expected                 MockResource
+		expectedHedgeReleaseWins int64
+		expectedIdlePoolLength   int
+	}{
+		{
+			name:                     "release before creation takes released resource",
+			isReleased:               true,
+			expected:                 MockResource{id: 1},
+			expectedHedgeReleaseWins: 1,
+			expectedIdlePoolLength:   1,
+		},
+		{
+			name:     "creation before release takes created resource",
+			expected: MockResource{id: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unblock := make(chan struct{})
+			mockCreator := getMockCreatorFunc()
+			creator := func(ctx context.Context) (MockResource, error) {
+				resource, err := mockCreator(ctx)
+				if resource.id > 1 {
+					<-unblock
+				}
+				return resource, err
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime, WithHedgedAcquire[MockResource]())
+			held, _ := pool.Acquire(nil)
+
+			acquired := make(chan MockResource)
+			go func() {
+				resource, _ := pool.Acquire(context.Background())
+				acquired <- resource
+			}()
+			assert.Eventually(t, func() bool { return pool.Stats().Creating == 1 }, time.Second, time.Millisecond)
+			if tc.isReleased {
+				pool.Release(held)
+			} else {
+				close(unblock)
+			}
+
+			assert.Equal(t, tc.expected, <-acquired)
+			if tc.isReleased {
+				close(unblock)
+			}
+			assert.Eventually(t, func() bool { return pool.Stats().Creating == 0 }, time.Second, time.Millisecond)
+			stats := pool.Stats()
+			assert.Equal(t, int64(1), stats.Hedges)
+			assert.Equal(t, tc.expectedHedgeReleaseWins, stats.HedgeReleaseWins)
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+		})
+	}
+}
+
+func TestNewPool_AcquireWithHedgedAcquireAndMaxActive(t *testing.T) {
+	unblock := make(chan struct{})
+	creator := func(context.Context) (MockResource, error) {
+		<-unblock
+		return MockResource{id: 1}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithHedgedAcquire[MockResource](),
+		WithMaxActive[MockResource](1),
+	)
+	go pool.Acquire(context.Background())
+	assert.Eventually(t, func() bool { return pool.Stats().Creating == 1 }, time.Second, time.Millisecond)
+
+	_, err := pool.Acquire(nil)
+
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+	close(unblock)
+}
+
+func TestNewPool_AcquireWithHedgedAcquireWithoutContext(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithHedgedAcquire[MockResource]())
+
+	resource, err := pool.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 1}, resource)
+	assert.Equal(t, int64(0), pool.Stats().Hedges)
+}
+
+func TestNewPool_AcquireWithHedgedAcquireWithoutGoroutine(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithSynchronous[MockResource](),
+		WithHedgedAcquire[MockResource](),
+		WithCreateRate[MockResource](1, 2),
+	)
+
+	pool.Acquire(context.Background())
+	pool.Acquire(context.Background())
+
+	assert.Equal(t, float64(0), pool.createRate.tokens)
+	assert.Equal(t, int64(0), pool.Stats().Hedges)
+}
+
+func TestNewPool_AcquireWithHedgedAcquireAndStateChange(t *testing.T) {
+	unblock := make(chan struct{})
+	mockCreator := getAtomicMockCreatorFunc()
+	creator := func(ctx context.Context) (MockResource, error) {
+		resource, err := mockCreator(ctx)
+		if resource.id > 1 {
+			<-unblock
+		}
+		return resource, err
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithHedgedAcquire[MockResource](),
+		WithStateChange[MockResource](func(PoolState, PoolState) {}),
+	)
+	held, _ := pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.PoolState() == StateExhausted }, time.Second, time.Millisecond)
+	pool.Release(held)
+	<-acquired
+
+	assert.Equal(t, StateHealthy, pool.PoolState())
+	close(unblock)
+}
@@ -0,0 +1,59 @@
+package pool
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+type holderTagKey struct{}
+
+// Holder describes an acquired resource in the report of Holders
+type Holder struct {
+	// Tag is the holder tag of the acquire context
+	Tag string
+	// AcquiredAt is when the resource was acquired
+	AcquiredAt time.Time
+	// Deadline is the hold deadline of the lease of AcquireLease; zero for
+	// resources acquired without a lease, or leases without a deadline
+	Deadline time.Time
+	// Renewals is the number of times the lease was extended with Extend
+	Renewals int
+}
+
+// HolderTag returns a context whose acquires record tag as the holder of the
+// resource, e.g. the name of the calling code path, for Holders
+func HolderTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, holderTagKey{}, tag)
+}
+
+// returns the holder tag of ctx; empty if it has none
+func getHolderTag(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	tag, _ := ctx.Value(holderTagKey{}).(string)
+	return tag
+}
+
+// returns the holders of the acquired resources, longest held first, e.g. to
+// find out who is hogging the pool. Resources of a pool with WithLocalCache
+// are not tracked while acquired.
+func (n *NewPool[T]) Holders() []Holder {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	holders := make([]Holder, 0, len(n.lock))
+	for resource, entry := range n.lock {
+		holder := Holder{Tag: entry.tag, AcquiredAt: entry.acquiredAt}
+		if lease, isFound := n.leases[resource]; isFound {
+			holder.Deadline, holder.Renewals = lease.getRenewal()
+		}
+		holders = append(holders, holder)
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].AcquiredAt.Before(holders[j].AcquiredAt)
+	})
+	return holders
+}
@@ -0,0 +1,56 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Holders(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := &MockClock{now: start}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+
+	resource, _ := pool.Acquire(HolderTag(context.Background(), "checkout"))
+	clock.Advance(time.Second)
+	pool.Acquire(nil)
+	clock.Advance(time.Second)
+	pool.AcquireN(HolderTag(context.Background(), "report"), 1)
+
+	assert.Equal(t, []Holder{
+		{Tag: "checkout", AcquiredAt: start},
+		{AcquiredAt: start.Add(time.Second)},
+		{Tag: "report", AcquiredAt: start.Add(2 * time.Second)},
+	}, pool.Holders())
+
+	pool.Release(resource)
+	clock.Advance(time.Second)
+	pool.Acquire(nil)
+
+	assert.Equal(t, []Holder{
+		{AcquiredAt: start.Add(time.Second)},
+		{Tag: "report", AcquiredAt: start.Add(2 * time.Second)},
+		{AcquiredAt: start.Add(3 * time.Second)},
+	}, pool.Holders())
+}
+
+func TestNewPool_HoldersWithLeaseRenewals(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := &MockClock{now: start}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithLeaseTTL[MockResource](time.Minute),
+	)
+	lease, _ := pool.AcquireLease(HolderTag(context.Background(), "export"))
+
+	clock.Advance(30 * time.Second)
+	assert.NoError(t, lease.Extend(time.Minute))
+	clock.Advance(30 * time.Second)
+	assert.NoError(t, lease.Extend(time.Minute))
+
+	assert.Equal(t, 2, lease.Renewals())
+	assert.Equal(t, []Holder{
+		{Tag: "export", AcquiredAt: start, Deadline: start.Add(2 * time.Minute), Renewals: 2},
+	}, pool.Holders())
+}
@@ -0,0 +1,165 @@
+package pool
+
+import (
+	"fmt"
+	"time"
+)
+
+// Hooks are optional callbacks run at points of a resource's lifecycle. Except
+// for OnWarm, they run while the pool mutex is held, so they must not call back
+// into the pool.
+// A panicking hook propagates to the caller unless WithPanicContainment is set.
+type Hooks[T comparable] struct {
+	// OnCreate is called after the creator returned a new resource
+	OnCreate func(T, HookInfo)
+	// OnWarm is called for resources created in the background, such as by
+	// warmup, before they are added to the idle pool; it is skipped for
+	// resources created on an acquire miss to keep acquire latency low. It
+	// runs without the pool mutex, so it may do slow work like priming caches
+	// or preparing statements. Resources it fails for are evicted.
+	OnWarm func(T, HookInfo) error
+	// OnAcquire is called when Acquire hands out a resource, reused or new
+	OnAcquire func(T, HookInfo)
+	// OnRelease is called when an acquired resource is released
+	OnRelease func(T, HookInfo)
+	// OnEvict is called when the pool decides to drop a resource
+	OnEvict func(T, HookInfo)
+	// OnDestroy is called after a dropped resource went through the destroyer
+	OnDestroy func(T, HookInfo)
+}
+
+// HookInfo describes the resource and the lifecycle step a hook is called for
+type HookInfo struct {
+	// CreatedAt is the time the resource was created
+	CreatedAt time.Time
+	// Elapsed is the creation time for OnCreate and OnWarm, the idle time for OnAcquire
+	// (zero for new resources), the hold time for OnRelease, the time since the
+	// resource was last acquired or released for OnEvict, and the destroyer
+	// run time for OnDestroy
+	Elapsed time.Duration
+	// Reused is set for OnAcquire when the resource came from the idle pool
+	Reused bool
+	// Reason is set for OnEvict and OnDestroy
+	Reason EvictReason
+	// Err is the error returned by the destroyer, for OnDestroy
+	Err error
+	// Metadata is the metadata attached to the resource
+	Metadata *Metadata
+}
+
+// WithHooks sets the lifecycle callbacks of the pool
+func WithHooks[T comparable](hooks Hooks[T]) Option[T] {
+	return func(n *NewPool[T]) {
+		n.hooks = hooks
+	}
+}
+
+func (n *NewPool[T]) runCreateHook(resource T, entry *resourceEntry, elapsed time.Duration) {
+	if n.hooks.OnCreate == nil {
+		return
+	}
+
+	n.callHook("OnCreate", func() {
+		n.hooks.OnCreate(resource, HookInfo{
+			CreatedAt: entry.createdAt,
+			Elapsed:   elapsed,
+			Metadata:  entry.getMetadata(),
+		})
+	})
+}
+
+func (n *NewPool[T]) runAcquireHook(resource T, entry *resourceEntry) {
+	if n.hooks.OnAcquire == nil {
+		return
+	}
+
+	info := HookInfo{CreatedAt: entry.createdAt, Metadata: entry.getMetadata()}
+	if !entry.releasedAt.IsZero() {
+		info.Elapsed = entry.acquiredAt.Sub(entry.releasedAt)
+		info.Reused = true
+	}
+	n.callHook("OnAcquire", func() {
+		n.hooks.OnAcquire(resource, info)
+	})
+}
+
+func (n *NewPool[T]) runReleaseHook(resource T, entry *resourceEntry) {
+	if n.hooks.OnRelease == nil {
+		return
+	}
+
+	n.callHook("OnRelease", func() {
+		n.hooks.OnRelease(resource, HookInfo{
+			CreatedAt: entry.createdAt,
+			Elapsed:   n.now().Sub(entry.acquiredAt),
+			Metadata:  entry.getMetadata(),
+		})
+	})
+}
+
+func (n *NewPool[T]) runEvictHook(resource T, entry *resourceEntry, reason EvictReason) {
+	if n.hooks.OnEvict == nil {
+		return
+	}
+
+	lastUsedAt := entry.acquiredAt
+	if entry.releasedAt.After(lastUsedAt) {
+		lastUsedAt = entry.releasedAt
+	}
+	n.callHook("OnEvict", func() {
+		n.hooks.OnEvict(resource, HookInfo{
+			CreatedAt: entry.createdAt,
+			Elapsed:   n.now().Sub(lastUsedAt),
+			Reason:    reason,
+			Metadata:  entry.getMetadata(),
+		})
+	})
+}
+
+func (n *NewPool[T]) runDestroyHook(resource T, entry *resourceEntry, reason EvictReason, elapsed time.Duration, err error) {
+	if n.hooks.OnDestroy == nil {
+		return
+	}
+
+	n.callHook("OnDestroy", func() {
+		n.hooks.OnDestroy(resource, HookInfo{
+			CreatedAt: entry.createdAt,
+			Elapsed:   elapsed,
+			Reason:    reason,
+			Err:       err,
+			Metadata:  entry.getMetadata(),
+		})
+	})
+}
+
+// runs the warm hook without the pool mutex held; a recovered panic in panic
+// containment mode is reported as an error
+func (n *NewPool[T]) runWarmHook(resource T, entry *resourceEntry, elapsed time.Duration) (err error) {
+	if n.hooks.OnWarm == nil {
+		return nil
+	}
+
+	if n.isPanicContained {
+		n.mutex.Lock()
+		isDisabled := n.disabledHooks["OnWarm"]
+		n.mutex.Unlock()
+		if isDisabled {
+			return nil
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				n.mutex.Lock()
+				n.containPanic("OnWarm", r)
+				n.mutex.Unlock()
+				err = fmt.Errorf("pool: OnWarm panicked: %v", r)
+			}
+		}()
+	}
+
+	return n.hooks.OnWarm(resource, HookInfo{
+		CreatedAt: entry.createdAt,
+		Elapsed:   elapsed,
+		Metadata:  entry.getMetadata(),
+	})
+}
@@ -0,0 +1,223 @@
+package pool
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type hookCall struct {
+	hook     string
+	resource MockResource
+	info     HookInfo
+}
+
+func TestNewPool_Hooks(t *testing.T) {
+	destroyErr := errors.New("destroy error")
+	createdAt := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		idle          map[MockResource]*resourceEntry
+		used          map[MockResource]*resourceEntry
+		destroyer     func(MockResource) error
+		run           func(*NewPool[MockResource])
+		expectedCalls []hookCall
+	}{
+		{
+			name: "with empty pool runs create and acquire hooks",
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedCalls: []hookCall{
+				{hook: "OnCreate", resource: MockResource{id: 1}},
+				{hook: "OnAcquire", resource: MockResource{id: 1}},
+			},
+		},
+		{
+			name: "with idle resource runs acquire hook with reuse info",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {createdAt: createdAt, releasedAt: time.Now()},
+			},
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedCalls: []hookCall{
+				{hook: "OnAcquire", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt, Reused: true}},
+			},
+		},
+		{
+			name: "with valid resource runs release hook",
+			used: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {createdAt: createdAt, acquiredAt: time.Now()},
+			},
+			run: func(pool *NewPool[MockResource]) {
+				pool.Release(MockResource{id: 2})
+			},
+			expectedCalls: []hookCall{
+				{hook: "OnRelease", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt}},
+			},
+		},
+		{
+			name: "with expired idle resource runs evict and destroy hooks",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {createdAt: createdAt, releasedAt: time.Now().Add(-2 * maxIdleTime)},
+			},
+			destroyer: func(MockResource) error { return destroyErr },
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+			},
+			expectedCalls: []hookCall{
+				{hook: "OnEvict", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt, Reason: EvictExpired}},
+				{hook: "OnDestroy", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt, Reason: EvictExpired, Err: destroyErr}},
+				{hook: "OnCreate", resource: MockResource{id: 1}},
+				{hook: "OnAcquire", resource: MockResource{id: 1}},
+			},
+		},
+		{
+			name: "with full idle pool runs release, evict and destroy hooks",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 5}: {releasedAt: time.Now()},
+				MockResource{id: 6}: {releasedAt: time.Now()},
+				MockResource{id: 7}: {releasedAt: time.Now()},
+			},
+			used: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {createdAt: createdAt, acquiredAt: time.Now()},
+			},
+			run: func(pool *NewPool[MockResource]) {
+				pool.Release(MockResource{id: 2})
+			},
+			expectedCalls: []hookCall{
+				{hook: "OnRelease", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt}},
+				{hook: "OnEvict", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt, Reason: EvictCapacity}},
+				{hook: "OnDestroy", resource: MockResource{id: 2}, info: HookInfo{CreatedAt: createdAt, Reason: EvictCapacity}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.idle == nil {
+				tc.idle = make(map[MockResource]*resourceEntry)
+			}
+			if tc.used == nil {
+				tc.used = make(map[MockResource]*resourceEntry)
+			}
+
+			var calls []hookCall
+			record := func(hook string) func(MockResource, HookInfo) {
+				return func(resource MockResource, info HookInfo) {
+					if hook == "OnCreate" || hook == "OnAcquire" && !info.Reused {
+						// new resources carry the current time
+						assert.WithinDuration(t, time.Now(), info.CreatedAt, time.Second)
+						info.CreatedAt = time.Time{}
+					}
+					// durations depend on the test timing
+					info.Elapsed = 0
+					assert.NotNil(t, info.Metadata)
+					info.Metadata = nil
+					calls = append(calls, hookCall{hook: hook, resource: resource, info: info})
+				}
+			}
+
+			mockMutex := &MockMutex{}
+			mockMutex.On("Lock")
+			mockMutex.On("Unlock")
+
+			pool := NewPool[MockResource]{
+				creator:     getMockCreatorFunc(),
+				maxIdleTime: maxIdleTime,
+				maxIdleSize: maxIdleSize,
+				mutex:       mockMutex,
+				lock:        tc.used,
+				unlock:      tc.idle,
+				destroyer:   tc.destroyer,
+				hooks: Hooks[MockResource]{
+					OnCreate:  record("OnCreate"),
+					OnAcquire: record("OnAcquire"),
+					OnRelease: record("OnRelease"),
+					OnEvict:   record("OnEvict"),
+					OnDestroy: record("OnDestroy"),
+				},
+			}
+
+			tc.run(&pool)
+
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func TestWithDestroyer(t *testing.T) {
+	var destroyed []MockResource
+	pool := New(
+		getMockCreatorFunc(),
+		-1,
+		maxIdleTime,
+		WithDestroyer(func(resource MockResource) error {
+			destroyed = append(destroyed, resource)
+			return nil
+		}),
+	)
+
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	assert.Equal(t, []MockResource{{id: 1}}, destroyed)
+}
+
+func TestNewPool_OnWarm(t *testing.T) {
+	testCases := []struct {
+		name               string
+		onWarm             func(MockResource, HookInfo) error
+		options            []Option[MockResource]
+		expectedIdle       int
+		expectedWarmFailed int64
+	}{
+		{
+			name:         "with successful hook adds warmed resources to idle pool",
+			onWarm:       func(MockResource, HookInfo) error { return nil },
+			expectedIdle: 2,
+		},
+		{
+			name:               "with failing hook evicts resources",
+			onWarm:             func(MockResource, HookInfo) error { return errors.New("error response") },
+			expectedWarmFailed: 2,
+		},
+		{
+			name:               "with panicking hook in containment mode evicts resources",
+			onWarm:             func(MockResource, HookInfo) error { panic("hook failure") },
+			options:            []Option[MockResource]{WithPanicContainment[MockResource](0)},
+			expectedWarmFailed: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warmed := make(chan MockResource, 2)
+			onWarm := func(resource MockResource, info HookInfo) error {
+				warmed <- resource
+				return tc.onWarm(resource, info)
+			}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, append(tc.options,
+				WithWarmup[MockResource](2),
+				WithHooks(Hooks[MockResource]{OnWarm: onWarm}),
+			)...)
+
+			assert.Eventually(t, func() bool {
+				stats := pool.Stats()
+				return stats.Idle+int(stats.Evictions[EvictWarmFailed]) == 2
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, tc.expectedWarmFailed, pool.Stats().Evictions[EvictWarmFailed])
+			assert.Len(t, warmed, 2)
+
+			// demand-miss creations skip the hook
+			pool.Acquire(nil)
+			pool.Acquire(nil)
+			pool.Acquire(nil)
+			assert.Len(t, warmed, 2)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package pool
+
+// WithIdentity tracks resources by the identity returned by identity, e.g. a
+// connection ID, instead of by value equality. Resources whose fields change
+// while they are acquired, such as structs holding per-use state, are then
+// found again on Release, Invalidate, Metadata and ResourceInfo, and the pool
+// keeps the value last released. identity must return the same key for a
+// resource over its lifetime, and distinct keys for distinct resources.
+func WithIdentity[T comparable, K comparable](identity func(T) K) Option[T] {
+	return func(n *NewPool[T]) {
+		n.identity = func(resource T) any {
+			return identity(resource)
+		}
+		n.identities = make(map[any]T)
+	}
+}
+
+// returns the value the pool tracks resource under, looked up by its
+// identity; the pool mutex must be held
+func (n *NewPool[T]) getTracked(resource T) T {
+	if n.identity == nil {
+		return resource
+	}
+
+	if tracked, isFound := n.identities[n.identity(resource)]; isFound {
+		return tracked
+	}
+	return resource
+}
+
+// tracks an acquired resource under its identity; the pool mutex must be held
+func (n *NewPool[T]) trackIdentity(resource T) {
+	if n.identity != nil {
+		n.identities[n.identity(resource)] = resource
+	}
+}
+
+// stops tracking a destroyed resource under its identity; the pool mutex must
+// be held
+func (n *NewPool[T]) untrackIdentity(resource T) {
+	if n.identity == nil {
+		return
+	}
+
+	key := n.identity(resource)
+	if tracked, isFound := n.identities[key]; isFound && tracked == resource {
+		delete(n.identities, key)
+	}
+}
+
+// moves the entry of an acquired resource whose fields changed during use to
+// the value handed back by the caller, and returns that value; the pool mutex
+// must be held
+func (n *NewPool[T]) rekey(resource T) T {
+	tracked := n.getTracked(resource)
+	if tracked == resource {
+		return resource
+	}
+	entry, isFound := n.lock[tracked]
+	if !isFound {
+		return resource
+	}
+
+	delete(n.lock, tracked)
+	n.lock[resource] = entry
+	n.identities[n.identity(resource)] = resource
+	if current, isFound := n.affinities[entry.affinity]; isFound && current == tracked {
+		n.affinities[entry.affinity] = resource
+	}
+	return resource
+}
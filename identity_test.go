@@ -0,0 +1,108 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// statefulResource is a resource whose fields change during use
+type statefulResource struct {
+	id   int
+	uses int
+}
+
+func getStatefulCreatorFunc() func(context.Context) (statefulResource, error) {
+	id := 0
+	return func(context.Context) (statefulResource, error) {
+		id++
+		return statefulResource{id: id}, nil
+	}
+}
+
+func getStatefulIdentity(resource statefulResource) int {
+	return resource.id
+}
+
+func TestNewPool_ReleaseWithIdentity(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		options                []Option[statefulResource]
+		expectedErr            error
+		expectedIdlePoolLength int
+		expectedResource       statefulResource
+	}{
+		{
+			name:             "without identity does not find mutated resource",
+			expectedErr:      ErrNotAcquired,
+			expectedResource: statefulResource{id: 2},
+		},
+		{
+			name:                   "with identity keeps mutated resource",
+			options:                []Option[statefulResource]{WithIdentity(getStatefulIdentity)},
+			expectedIdlePoolLength: 1,
+			expectedResource:       statefulResource{id: 1, uses: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getStatefulCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+			resource.uses++
+
+			err := pool.TryRelease(resource)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+			resource, _ = pool.Acquire(nil)
+			assert.Equal(t, tc.expectedResource, resource)
+		})
+	}
+}
+
+func TestNewPool_InvalidateWithIdentity(t *testing.T) {
+	var destroyed []statefulResource
+	pool := New(getStatefulCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithIdentity(getStatefulIdentity),
+		WithDestroyer(func(resource statefulResource) error {
+			destroyed = append(destroyed, resource)
+			return nil
+		}),
+	)
+	resource, _ := pool.Acquire(nil)
+	resource.uses++
+
+	err := pool.Invalidate(resource)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []statefulResource{{id: 1, uses: 1}}, destroyed)
+	assert.Equal(t, 0, pool.Stats().Active)
+	assert.Empty(t, pool.identities)
+}
+
+func TestNewPool_ResourceInfoWithIdentity(t *testing.T) {
+	pool := New(getStatefulCreatorFunc(), maxIdleSize, maxIdleTime, WithIdentity(getStatefulIdentity))
+	resource, _ := pool.Acquire(nil)
+	resource.uses++
+
+	info, isFound := pool.ResourceInfo(resource)
+	_, isMetadataFound := pool.Metadata(resource)
+
+	assert.True(t, isFound)
+	assert.True(t, info.IsAcquired)
+	assert.True(t, isMetadataFound)
+}
+
+func TestNewPool_EvictIdleWithIdentity(t *testing.T) {
+	pool := New(getStatefulCreatorFunc(), maxIdleSize, maxIdleTime, WithIdentity(getStatefulIdentity))
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+	resource.uses++
+
+	isEvicted := pool.EvictIdle(resource)
+
+	assert.True(t, isEvicted)
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Empty(t, pool.identities)
+}
@@ -0,0 +1,130 @@
+package pool
+
+import (
+	"time"
+)
+
+// WithResourceIdleTime overrides the pool's maxIdleTime for individual
+// resources, e.g. to expire connections to a flaky region sooner. idleTime is
+// called once when a resource is created; a zero or negative duration keeps
+// the pool's maxIdleTime.
+func WithResourceIdleTime[T comparable](idleTime func(T) time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.idleTimer = idleTime
+	}
+}
+
+// IdleTimeMode sets what the max idle time of a resource is measured from
+type IdleTimeMode int
+
+const (
+	// IdleSinceAcquire measures the idle time from the last release while the
+	// resource is idle, and drops released resources which were acquired
+	// longer than the max idle time ago; it is the default
+	IdleSinceAcquire IdleTimeMode = iota
+	// IdleSinceRelease only measures the time spent in the idle pool, so a
+	// resource held for long is still reused once released
+	IdleSinceRelease
+)
+
+// WithIdleTimeMode sets what the max idle time is measured from. To expire
+// resources by the time since they were created instead, or as well, use
+// WithMaxLifetime.
+func WithIdleTimeMode[T comparable](mode IdleTimeMode) Option[T] {
+	return func(n *NewPool[T]) {
+		n.idleTimeMode = mode
+	}
+}
+
+// WithMaxLifetime expires resources maxLifetime after they were created,
+// whether they are used or not, e.g. to rotate connections behind a load
+// balancer. Idle resources are swept once past it, and acquired ones are
+// dropped when they are released, with EvictExpired. It applies on top of the
+// max idle time; to expire resources by age only, pass a max idle time of at
+// least maxLifetime.
+func WithMaxLifetime[T comparable](maxLifetime time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.maxLifetime = maxLifetime
+	}
+}
+
+// WithIdleOverflow makes maxIdleSize a soft target of the idle pool, up to a
+// hard cap of maxOverflowSize: releases finding the idle pool at its target
+// put the resource into an overflow buffer, where it expires after
+// overflowIdleTime, instead of dropping it. Bursty workloads briefly above the
+// target then reuse the buffered resources instead of churning creations.
+func WithIdleOverflow[T comparable](maxOverflowSize int, overflowIdleTime time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.maxOverflowSize = maxOverflowSize
+		n.overflowIdleTime = overflowIdleTime
+	}
+}
+
+// returns the hard cap of idle resources, the max idle size without an
+// overflow buffer, or the pressured idle size under memory pressure
+func (n *NewPool[T]) getMaxIdleCap() int {
+	if n.isUnderPressure && n.pressuredIdleSize < n.maxIdleSize {
+		return n.pressuredIdleSize
+	}
+	if n.maxOverflowSize > n.maxIdleSize {
+		return n.maxOverflowSize
+	}
+	return n.maxIdleSize
+}
+
+// returns when an idle resource expires: the max idle time after it was
+// released, the overflow idle time for a resource of the overflow buffer, or
+// the max lifetime after it was created if that comes first
+func (n *NewPool[T]) getIdleDeadline(entry *resourceEntry) time.Time {
+	deadline := entry.releasedAt.Add(n.getMaxIdleTime(entry))
+	if entry.isOverflow {
+		if overflowDeadline := entry.releasedAt.Add(n.overflowIdleTime); overflowDeadline.Before(deadline) {
+			deadline = overflowDeadline
+		}
+	}
+	if n.maxLifetime > 0 {
+		if lifetimeDeadline := entry.createdAt.Add(n.maxLifetime); lifetimeDeadline.Before(deadline) {
+			return lifetimeDeadline
+		}
+	}
+	return deadline
+}
+
+// reports whether a released resource expired while it was acquired
+func (n *NewPool[T]) isExpiredOnRelease(entry *resourceEntry) bool {
+	if n.maxLifetime > 0 && entry.createdAt.Before(n.now().Add(-n.maxLifetime)) {
+		return true
+	}
+	return n.idleTimeMode == IdleSinceAcquire && n.isExpired(entry, entry.acquiredAt)
+}
+
+// WithExpiryJitter spreads the expiry of resources created together, e.g. in
+// a burst, so they are not all recycled at the same instant: the max idle
+// time of each resource is drawn at creation within ± fraction of its
+// nominal value. fraction is capped at 1.
+func WithExpiryJitter[T comparable](fraction float64) Option[T] {
+	return func(n *NewPool[T]) {
+		n.expiryJitter = fraction
+	}
+}
+
+// returns the max idle time of a resource
+func (n *NewPool[T]) getMaxIdleTime(entry *resourceEntry) time.Duration {
+	if entry.maxIdleTime > 0 {
+		return entry.maxIdleTime
+	}
+	return n.maxIdleTime
+}
+
+// returns idleTime moved by up to ± fraction of it, keeping it positive;
+// random returns a value in [0, max)
+func getJitteredIdleTime(idleTime time.Duration, fraction float64, random func(max int64) int64) time.Duration {
+	spread := int64(float64(idleTime) * fraction)
+	if spread >= int64(idleTime) {
+		spread = int64(idleTime) - 1
+	}
+	if spread <= 0 {
+		return idleTime
+	}
+	return idleTime - time.Duration(spread) + time.Duration(random(2*spread+1))
+}
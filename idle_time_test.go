@@ -0,0 +1,247 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithResourceIdleTime(t *testing.T) {
+	testCases := []struct {
+		name             string
+		idleTime         time.Duration
+		advance          time.Duration
+		expectedResource MockResource
+		expectedEvicted  int64
+	}{
+		{
+			name:             "before resource idle time reuses idle resource",
+			idleTime:         time.Second,
+			advance:          time.Second - time.Nanosecond,
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "after resource idle time evicts idle resource",
+			idleTime:         time.Second,
+			advance:          time.Second + time.Nanosecond,
+			expectedResource: MockResource{id: 2},
+			expectedEvicted:  1,
+		},
+		{
+			name:             "with longer resource idle time reuses idle resource",
+			idleTime:         2 * maxIdleTime,
+			advance:          maxIdleTime + time.Nanosecond,
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "without resource idle time keeps pool default",
+			advance:          maxIdleTime + time.Nanosecond,
+			expectedResource: MockResource{id: 2},
+			expectedEvicted:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithResourceIdleTime(func(MockResource) time.Duration { return tc.idleTime }),
+			)
+
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			clock.Advance(tc.advance)
+
+			resource, _ = pool.Acquire(nil)
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedEvicted, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithResourceIdleTime(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithResourceIdleTime(func(MockResource) time.Duration { return time.Second }),
+	)
+	resource, _ := pool.Acquire(nil)
+	clock.Advance(2 * time.Second)
+
+	pool.Release(resource)
+
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictExpired])
+}
+
+func TestNewPool_AcquireWithExpiryJitter(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), 100, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithExpiryJitter[MockResource](0.5),
+	)
+	resources, _ := pool.AcquireN(nil, 100)
+	pool.ReleaseAll(resources)
+
+	clock.Advance(maxIdleTime / 2)
+	pool.deleteInvalidIdleResources()
+	assert.Equal(t, 100, pool.NumIdle())
+
+	clock.Advance(maxIdleTime / 2)
+	pool.deleteInvalidIdleResources()
+	assert.Greater(t, pool.NumIdle(), 0)
+	assert.Less(t, pool.NumIdle(), 100)
+
+	clock.Advance(maxIdleTime/2 + time.Nanosecond)
+	pool.deleteInvalidIdleResources()
+	assert.Equal(t, 0, pool.NumIdle())
+}
+
+func TestGetJitteredIdleTime(t *testing.T) {
+	testCases := []struct {
+		name             string
+		fraction         float64
+		random           func(int64) int64
+		expectedIdleTime time.Duration
+	}{
+		{
+			name:             "without fraction keeps idle time",
+			expectedIdleTime: time.Minute,
+		},
+		{
+			name:             "with lowest draw shortens idle time by fraction",
+			fraction:         0.25,
+			random:           func(int64) int64 { return 0 },
+			expectedIdleTime: 45 * time.Second,
+		},
+		{
+			name:             "with highest draw lengthens idle time by fraction",
+			fraction:         0.25,
+			random:           func(max int64) int64 { return max - 1 },
+			expectedIdleTime: 75 * time.Second,
+		},
+		{
+			name:             "with fraction above one keeps idle time positive",
+			fraction:         2,
+			random:           func(int64) int64 { return 0 },
+			expectedIdleTime: time.Nanosecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIdleTime, getJitteredIdleTime(time.Minute, tc.fraction, tc.random))
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithIdleTimeMode(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []Option[MockResource]
+		held            time.Duration
+		expectedIdle    int
+		expectedEvicted int64
+	}{
+		{
+			name:            "since acquire drops resource held past max idle time",
+			held:            maxIdleTime + time.Second,
+			expectedEvicted: 1,
+		},
+		{
+			name:         "since release keeps resource held past max idle time",
+			options:      []Option[MockResource]{WithIdleTimeMode[MockResource](IdleSinceRelease)},
+			held:         maxIdleTime + time.Second,
+			expectedIdle: 1,
+		},
+		{
+			name:            "with max lifetime drops resource past it",
+			options:         []Option[MockResource]{WithIdleTimeMode[MockResource](IdleSinceRelease), WithMaxLifetime[MockResource](time.Second)},
+			held:            2 * time.Second,
+			expectedEvicted: 1,
+		},
+		{
+			name:         "with max lifetime keeps resource before it",
+			options:      []Option[MockResource]{WithMaxLifetime[MockResource](time.Minute)},
+			held:         time.Second,
+			expectedIdle: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, append(tc.options, WithClock[MockResource](clock))...)
+			resource, _ := pool.Acquire(nil)
+			clock.Advance(tc.held)
+
+			pool.Release(resource)
+
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, tc.expectedEvicted, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
+
+func TestNewPool_AcquireWithMaxLifetime(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMaxLifetime[MockResource](3*time.Second),
+	)
+	resource, _ := pool.Acquire(nil)
+	clock.Advance(2 * time.Second)
+	pool.Release(resource)
+	clock.Advance(time.Second + time.Nanosecond)
+
+	resource, _ = pool.Acquire(nil)
+
+	assert.Equal(t, MockResource{id: 2}, resource)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictExpired])
+}
+
+func TestNewPool_ReleaseWithIdleOverflow(t *testing.T) {
+	testCases := []struct {
+		name             string
+		advance          time.Duration
+		expectedIdle     int
+		expectedCapacity int64
+		expectedExpired  int64
+	}{
+		{
+			name:             "keeps releases past target in overflow buffer up to hard cap",
+			expectedIdle:     3,
+			expectedCapacity: 1,
+		},
+		{
+			name:             "sweeps overflow buffer after overflow idle time",
+			advance:          time.Second + time.Nanosecond,
+			expectedIdle:     1,
+			expectedCapacity: 1,
+			expectedExpired:  2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), 1, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithIdleOverflow[MockResource](3, time.Second),
+			)
+			resources, _ := pool.AcquireN(nil, 4)
+			pool.ReleaseAll(resources)
+
+			clock.Advance(tc.advance)
+			pool.mutex.Lock()
+			pool.deleteInvalidIdleResources()
+			pool.mutex.Unlock()
+
+			assert.Equal(t, tc.expectedIdle, pool.NumIdle())
+			assert.Equal(t, tc.expectedCapacity, pool.Stats().Evictions[EvictCapacity])
+			assert.Equal(t, tc.expectedExpired, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
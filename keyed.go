@@ -0,0 +1,297 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// KeyedPool keeps a separate resource pool per key, e.g. one pool of
+// connections per host. Each key pool is created on first use with the
+// keyed pool's limits and options, and removed once it holds no resources.
+type KeyedPool[K comparable, T comparable] struct {
+	creator     func(context.Context, K) (T, error)
+	maxIdleSize int
+	maxIdleTime time.Duration
+	options     []Option[T]
+	scheduler   *Scheduler
+	tokens      semaphore
+	mutex       sync.Mutex
+	pools       map[K]*NewPool[T]
+	isClosed    bool
+	// removed holds the counters of removed key pools
+	removed Stats
+
+	maxActive    int
+	maxActiveKey int
+
+	warmupSizes map[K]int
+	warmupSize  func(K) int
+}
+
+// KeyedOption configures optional keyed pool behavior
+type KeyedOption[K comparable, T comparable] func(*KeyedPool[K, T])
+
+// WithKeyOptions applies options to the pool of every key
+func WithKeyOptions[K comparable, T comparable](options ...Option[T]) KeyedOption[K, T] {
+	return func(k *KeyedPool[K, T]) {
+		k.options = append(k.options, options...)
+	}
+}
+
+// WithKeyMaxActive caps the number of acquired resources of each key
+func WithKeyMaxActive[K comparable, T comparable](maxActive int) KeyedOption[K, T] {
+	return func(k *KeyedPool[K, T]) {
+		k.maxActiveKey = maxActive
+	}
+}
+
+// WithTotalMaxActive caps the number of acquired resources across all keys.
+// At capacity, Acquire waits for a release of any key until ctx is done; with
+// a nil ctx it returns ErrPoolExhausted instead of waiting.
+func WithTotalMaxActive[K comparable, T comparable](maxActive int) KeyedOption[K, T] {
+	return func(k *KeyedPool[K, T]) {
+		k.maxActive = maxActive
+	}
+}
+
+// WithKeyWarmup warms the listed keys with their own number of idle
+// resources when the keyed pool is constructed, so hot keys start with more
+// warm resources than rarely used ones
+func WithKeyWarmup[K comparable, T comparable](sizes map[K]int) KeyedOption[K, T] {
+	return func(k *KeyedPool[K, T]) {
+		k.warmupSizes = sizes
+	}
+}
+
+// WithKeyWarmupFunc sets the number of idle resources warmed for a key when
+// its pool is created on first use. Keys listed in WithKeyWarmup use their
+// listed size instead.
+func WithKeyWarmupFunc[K comparable, T comparable](size func(K) int) KeyedOption[K, T] {
+	return func(k *KeyedPool[K, T]) {
+		k.warmupSize = size
+	}
+}
+
+// creates or returns a ready-to-use item from the pool of key
+func (k *KeyedPool[K, T]) Acquire(ctx context.Context, key K) (T, error) {
+	resource, _, err := k.AcquireWithInfo(ctx, key)
+	return resource, err
+}
+
+// acquires like Acquire and reports whether the resource was reused or freshly
+// created, and its age
+func (k *KeyedPool[K, T]) AcquireWithInfo(ctx context.Context, key K) (T, AcquireInfo, error) {
+	if err := k.tokens.take(ctx); err != nil {
+		return *new(T), AcquireInfo{}, err
+	}
+
+	for {
+		pool, err := k.getPool(key)
+		if err != nil {
+			k.tokens.give()
+			return *new(T), AcquireInfo{}, err
+		}
+
+		resource, info, err := pool.AcquireWithInfo(ctx)
+		// the key pool was removed for being empty between getPool and Acquire
+		if errors.Is(err, ErrPoolClosed) && !k.getIsClosed() {
+			continue
+		}
+		if err != nil {
+			k.tokens.give()
+		}
+		return resource, info, err
+	}
+}
+
+// releases an active resource back to the pool of key
+func (k *KeyedPool[K, T]) Release(key K, resource T) {
+	k.TryRelease(key, resource)
+}
+
+// releases an active resource back to the pool of key; returns
+// ErrNotAcquired if the resource was not acquired from the pool of key
+func (k *KeyedPool[K, T]) TryRelease(key K, resource T) error {
+	k.mutex.Lock()
+	pool, isFound := k.pools[key]
+	k.mutex.Unlock()
+
+	if !isFound {
+		return ErrNotAcquired
+	}
+	if err := pool.TryRelease(resource); err != nil {
+		return err
+	}
+
+	k.tokens.give()
+	return nil
+}
+
+// destroys an acquired resource of key instead of returning it to the pool,
+// e.g. after it failed mid-use; returns ErrNotAcquired if the resource was
+// not acquired from the pool of key
+func (k *KeyedPool[K, T]) Invalidate(key K, resource T) error {
+	k.mutex.Lock()
+	pool, isFound := k.pools[key]
+	k.mutex.Unlock()
+
+	if !isFound {
+		return ErrNotAcquired
+	}
+	if err := pool.Invalidate(resource); err != nil {
+		return err
+	}
+
+	k.tokens.give()
+	return nil
+}
+
+// returns the number of keys with a pool
+func (k *KeyedPool[K, T]) NumKeys() int {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	return len(k.pools)
+}
+
+// returns the stats of all keys combined
+func (k *KeyedPool[K, T]) Stats() Stats {
+	k.mutex.Lock()
+	pools := make([]*NewPool[T], 0, len(k.pools))
+	for _, pool := range k.pools {
+		pools = append(pools, pool)
+	}
+	var stats Stats
+	stats.add(k.removed)
+	k.mutex.Unlock()
+
+	for _, pool := range pools {
+		stats.add(pool.Stats())
+	}
+	stats.Goroutines = k.scheduler.Stats()
+	// keys are not bounded, so per-key caps do not cap the keyed pool
+	stats.Cap = k.maxActive
+	return stats
+}
+
+// returns the number of idle items of key
+func (k *KeyedPool[K, T]) NumIdle(key K) int {
+	k.mutex.Lock()
+	pool, isFound := k.pools[key]
+	k.mutex.Unlock()
+
+	if !isFound {
+		return 0
+	}
+	return pool.NumIdle()
+}
+
+// closes the pool of every key and rejects further acquires
+func (k *KeyedPool[K, T]) Close() {
+	k.mutex.Lock()
+	k.isClosed = true
+	pools := k.pools
+	k.pools = make(map[K]*NewPool[T])
+	k.mutex.Unlock()
+
+	for _, pool := range pools {
+		pool.Close()
+	}
+}
+
+// returns the pool of key, creating it if needed
+func (k *KeyedPool[K, T]) getPool(key K) (*NewPool[T], error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.isClosed {
+		return nil, ErrPoolClosed
+	}
+
+	if pool, isFound := k.pools[key]; isFound {
+		return pool, nil
+	}
+	k.removeEmptyPools()
+
+	creator := func(ctx context.Context) (T, error) {
+		return k.creator(ctx, key)
+	}
+	options := append([]Option[T]{WithScheduler[T](k.scheduler)}, k.options...)
+	if k.maxActiveKey > 0 {
+		options = append(options, WithMaxActive[T](k.maxActiveKey))
+	}
+	if size := k.getWarmupSize(key); size > 0 {
+		options = append(options, WithWarmup[T](size))
+	}
+
+	pool := New(creator, k.maxIdleSize, k.maxIdleTime, options...)
+	k.pools[key] = pool
+	return pool, nil
+}
+
+// closes and removes the pools of keys without idle or acquired resources;
+// runs when a new key is added, so the number of keys stays bounded by the
+// keys in use
+func (k *KeyedPool[K, T]) removeEmptyPools() {
+	for key, pool := range k.pools {
+		if pool.isEmpty() {
+			delete(k.pools, key)
+			pool.Close()
+			// the quarantine of a removed pool ends with it
+			stats := pool.Stats()
+			stats.Quarantined = nil
+			k.removed.add(stats)
+		}
+	}
+}
+
+func (k *KeyedPool[K, T]) getIsClosed() bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	return k.isClosed
+}
+
+// returns the number of resources to warm for key
+func (k *KeyedPool[K, T]) getWarmupSize(key K) int {
+	if size, isFound := k.warmupSizes[key]; isFound {
+		return size
+	}
+	if k.warmupSize != nil {
+		return k.warmupSize(key)
+	}
+	return 0
+}
+
+func NewKeyed[K comparable, T comparable](
+	// creator is a function called by the pool to create a resource for a key.
+	creator func(context.Context, K) (T, error),
+	// maxIdleSize is the number of maximum idle items kept per key; zero is
+	// derived from GOMAXPROCS, a negative size keeps no idle items
+	maxIdleSize int,
+	// maxIdleTime is the maximum idle time for an idle item to be swept from the pool
+	maxIdleTime time.Duration,
+	// options configure optional behavior
+	options ...KeyedOption[K, T],
+) *KeyedPool[K, T] {
+	keyed := &KeyedPool[K, T]{
+		creator:     creator,
+		maxIdleSize: maxIdleSize,
+		maxIdleTime: maxIdleTime,
+		scheduler:   NewScheduler(defaultGoroutineLimit),
+		pools:       make(map[K]*NewPool[T]),
+	}
+
+	for _, option := range options {
+		option(keyed)
+	}
+	keyed.tokens = newSemaphore(keyed.maxActive)
+
+	for key := range keyed.warmupSizes {
+		keyed.getPool(key)
+	}
+
+	return keyed
+}
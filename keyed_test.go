@@ -0,0 +1,201 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func getMockKeyedCreatorFunc() func(context.Context, string) (MockResource, error) {
+	mutex := sync.Mutex{}
+	id := 0
+	return func(ctx context.Context, key string) (MockResource, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		id += 1
+		return MockResource{id}, nil
+	}
+}
+
+func TestKeyedPool_Acquire(t *testing.T) {
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	first, err := pool.Acquire(nil, "a")
+	assert.NoError(t, err)
+	pool.Release("a", first)
+
+	other, err := pool.Acquire(nil, "b")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	reused, err := pool.Acquire(nil, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, first, reused)
+}
+
+func TestKeyedPool_Close(t *testing.T) {
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	resource, _ := pool.Acquire(nil, "a")
+	pool.Release("a", resource)
+	pool.Close()
+
+	_, err := pool.Acquire(nil, "a")
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, 0, pool.NumIdle("a"))
+}
+
+func TestKeyedPool_Warmup(t *testing.T) {
+	testCases := []struct {
+		name         string
+		options      []KeyedOption[string, MockResource]
+		expectedIdle map[string]int
+	}{
+		{
+			name: "sizes warm listed keys at construction",
+			options: []KeyedOption[string, MockResource]{
+				WithKeyWarmup[string, MockResource](map[string]int{"hot": 3, "cold": 1}),
+			},
+			expectedIdle: map[string]int{"hot": 3, "cold": 1, "other": 0},
+		},
+		{
+			name: "func warms a key on first use",
+			options: []KeyedOption[string, MockResource]{
+				WithKeyWarmupFunc[string, MockResource](func(key string) int {
+					if key == "hot" {
+						return 3
+					}
+					return 1
+				}),
+			},
+			expectedIdle: map[string]int{"hot": 3, "cold": 1},
+		},
+		{
+			name: "listed size overrides func",
+			options: []KeyedOption[string, MockResource]{
+				WithKeyWarmup[string, MockResource](map[string]int{"hot": 2}),
+				WithKeyWarmupFunc[string, MockResource](func(string) int { return 1 }),
+			},
+			expectedIdle: map[string]int{"hot": 2, "cold": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+
+			for key := range tc.expectedIdle {
+				if tc.expectedIdle[key] > 0 {
+					pool.getPool(key)
+				}
+			}
+
+			for key, expected := range tc.expectedIdle {
+				assert.Eventually(t, func() bool { return pool.NumIdle(key) == expected }, time.Second, time.Millisecond, key)
+			}
+		})
+	}
+}
+
+func TestKeyedPool_MaxActive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []KeyedOption[string, MockResource]
+		secondKey     string
+		expectedError error
+	}{
+		{
+			name:          "with key max active fails fast for the same key",
+			options:       []KeyedOption[string, MockResource]{WithKeyMaxActive[string, MockResource](1)},
+			secondKey:     "a",
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name:      "with key max active allows other keys",
+			options:   []KeyedOption[string, MockResource]{WithKeyMaxActive[string, MockResource](1)},
+			secondKey: "b",
+		},
+		{
+			name:          "with total max active fails fast for other keys",
+			options:       []KeyedOption[string, MockResource]{WithTotalMaxActive[string, MockResource](1)},
+			secondKey:     "b",
+			expectedError: ErrPoolExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			_, err := pool.Acquire(nil, "a")
+			assert.NoError(t, err)
+
+			_, err = pool.Acquire(nil, tc.secondKey)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestKeyedPool_TotalMaxActiveWaitsForRelease(t *testing.T) {
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime, WithTotalMaxActive[string, MockResource](1))
+	first, _ := pool.Acquire(nil, "a")
+
+	acquired := make(chan error)
+	go func() {
+		_, err := pool.Acquire(context.Background(), "b")
+		acquired <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, pool.TryRelease("a", first))
+
+	assert.NoError(t, <-acquired)
+	assert.ErrorIs(t, pool.TryRelease("a", first), ErrNotAcquired)
+}
+
+func TestKeyedPool_RemovesEmptyKeys(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithKeyOptions[string, MockResource](WithClock[MockResource](clock)),
+	)
+
+	idle, _ := pool.Acquire(nil, "idle")
+	pool.Release("idle", idle)
+	pool.Acquire(nil, "active")
+	assert.Equal(t, 2, pool.NumKeys())
+
+	clock.Advance(maxIdleTime + time.Nanosecond)
+	pool.Acquire(nil, "new")
+
+	assert.Equal(t, 2, pool.NumKeys())
+	assert.Equal(t, 2, pool.Stats().Active)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictExpired])
+}
+
+func TestKeyedPool_AcquireWithInfo(t *testing.T) {
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, info, err := pool.AcquireWithInfo(nil, "a")
+	assert.NoError(t, err)
+	assert.False(t, info.IsReused)
+	pool.Release("a", resource)
+
+	_, info, err = pool.AcquireWithInfo(nil, "a")
+
+	assert.NoError(t, err)
+	assert.True(t, info.IsReused)
+}
+
+func TestKeyedPool_Invalidate(t *testing.T) {
+	pool := NewKeyed(getMockKeyedCreatorFunc(), maxIdleSize, maxIdleTime, WithTotalMaxActive[string, MockResource](1))
+	resource, _ := pool.Acquire(nil, "a")
+
+	assert.ErrorIs(t, pool.Invalidate("b", resource), ErrNotAcquired)
+	assert.NoError(t, pool.Invalidate("a", resource))
+
+	assert.Equal(t, 0, pool.NumIdle("a"))
+	_, err := pool.Acquire(nil, "b")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,146 @@
+package pool
+
+import (
+	"sort"
+	"time"
+)
+
+// AcquireOutcome is how an acquire got its resource, to split acquire
+// latencies by
+type AcquireOutcome string
+
+const (
+	// AcquireReused is the outcome of acquires served from the idle pool
+	// without waiting
+	AcquireReused AcquireOutcome = "reused"
+	// AcquireCreated is the outcome of acquires which created their resource
+	// without waiting
+	AcquireCreated AcquireOutcome = "created"
+	// AcquireWaited is the outcome of acquires which waited for a release or
+	// for capacity, whether they then reused or created a resource
+	AcquireWaited AcquireOutcome = "waited"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the acquire latency buckets
+// used by WithAcquireLatency without bounds of its own
+var DefaultLatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// LatencyHistogram counts latencies in buckets
+type LatencyHistogram struct {
+	// Bounds are the inclusive upper bounds of the buckets, in ascending order
+	Bounds []time.Duration
+	// Counts are the number of latencies in each bucket; the last count, past
+	// the bounds, is of the latencies above the last bound
+	Counts []int64
+	// Count is the number of latencies
+	Count int64
+	// Sum is the total of the latencies
+	Sum time.Duration
+}
+
+// WithAcquireLatency records the latency of successful acquires, from the
+// call to the hand-out, in histograms with the given bucket bounds, or
+// DefaultLatencyBuckets without any. The histograms are split by outcome in
+// Stats.AcquireLatency, so that the tail of acquires which waited or created
+// is not hidden by fast reuses. Acquires of AcquireN and hits of the local
+// cache are not recorded.
+func WithAcquireLatency[T comparable](bounds ...time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		if len(bounds) == 0 {
+			bounds = DefaultLatencyBuckets
+		}
+		n.latencyBounds = append([]time.Duration(nil), bounds...)
+		sort.Slice(n.latencyBounds, func(i, j int) bool {
+			return n.latencyBounds[i] < n.latencyBounds[j]
+		})
+	}
+}
+
+// returns the outcome of the acquire the info describes
+func (i AcquireInfo) Outcome() AcquireOutcome {
+	switch {
+	case i.Waited > 0:
+		return AcquireWaited
+	case i.IsReused:
+		return AcquireReused
+	default:
+		return AcquireCreated
+	}
+}
+
+// returns the histogram of both h and other, e.g. to report several pools as
+// one; other is ignored if its bounds differ from those of a non-empty h
+func (h LatencyHistogram) Merge(other LatencyHistogram) LatencyHistogram {
+	if h.Count == 0 && len(h.Bounds) == 0 {
+		return other.copy()
+	}
+	if !isSameBounds(h.Bounds, other.Bounds) {
+		return h
+	}
+
+	merged := h.copy()
+	for i, count := range other.Counts {
+		merged.Counts[i] += count
+	}
+	merged.Count += other.Count
+	merged.Sum += other.Sum
+	return merged
+}
+
+// counts a latency in its bucket
+func (h *LatencyHistogram) observe(d time.Duration) {
+	i := sort.Search(len(h.Bounds), func(i int) bool { return d <= h.Bounds[i] })
+	h.Counts[i]++
+	h.Count++
+	h.Sum += d
+}
+
+// returns a copy of the histogram not sharing its counts
+func (h LatencyHistogram) copy() LatencyHistogram {
+	h.Counts = append([]int64(nil), h.Counts...)
+	return h
+}
+
+func isSameBounds(a []time.Duration, b []time.Duration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// records the latency of a successful acquire since start, if
+// WithAcquireLatency is set; the pool mutex must be held
+func (n *NewPool[T]) recordAcquireLatency(outcome AcquireOutcome, start time.Time) {
+	if n.latencyBounds == nil {
+		return
+	}
+
+	if n.stats.acquireLatency == nil {
+		n.stats.acquireLatency = make(map[AcquireOutcome]*LatencyHistogram)
+	}
+	histogram, isFound := n.stats.acquireLatency[outcome]
+	if !isFound {
+		histogram = &LatencyHistogram{
+			Bounds: n.latencyBounds,
+			Counts: make([]int64, len(n.latencyBounds)+1),
+		}
+		n.stats.acquireLatency[outcome] = histogram
+	}
+	histogram.observe(n.now().Sub(start))
+}
@@ -0,0 +1,132 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireLatency(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	creator := func(ctx context.Context) (MockResource, error) {
+		clock.Advance(2 * time.Millisecond)
+		return MockResource{}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMaxActive[MockResource](1),
+		WithAcquireLatency[MockResource](time.Millisecond, 10*time.Millisecond),
+	)
+
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+	resource, _ = pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	clock.Advance(20 * time.Millisecond)
+	pool.Release(resource)
+	<-acquired
+
+	bounds := []time.Duration{time.Millisecond, 10 * time.Millisecond}
+	assert.Equal(t, map[AcquireOutcome]LatencyHistogram{
+		AcquireCreated: {Bounds: bounds, Counts: []int64{0, 1, 0}, Count: 1, Sum: 2 * time.Millisecond},
+		AcquireReused:  {Bounds: bounds, Counts: []int64{1, 0, 0}, Count: 1},
+		AcquireWaited:  {Bounds: bounds, Counts: []int64{0, 0, 1}, Count: 1, Sum: 20 * time.Millisecond},
+	}, pool.Stats().AcquireLatency)
+}
+
+func TestNewPool_AcquireLatencyDisabled(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	pool.Acquire(nil)
+
+	assert.Nil(t, pool.Stats().AcquireLatency)
+}
+
+func TestWithAcquireLatency(t *testing.T) {
+	testCases := []struct {
+		name           string
+		bounds         []time.Duration
+		expectedBounds []time.Duration
+	}{
+		{
+			name:           "defaults",
+			expectedBounds: DefaultLatencyBuckets,
+		},
+		{
+			name:           "sorts bounds",
+			bounds:         []time.Duration{time.Second, time.Millisecond},
+			expectedBounds: []time.Duration{time.Millisecond, time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithAcquireLatency[MockResource](tc.bounds...))
+
+			pool.Acquire(nil)
+
+			assert.Equal(t, tc.expectedBounds, pool.Stats().AcquireLatency[AcquireCreated].Bounds)
+		})
+	}
+}
+
+func TestLatencyHistogram_Merge(t *testing.T) {
+	bounds := []time.Duration{time.Millisecond}
+	histogram := LatencyHistogram{Bounds: bounds, Counts: []int64{1, 2}, Count: 3, Sum: time.Second}
+	testCases := []struct {
+		name      string
+		histogram LatencyHistogram
+		other     LatencyHistogram
+		expected  LatencyHistogram
+	}{
+		{
+			name:     "into empty",
+			other:    histogram,
+			expected: histogram,
+		},
+		{
+			name:      "same bounds",
+			histogram: histogram,
+			other:     LatencyHistogram{Bounds: bounds, Counts: []int64{1, 0}, Count: 1, Sum: time.Microsecond},
+			expected:  LatencyHistogram{Bounds: bounds, Counts: []int64{2, 2}, Count: 4, Sum: time.Second + time.Microsecond},
+		},
+		{
+			name:      "other bounds",
+			histogram: histogram,
+			other:     LatencyHistogram{Bounds: []time.Duration{time.Second}, Counts: []int64{1, 0}, Count: 1},
+			expected:  histogram,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.histogram.Merge(tc.other))
+			assert.Equal(t, []int64{1, 2}, histogram.Counts)
+		})
+	}
+}
+
+func TestAcquireInfo_Outcome(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     AcquireInfo
+		expected AcquireOutcome
+	}{
+		{name: "created", expected: AcquireCreated},
+		{name: "reused", info: AcquireInfo{IsReused: true}, expected: AcquireReused},
+		{name: "waited", info: AcquireInfo{IsReused: true, Waited: time.Millisecond}, expected: AcquireWaited},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.info.Outcome())
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithLeaseTTL sets the hold time granted to leases by AcquireLease; a lease
+// without a TTL has no deadline until it is extended
+func WithLeaseTTL[T comparable](ttl time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.leaseTTL = ttl
+	}
+}
+
+// WithMaxHoldTime caps the total time a lease can be held, counted from the
+// acquire, however often it is extended. Extend fails with ErrMaxHoldTime
+// beyond the cap, so long operations can checkpoint and re-acquire.
+func WithMaxHoldTime[T comparable](maxHoldTime time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.maxHoldTime = maxHoldTime
+	}
+}
+
+// WithLeaseReclaim reclaims the slots of leases which expired without being
+// released, so a holder which never releases can not exhaust the pool. Past
+// its deadline, the resource of a lease stops counting as acquired, a warning
+// is logged with its holder tag and hold time, and EventLeaseReclaimed is
+// emitted. With isDestroyed, the resource is destroyed right away with
+// EvictReclaimed and its later release is ignored; otherwise it is left to
+// the holder and destroyed with EvictReclaimed once released, never reused.
+// In synchronous mode, expired leases are reclaimed by the first Acquire or
+// Release after their deadline.
+func WithLeaseReclaim[T comparable](isDestroyed bool) Option[T] {
+	return func(n *NewPool[T]) {
+		n.isLeaseReclaimed = true
+		n.isReclaimDestroying = isDestroyed
+	}
+}
+
+// Lease is an acquired resource with a hold deadline. The lease context is
+// done once the deadline passes or the lease is released, which tells the
+// holder to stop using the resource.
+type Lease[T comparable] struct {
+	pool       *NewPool[T]
+	resource   T
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	mutex      sync.Mutex
+	acquiredAt time.Time
+	deadline   time.Time
+	timer      Timer
+	renewals   int
+	isReleased bool
+}
+
+// acquires a resource under a lease with the pool's lease TTL
+func (n *NewPool[T]) AcquireLease(ctx context.Context) (*Lease[T], error) {
+	resource, err := n.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lease := &Lease[T]{
+		pool:       n,
+		resource:   resource,
+		acquiredAt: n.now(),
+	}
+	lease.ctx, lease.cancel = context.WithCancelCause(context.Background())
+	if n.leaseTTL > 0 {
+		lease.setDeadline(n.leaseTTL)
+	}
+	n.trackLease(lease)
+	return lease, nil
+}
+
+// records a lease so a forced close can end it
+func (n *NewPool[T]) trackLease(lease *Lease[T]) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if _, isOrphaned := n.orphaned[lease.resource]; isOrphaned {
+		lease.expire(ErrPoolClosed)
+		return
+	}
+	if n.leases == nil {
+		n.leases = make(map[T]*Lease[T])
+	}
+	n.leases[lease.resource] = lease
+}
+
+// forgets a lease which is being released
+func (n *NewPool[T]) untrackLease(lease *Lease[T]) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.leases[lease.resource] == lease {
+		delete(n.leases, lease.resource)
+	}
+}
+
+// ends the lease of a resource handed back to the pool without the lease,
+// e.g. by Release instead of Lease.Release, so its deadline can not reclaim
+// the resource from its next holder; the pool mutex must be held
+func (n *NewPool[T]) endLease(resource T) {
+	lease, isLeased := n.leases[resource]
+	if !isLeased {
+		return
+	}
+
+	delete(n.leases, resource)
+	lease.expire(nil)
+}
+
+// returns the leased resource
+func (l *Lease[T]) Resource() T {
+	return l.resource
+}
+
+// returns a context which is done once the lease expired or was released.
+// Its context.Cause is ErrLeaseExpired past the deadline, ErrPoolClosed when
+// a forced close destroyed the resource, and context.Canceled on Release.
+func (l *Lease[T]) Context() context.Context {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.expireIfDue()
+	return l.ctx
+}
+
+// returns the hold deadline of the lease; zero if it has none
+func (l *Lease[T]) Deadline() time.Time {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.deadline
+}
+
+// returns the number of times the lease was extended
+func (l *Lease[T]) Renewals() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.renewals
+}
+
+// returns the deadline and renewal count of the lease
+func (l *Lease[T]) getRenewal() (time.Time, int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.deadline, l.renewals
+}
+
+// moves the lease deadline to d from now, e.g. to renew it periodically
+// during a legitimately long operation; renewals are counted in Holders.
+// Returns ErrLeaseExpired if the
+// lease already expired or was released, and ErrMaxHoldTime, leaving the
+// deadline unchanged, if the new deadline is past the pool's hold time cap.
+func (l *Lease[T]) Extend(d time.Duration) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.expireIfDue()
+	if l.isReleased || l.ctx.Err() != nil {
+		return ErrLeaseExpired
+	}
+
+	maxHoldTime := l.pool.maxHoldTime
+	if maxHoldTime > 0 && l.pool.now().Add(d).After(l.acquiredAt.Add(maxHoldTime)) {
+		return ErrMaxHoldTime
+	}
+
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.setDeadline(d)
+	l.renewals++
+	return nil
+}
+
+// ends the lease and releases the resource back to the pool; extra calls
+// have no effect
+func (l *Lease[T]) Release() {
+	if !l.expire(nil) {
+		return
+	}
+
+	l.pool.untrackLease(l)
+	l.pool.Release(l.resource)
+}
+
+// ends the lease and destroys the resource instead of releasing it, e.g.
+// after it failed mid-use; extra calls, and calls after Release, have no
+// effect
+func (l *Lease[T]) Invalidate() {
+	if !l.expire(nil) {
+		return
+	}
+
+	l.pool.untrackLease(l)
+	l.pool.Invalidate(l.resource)
+}
+
+// ends the lease without releasing the resource, canceling its context with
+// cause; reports whether the lease was still held
+func (l *Lease[T]) expire(cause error) bool {
+	l.mutex.Lock()
+	if l.isReleased {
+		l.mutex.Unlock()
+		return false
+	}
+	l.isReleased = true
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.mutex.Unlock()
+
+	l.cancel(cause)
+	return true
+}
+
+// sets the deadline d from now and arms the expiry timer
+func (l *Lease[T]) setDeadline(d time.Duration) {
+	l.deadline = l.pool.now().Add(d)
+	if l.pool.isSynchronous {
+		l.timer = nil
+		return
+	}
+	l.timer = l.pool.getClock().AfterFunc(d, func() {
+		l.cancel(ErrLeaseExpired)
+		if l.pool.isLeaseReclaimed {
+			l.pool.scheduler.Go(func() { l.pool.reclaimLease(l) })
+		}
+	})
+}
+
+// in synchronous mode, cancels the lease context once the deadline passed;
+// the lease mutex must be held
+func (l *Lease[T]) expireIfDue() {
+	if l.pool.isSynchronous && !l.deadline.IsZero() && !l.pool.now().Before(l.deadline) {
+		l.cancel(ErrLeaseExpired)
+	}
+}
+
+// reports whether the lease is held past its deadline at now; the lease
+// mutex must not be held
+func (l *Lease[T]) isExpiredAt(now time.Time) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return !l.isReleased && !l.deadline.IsZero() && !now.Before(l.deadline)
+}
+
+// reclaims the slot of a lease which expired without being released
+func (n *NewPool[T]) reclaimLease(lease *Lease[T]) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.leases[lease.resource] == lease {
+		n.reclaim(lease)
+	}
+}
+
+// reclaims the leases past their deadline, in synchronous mode; the pool
+// mutex must be held
+func (n *NewPool[T]) reclaimExpiredLeases() {
+	now := n.now()
+	for _, lease := range n.leases {
+		if lease.isExpiredAt(now) {
+			lease.cancel(ErrLeaseExpired)
+			n.reclaim(lease)
+		}
+	}
+}
+
+// stops counting the resource of an expired lease as acquired, destroying it
+// with WithLeaseReclaim(true); the pool mutex must be held
+func (n *NewPool[T]) reclaim(lease *Lease[T]) {
+	delete(n.leases, lease.resource)
+	entry, isFound := n.lock[lease.resource]
+	if !isFound {
+		return
+	}
+
+	n.stats.reclaimed++
+	n.log(LogWarn, "lease expired without release; reclaiming resource",
+		Field{Key: "holder_tag", Value: entry.tag},
+		Field{Key: "held", Value: n.now().Sub(lease.acquiredAt)},
+	)
+	n.publish(EventLeaseReclaimed, entry, ErrLeaseExpired)
+	n.deactivate(lease.resource, entry)
+	n.notifyWaiters()
+
+	if n.isReclaimDestroying {
+		if n.orphaned == nil {
+			n.orphaned = make(map[T]struct{})
+		}
+		n.orphaned[lease.resource] = struct{}{}
+		n.evict(lease.resource, entry, EvictReclaimed)
+		return
+	}
+	if n.reclaimed == nil {
+		n.reclaimed = make(map[T]*resourceEntry)
+	}
+	n.reclaimed[lease.resource] = entry
+}
+
+// destroys a reclaimed resource handed back by its holder; reports false if
+// the resource was not reclaimed. The pool mutex must be held.
+func (n *NewPool[T]) destroyReclaimed(resource T) bool {
+	entry, isReclaimed := n.reclaimed[resource]
+	if !isReclaimed {
+		return false
+	}
+
+	delete(n.reclaimed, resource)
+	n.evict(resource, entry, EvictReclaimed)
+	return true
+}
@@ -0,0 +1,304 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLease_Extend(t *testing.T) {
+	testCases := []struct {
+		name             string
+		options          []Option[MockResource]
+		advance          time.Duration
+		extend           time.Duration
+		expectedError    error
+		expectedDeadline time.Duration
+	}{
+		{
+			name:             "without cap moves deadline",
+			options:          []Option[MockResource]{WithLeaseTTL[MockResource](time.Minute)},
+			advance:          30 * time.Second,
+			extend:           time.Hour,
+			expectedDeadline: 30*time.Second + time.Hour,
+		},
+		{
+			name: "within cap moves deadline",
+			options: []Option[MockResource]{
+				WithLeaseTTL[MockResource](time.Minute),
+				WithMaxHoldTime[MockResource](2 * time.Minute),
+			},
+			advance:          30 * time.Second,
+			extend:           90 * time.Second,
+			expectedDeadline: 2 * time.Minute,
+		},
+		{
+			name: "past cap fails and keeps deadline",
+			options: []Option[MockResource]{
+				WithLeaseTTL[MockResource](time.Minute),
+				WithMaxHoldTime[MockResource](2 * time.Minute),
+			},
+			advance:          30 * time.Second,
+			extend:           2 * time.Minute,
+			expectedError:    ErrMaxHoldTime,
+			expectedDeadline: time.Minute,
+		},
+		{
+			name:             "after expiry fails",
+			options:          []Option[MockResource]{WithLeaseTTL[MockResource](time.Minute)},
+			advance:          time.Minute,
+			extend:           time.Minute,
+			expectedError:    ErrLeaseExpired,
+			expectedDeadline: time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Unix(0, 0)
+			clock := &MockClock{now: start}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, append(tc.options, WithClock[MockResource](clock))...)
+
+			lease, err := pool.AcquireLease(nil)
+			assert.NoError(t, err)
+			clock.Advance(tc.advance)
+			if tc.expectedError == ErrLeaseExpired {
+				<-lease.Context().Done()
+			}
+
+			assert.ErrorIs(t, lease.Extend(tc.extend), tc.expectedError)
+			assert.Equal(t, start.Add(tc.expectedDeadline), lease.Deadline())
+		})
+	}
+}
+
+func TestLease_Context(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithLeaseTTL[MockResource](time.Minute),
+	)
+	lease, _ := pool.AcquireLease(nil)
+
+	assert.NoError(t, lease.Extend(2*time.Minute))
+	clock.Advance(time.Minute)
+	assert.NoError(t, lease.Context().Err())
+
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return lease.Context().Err() != nil }, time.Second, time.Millisecond)
+}
+
+func TestLease_Release(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	lease, _ := pool.AcquireLease(nil)
+	assert.True(t, lease.Deadline().IsZero())
+
+	lease.Release()
+	lease.Release()
+
+	assert.Error(t, lease.Context().Err())
+	assert.ErrorIs(t, lease.Extend(time.Minute), ErrLeaseExpired)
+	assert.Equal(t, 1, pool.NumIdle())
+}
+
+func TestLease_ContextCause(t *testing.T) {
+	testCases := []struct {
+		name          string
+		end           func(*NewPool[MockResource], *Lease[MockResource], *MockClock)
+		expectedCause error
+	}{
+		{
+			name: "past deadline is lease expired",
+			end: func(pool *NewPool[MockResource], lease *Lease[MockResource], clock *MockClock) {
+				clock.Advance(time.Minute)
+			},
+			expectedCause: ErrLeaseExpired,
+		},
+		{
+			name: "on forced close is pool closed",
+			end: func(pool *NewPool[MockResource], lease *Lease[MockResource], clock *MockClock) {
+				pool.CloseNow()
+			},
+			expectedCause: ErrPoolClosed,
+		},
+		{
+			name: "on release is canceled",
+			end: func(pool *NewPool[MockResource], lease *Lease[MockResource], clock *MockClock) {
+				lease.Release()
+			},
+			expectedCause: context.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithLeaseTTL[MockResource](time.Minute),
+			)
+			lease, _ := pool.AcquireLease(nil)
+
+			tc.end(pool, lease, clock)
+
+			<-lease.Context().Done()
+			assert.ErrorIs(t, context.Cause(lease.Context()), tc.expectedCause)
+		})
+	}
+}
+
+func TestLease_Invalidate(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	lease, _ := pool.AcquireLease(nil)
+
+	lease.Invalidate()
+	lease.Release()
+
+	assert.Error(t, lease.Context().Err())
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictInvalidated])
+}
+
+func TestLease_Reclaim(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		isDestroyed             bool
+		expectedEvictionsBefore int64
+	}{
+		{
+			name:                    "with destroy destroys resource on reclaim",
+			isDestroyed:             true,
+			expectedEvictionsBefore: 1,
+		},
+		{
+			name: "without destroy destroys resource on release",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithMaxActive[MockResource](1),
+				WithLeaseTTL[MockResource](time.Minute),
+				WithLeaseReclaim[MockResource](tc.isDestroyed),
+			)
+			events := pool.Subscribe()
+			lease, _ := pool.AcquireLease(nil)
+
+			clock.Advance(time.Minute)
+
+			assert.Eventually(t, func() bool { return pool.Stats().Reclaimed == 1 }, time.Second, time.Millisecond)
+			assert.Equal(t, EventCreated, (<-events).Type)
+			assert.Equal(t, EventLeaseReclaimed, (<-events).Type)
+			stats := pool.Stats()
+			assert.Equal(t, 0, stats.Active)
+			assert.Equal(t, tc.expectedEvictionsBefore, stats.Evictions[EvictReclaimed])
+			resource, err := pool.Acquire(nil)
+			assert.NoError(t, err)
+			assert.NotEqual(t, lease.Resource(), resource)
+
+			lease.Release()
+
+			stats = pool.Stats()
+			assert.Equal(t, int64(1), stats.Evictions[EvictReclaimed])
+			assert.Equal(t, 0, pool.NumIdle())
+			assert.Equal(t, 1, stats.Active)
+		})
+	}
+}
+
+func TestLease_ReclaimSkipsReleasedLease(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithLeaseTTL[MockResource](time.Minute),
+		WithLeaseReclaim[MockResource](true),
+	)
+	lease, _ := pool.AcquireLease(nil)
+	lease.Release()
+
+	clock.Advance(time.Minute)
+
+	assert.Equal(t, int64(0), pool.Stats().Reclaimed)
+	assert.Equal(t, 1, pool.NumIdle())
+}
+
+func TestLease_ReclaimSkipsResourceReleasedWithoutLease(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options []Option[MockResource]
+	}{
+		{
+			name: "with timers",
+		},
+		{
+			name:    "with synchronous",
+			options: []Option[MockResource]{WithSynchronous[MockResource]()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				append(tc.options,
+					WithClock[MockResource](clock),
+					WithLeaseTTL[MockResource](time.Minute),
+					WithLeaseReclaim[MockResource](true),
+				)...,
+			)
+			lease, _ := pool.AcquireLease(nil)
+			pool.Release(lease.Resource())
+			resource, _ := pool.Acquire(nil)
+			assert.Equal(t, lease.Resource(), resource)
+
+			clock.Advance(time.Minute)
+			pool.Acquire(nil)
+
+			assert.Never(t, func() bool { return pool.Stats().Reclaimed > 0 }, 20*time.Millisecond, time.Millisecond)
+			assert.Equal(t, 2, pool.Stats().Active)
+			assert.ErrorIs(t, lease.Context().Err(), context.Canceled)
+			pool.Release(resource)
+		})
+	}
+}
+
+func TestLease_ReclaimWithSynchronous(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithSynchronous[MockResource](),
+		WithMaxActive[MockResource](1),
+		WithLeaseTTL[MockResource](time.Minute),
+		WithLeaseReclaim[MockResource](true),
+	)
+	lease, _ := pool.AcquireLease(nil)
+	clock.Advance(time.Minute)
+
+	_, err := pool.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+	resource, err := pool.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 2}, resource)
+	assert.ErrorIs(t, context.Cause(lease.Context()), ErrLeaseExpired)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictReclaimed])
+}
+
+func TestLease_Renewals(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithLeaseTTL[MockResource](time.Minute),
+		WithMaxHoldTime[MockResource](2*time.Minute),
+	)
+	lease, _ := pool.AcquireLease(nil)
+
+	assert.NoError(t, lease.Extend(time.Minute))
+	assert.ErrorIs(t, lease.Extend(3*time.Minute), ErrMaxHoldTime)
+
+	assert.Equal(t, 1, lease.Renewals())
+}
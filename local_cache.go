@@ -0,0 +1,132 @@
+package pool
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// WithLocalCache puts a per-processor cache in front of the idle pool, so that
+// most Acquire and Release pairs never take the pool mutex. Acquire falls
+// back to the idle pool and the creator on a miss.
+//
+// It is meant for cheap resources such as buffers or encoders: resources are
+// not tracked while acquired, so WithMaxActive does not apply and releasing a
+// foreign resource is not detected; hooks and events only see the shared
+// path; and resources in the cache are not bounded by maxIdleSize nor swept
+// by maxIdleTime, they are kept until Close destroys them.
+func WithLocalCache[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isLocalCached = true
+	}
+}
+
+// localCache is the per-processor cache of a pool; the shards hold every
+// cached resource, so that Close can destroy all of them
+type localCache[T any] struct {
+	shards   []localShard[T]
+	affinity sync.Pool
+	next     atomic.Uint32
+	isClosed atomic.Bool
+	hits     atomic.Int64
+}
+
+// localShard is the part of a local cache mostly used by one processor
+type localShard[T any] struct {
+	mutex     sync.Mutex
+	resources []T
+}
+
+// returns a local cache with a shard per processor
+func newLocalCache[T any]() *localCache[T] {
+	c := &localCache[T]{shards: make([]localShard[T], runtime.GOMAXPROCS(0))}
+	// sync.Pool hands each processor the same shard while it is not dropped by
+	// the garbage collector; a dropped shard keeps its resources
+	c.affinity.New = func() any {
+		return &c.shards[int(c.next.Add(1))%len(c.shards)]
+	}
+	return c
+}
+
+// takes a resource from the cache of the current processor or another one
+func (c *localCache[T]) get() (T, bool) {
+	if c == nil || c.isClosed.Load() {
+		return *new(T), false
+	}
+
+	shard := c.affinity.Get().(*localShard[T])
+	defer c.affinity.Put(shard)
+
+	resource, isFound := shard.pop()
+	for i := 0; !isFound && i < len(c.shards); i++ {
+		if other := &c.shards[i]; other != shard {
+			resource, isFound = other.pop()
+		}
+	}
+	if isFound {
+		c.hits.Add(1)
+	}
+	return resource, isFound
+}
+
+// caches a released resource; returns false once the pool is closed
+func (c *localCache[T]) put(resource T) bool {
+	if c == nil || c.isClosed.Load() {
+		return false
+	}
+
+	shard := c.affinity.Get().(*localShard[T])
+	defer c.affinity.Put(shard)
+
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	// checked again under the shard mutex, so close either sees the resource
+	// or the put sees the cache closed
+	if c.isClosed.Load() {
+		return false
+	}
+	shard.resources = append(shard.resources, resource)
+	return true
+}
+
+// rejects further gets and puts; returns the resources left in the cache
+func (c *localCache[T]) close() []T {
+	if c == nil {
+		return nil
+	}
+
+	c.isClosed.Store(true)
+	var resources []T
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mutex.Lock()
+		resources = append(resources, shard.resources...)
+		shard.resources = nil
+		shard.mutex.Unlock()
+	}
+	return resources
+}
+
+// returns the number of acquires served by the cache
+func (c *localCache[T]) getHits() int64 {
+	if c == nil {
+		return 0
+	}
+
+	return c.hits.Load()
+}
+
+// takes the last resource put into the shard
+func (s *localShard[T]) pop() (T, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.resources) == 0 {
+		return *new(T), false
+	}
+	resource := s.resources[len(s.resources)-1]
+	s.resources[len(s.resources)-1] = *new(T)
+	s.resources = s.resources[:len(s.resources)-1]
+	return resource, true
+}
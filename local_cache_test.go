@@ -0,0 +1,107 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type MockBuffer struct {
+	id int
+}
+
+func getMockBufferCreatorFunc() func(ctx context.Context) (*MockBuffer, error) {
+	var id atomic.Int64
+	return func(ctx context.Context) (*MockBuffer, error) {
+		return &MockBuffer{id: int(id.Add(1))}, nil
+	}
+}
+
+func TestNewPool_AcquireWithLocalCache(t *testing.T) {
+	pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime, WithLocalCache[*MockBuffer]())
+
+	first, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, pool.TryRelease(first))
+
+	second, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, int64(1), pool.Stats().LocalHits)
+
+	stats := pool.Stats()
+	assert.Equal(t, 0, stats.Active)
+	assert.Equal(t, 0, stats.Idle)
+}
+
+func TestNewPool_AcquireWithLocalCacheFallsBackToIdle(t *testing.T) {
+	pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithLocalCache[*MockBuffer](),
+		WithWarmup[*MockBuffer](1),
+	)
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 1 }, time.Second, time.Millisecond)
+
+	resource, err := pool.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &MockBuffer{id: 1}, resource)
+	assert.Equal(t, int64(1), pool.Stats().Reused)
+}
+
+func TestNewPool_CloseWithLocalCache(t *testing.T) {
+	destroyed := 0
+	pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithLocalCache[*MockBuffer](),
+		WithDestroyer(func(*MockBuffer) error { destroyed++; return nil }),
+	)
+	cached, _ := pool.Acquire(nil)
+	active, _ := pool.Acquire(nil)
+	pool.Release(cached)
+
+	pool.Close()
+	pool.Release(active)
+	_, err := pool.Acquire(nil)
+
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, 2, destroyed)
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictClosed])
+}
+
+func TestNewPool_CloseWithLocalCacheRacingRelease(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var destroyed atomic.Int64
+		pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime,
+			WithLocalCache[*MockBuffer](),
+			WithDestroyer(func(*MockBuffer) error { destroyed.Add(1); return nil }),
+		)
+		var held []*MockBuffer
+		for j := 0; j < 8; j++ {
+			resource, _ := pool.Acquire(nil)
+			held = append(held, resource)
+		}
+
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for _, resource := range held {
+			wg.Add(1)
+			go func(resource *MockBuffer) {
+				defer wg.Done()
+				<-start
+				pool.Release(resource)
+			}(resource)
+		}
+		close(start)
+		pool.Close()
+		wg.Wait()
+
+		assert.Equal(t, int64(len(held)), destroyed.Load())
+	}
+}
+
+func BenchmarkNewPool_AcquireReleaseWithLocalCache(b *testing.B) {
+	pool := New(getAtomicMockCreatorFunc(), 1024, time.Minute, WithLocalCache[MockResource]())
+	benchmarkAcquireRelease(b, pool)
+}
@@ -0,0 +1,54 @@
+package pool
+
+// LogLevel is the severity of a log message
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Field is a key/value pair attached to a log message
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Logger receives the diagnostic messages of the pool. It is called while the
+// pool mutex is held, so it must not call back into the pool.
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...Field)
+}
+
+// WithLogger routes the pool's diagnostic messages to logger; by default they
+// are discarded
+func WithLogger[T comparable](logger Logger) Option[T] {
+	return func(n *NewPool[T]) {
+		n.logger = logger
+	}
+}
+
+func (n *NewPool[T]) log(level LogLevel, msg string, fields ...Field) {
+	if n.logger == nil {
+		return
+	}
+
+	n.logger.Log(level, msg, fields...)
+}
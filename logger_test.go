@@ -0,0 +1,100 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type logEntry struct {
+	level LogLevel
+	msg   string
+}
+
+type MockLogger struct {
+	entries []logEntry
+}
+
+func (m *MockLogger) Log(level LogLevel, msg string, fields ...Field) {
+	m.entries = append(m.entries, logEntry{level: level, msg: msg})
+}
+
+func TestNewPool_ReleaseLogs(t *testing.T) {
+	testCases := []struct {
+		name             string
+		used             map[MockResource]*resourceEntry
+		idle             map[MockResource]*resourceEntry
+		expectedEntries  []logEntry
+		withoutLogger    bool
+		expectedIdleSize int
+	}{
+		{
+			name: "with non-acquired resource logs warning",
+			expectedEntries: []logEntry{
+				{level: LogWarn, msg: "resource not previously acquired; not returning to idle resource pool"},
+			},
+		},
+		{
+			name: "with expired resource logs expiry",
+			used: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {acquiredAt: time.Now().Add(-2 * maxIdleTime)},
+			},
+			expectedEntries: []logEntry{
+				{level: LogDebug, msg: "resource already expired; not returning to idle resource pool"},
+			},
+		},
+		{
+			name: "with full idle pool logs full idle pool",
+			used: map[MockResource]*resourceEntry{
+				MockResource{id: 2}: {acquiredAt: time.Now()},
+			},
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 5}: {releasedAt: time.Now()},
+				MockResource{id: 6}: {releasedAt: time.Now()},
+				MockResource{id: 7}: {releasedAt: time.Now()},
+			},
+			expectedEntries: []logEntry{
+				{level: LogDebug, msg: "idle resource pool full; not returning resource to idle resource pool"},
+			},
+			expectedIdleSize: 3,
+		},
+		{
+			name:          "without logger discards messages",
+			withoutLogger: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.used == nil {
+				tc.used = make(map[MockResource]*resourceEntry)
+			}
+			if tc.idle == nil {
+				tc.idle = make(map[MockResource]*resourceEntry)
+			}
+
+			mockMutex := &MockMutex{}
+			mockMutex.On("Lock")
+			mockMutex.On("Unlock")
+
+			mockLogger := &MockLogger{}
+			pool := NewPool[MockResource]{
+				creator:     getMockCreatorFunc(),
+				maxIdleTime: maxIdleTime,
+				maxIdleSize: maxIdleSize,
+				mutex:       mockMutex,
+				lock:        tc.used,
+				unlock:      tc.idle,
+			}
+			if !tc.withoutLogger {
+				pool.logger = mockLogger
+			}
+
+			pool.Release(MockResource{id: 2})
+
+			assert.Equal(t, tc.expectedEntries, mockLogger.entries)
+			assert.Equal(t, tc.expectedIdleSize, len(pool.unlock))
+			mockMutex.AssertExpectations(t)
+		})
+	}
+}
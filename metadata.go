@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"sync"
+)
+
+// Metadata holds arbitrary key/value pairs attached to a pooled resource, e.g.
+// its server version, region or prepared-statement cache state. It lives as
+// long as the resource stays in the pool and is safe for concurrent use.
+// Hooks get it in HookInfo, so OnCreate can fill it in at creation.
+type Metadata struct {
+	mutex  sync.Mutex
+	values map[string]any
+}
+
+// returns the value of key; false if it is not set
+func (m *Metadata) Get(key string) (any, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, isFound := m.values[key]
+	return value, isFound
+}
+
+// sets the value of key
+func (m *Metadata) Set(key string, value any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.values == nil {
+		m.values = make(map[string]any)
+	}
+	m.values[key] = value
+}
+
+// unsets key
+func (m *Metadata) Delete(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.values, key)
+}
+
+// returns a copy of the key/value pairs
+func (m *Metadata) All() map[string]any {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	values := make(map[string]any, len(m.values))
+	for key, value := range m.values {
+		values[key] = value
+	}
+	return values
+}
+
+// returns the metadata of an idle or acquired resource, e.g. to query it at
+// acquire or release time; false if the pool does not track the resource.
+// Resources of a pool with WithLocalCache are not tracked while acquired or
+// in the cache.
+func (n *NewPool[T]) Metadata(resource T) (*Metadata, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resource = n.getTracked(resource)
+	entry, isFound := n.lock[resource]
+	if !isFound {
+		entry, isFound = n.unlock[resource]
+	}
+	if !isFound {
+		return nil, false
+	}
+	return entry.getMetadata(), true
+}
+
+// returns the metadata of the resource, creating it on first use; the pool
+// mutex must be held
+func (e *resourceEntry) getMetadata() *Metadata {
+	if e.metadata == nil {
+		e.metadata = &Metadata{}
+	}
+	return e.metadata
+}
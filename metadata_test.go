@@ -0,0 +1,43 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_Metadata(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithHooks(Hooks[MockResource]{
+			OnCreate: func(resource MockResource, info HookInfo) {
+				info.Metadata.Set("region", "eu-west-1")
+			},
+			OnRelease: func(resource MockResource, info HookInfo) {
+				info.Metadata.Set("released", true)
+			},
+		}),
+	)
+	resource, _ := pool.Acquire(nil)
+
+	metadata, isFound := pool.Metadata(resource)
+	assert.True(t, isFound)
+	region, _ := metadata.Get("region")
+	assert.Equal(t, "eu-west-1", region)
+
+	pool.Release(resource)
+	metadata, isFound = pool.Metadata(resource)
+	assert.True(t, isFound)
+	assert.Equal(t, map[string]any{"region": "eu-west-1", "released": true}, metadata.All())
+
+	metadata.Delete("released")
+	_, isSet := metadata.Get("released")
+	assert.False(t, isSet)
+}
+
+func TestNewPool_MetadataOfUnknownResource(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	metadata, isFound := pool.Metadata(MockResource{id: 1})
+
+	assert.False(t, isFound)
+	assert.Nil(t, metadata)
+}
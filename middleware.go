@@ -0,0 +1,50 @@
+package pool
+
+import (
+	"context"
+)
+
+// Middleware wraps a pool with cross-cutting behavior, such as logging,
+// metrics, tracing or retries, and returns the wrapped pool
+type Middleware[T any] func(Pool[T]) Pool[T]
+
+// wraps pool with middlewares; the first middleware is the outermost one, so
+// it sees each call first
+func Chain[T any](pool Pool[T], middlewares ...Middleware[T]) Pool[T] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		pool = middlewares[i](pool)
+	}
+	return pool
+}
+
+// returns a middleware intercepting Acquire; wrap is given the Acquire of the
+// next pool and returns its replacement, e.g. one retrying on errors
+func WrapAcquire[T any](wrap func(next func(context.Context) (T, error)) func(context.Context) (T, error)) Middleware[T] {
+	return func(next Pool[T]) Pool[T] {
+		return &wrappedPool[T]{Pool: next, acquire: wrap(next.Acquire), release: next.Release}
+	}
+}
+
+// returns a middleware intercepting Release; wrap is given the Release of the
+// next pool and returns its replacement, e.g. one logging hold times
+func WrapRelease[T any](wrap func(next func(T)) func(T)) Middleware[T] {
+	return func(next Pool[T]) Pool[T] {
+		return &wrappedPool[T]{Pool: next, acquire: next.Acquire, release: wrap(next.Release)}
+	}
+}
+
+// wrappedPool replaces the Acquire and Release of a pool; NumIdle is passed
+// through
+type wrappedPool[T any] struct {
+	Pool[T]
+	acquire func(context.Context) (T, error)
+	release func(T)
+}
+
+func (p *wrappedPool[T]) Acquire(ctx context.Context) (T, error) {
+	return p.acquire(ctx)
+}
+
+func (p *wrappedPool[T]) Release(resource T) {
+	p.release(resource)
+}
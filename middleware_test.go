@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware[MockResource] {
+		return WrapAcquire(func(next func(context.Context) (MockResource, error)) func(context.Context) (MockResource, error) {
+			return func(ctx context.Context) (MockResource, error) {
+				calls = append(calls, name+" before")
+				resource, err := next(ctx)
+				calls = append(calls, name+" after")
+				return resource, err
+			}
+		})
+	}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	chained := Chain[MockResource](pool, record("outer"), record("inner"))
+	resource, err := chained.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 1}, resource)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+}
+
+func TestChain_WithoutMiddlewares(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	assert.Same(t, pool, Chain[MockResource](pool))
+}
+
+func TestWrapAcquire_Retry(t *testing.T) {
+	attempts := 0
+	retry := WrapAcquire(func(next func(context.Context) (MockResource, error)) func(context.Context) (MockResource, error) {
+		return func(ctx context.Context) (MockResource, error) {
+			resource, err := next(ctx)
+			for i := 0; err != nil && i < 2; i++ {
+				resource, err = next(ctx)
+			}
+			return resource, err
+		}
+	})
+	creator := func(ctx context.Context) (MockResource, error) {
+		attempts++
+		if attempts < 3 {
+			return MockResource{}, errors.New("connection refused")
+		}
+		return MockResource{id: attempts}, nil
+	}
+
+	resource, err := Chain[MockResource](New(creator, maxIdleSize, maxIdleTime), retry).Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 3}, resource)
+}
+
+func TestWrapRelease(t *testing.T) {
+	var released []MockResource
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	chained := Chain[MockResource](pool, WrapRelease(func(next func(MockResource)) func(MockResource) {
+		return func(resource MockResource) {
+			released = append(released, resource)
+			next(resource)
+		}
+	}))
+	resource, _ := chained.Acquire(nil)
+
+	chained.Release(resource)
+
+	assert.Equal(t, []MockResource{resource}, released)
+	assert.Equal(t, 1, chained.NumIdle())
+}
@@ -0,0 +1,144 @@
+package netpool
+
+import (
+	"context"
+	pool "example/ptran"
+	"net"
+	"time"
+)
+
+// Address is the network and address connections of a Dialer are dialed to
+type Address struct {
+	Network string
+	Address string
+}
+
+// Dialer pools connections per address, e.g. host:port, for use as the
+// DialContext of an http.Transport, so the pool caps, probes and reports the
+// connections of each host:
+//
+//	dialer := netpool.NewDialer((&net.Dialer{}).DialContext, 8, time.Minute, netpool.WithHostMaxActive(32))
+//	transport := &http.Transport{DialContext: dialer.DialContext}
+//
+// The transport keeps its own idle connections; the ones it closes are
+// destroyed, as the transport also closes connections which are not reusable,
+// e.g. after an unread body, a Connection: close or the close_notify ending
+// the TLS session of HTTPS connections. Without WithDialerProbe the dialer is
+// therefore a pass-through which caps and reports the connections of each
+// host but never reuses one; WithDialerProbe reuses them instead.
+type Dialer struct {
+	pool         *pool.KeyedPool[Address, net.Conn]
+	options      []pool.KeyedOption[Address, net.Conn]
+	probeTimeout time.Duration
+}
+
+// DialerOption configures optional dialer behavior
+type DialerOption func(*Dialer)
+
+// WithHostMaxActive caps the number of connections handed out per address.
+// At capacity, DialContext waits for a connection to be closed until ctx is
+// done.
+func WithHostMaxActive(maxActive int) DialerOption {
+	return func(d *Dialer) {
+		d.options = append(d.options, pool.WithKeyMaxActive[Address, net.Conn](maxActive))
+	}
+}
+
+// WithKeyedPoolOptions passes options to the underlying keyed pool, e.g.
+// pool.WithTotalMaxActive
+func WithKeyedPoolOptions(options ...pool.KeyedOption[Address, net.Conn]) DialerOption {
+	return func(d *Dialer) {
+		d.options = append(d.options, options...)
+	}
+}
+
+// WithDialerProbe releases the connections closed by the transport back to
+// the pool instead of destroying them, and checks idle connections are still
+// alive before handing them out, like WithProbe. A probe does not catch a
+// response left unread, or the end of a TLS session, before the peer closes
+// the connection, so only use it for plain HTTP on transports which close
+// connections only once idle, e.g. past their IdleConnTimeout.
+func WithDialerProbe(timeout time.Duration) DialerOption {
+	return func(d *Dialer) {
+		d.probeTimeout = timeout
+	}
+}
+
+// returns an idle connection to address on the named network, or dials a new
+// one. Closing the returned connection releases it back to the pool.
+func (d *Dialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	key := Address{Network: network, Address: address}
+	for {
+		conn, info, err := d.pool.AcquireWithInfo(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsReused || d.probeTimeout <= 0 || isAlive(conn, d.probeTimeout) {
+			releaser := &keyedReleaser{pool: d.pool, key: key, isReusable: d.probeTimeout > 0}
+			return &Conn{Conn: conn, releaser: releaser}, nil
+		}
+		d.pool.Invalidate(key, conn)
+	}
+}
+
+// returns the statistics of the connections of all addresses combined
+func (d *Dialer) Stats() pool.Stats {
+	return d.pool.Stats()
+}
+
+// returns the underlying keyed pool
+func (d *Dialer) Pool() *pool.KeyedPool[Address, net.Conn] {
+	return d.pool
+}
+
+// closes the idle connections and rejects further dials
+func (d *Dialer) Close() {
+	d.pool.Close()
+}
+
+// keyedReleaser takes back the connections of one address of a Dialer,
+// destroying them unless they are reusable
+type keyedReleaser struct {
+	pool       *pool.KeyedPool[Address, net.Conn]
+	key        Address
+	isReusable bool
+}
+
+func (r *keyedReleaser) TryRelease(conn net.Conn) error {
+	if !r.isReusable {
+		return r.pool.Invalidate(r.key, conn)
+	}
+	return r.pool.TryRelease(r.key, conn)
+}
+
+func (r *keyedReleaser) Invalidate(conn net.Conn) error {
+	return r.pool.Invalidate(r.key, conn)
+}
+
+// creates a dialer pooling connections dialed by dial per address. Without
+// WithDialerProbe every connection closed by the transport is destroyed, so
+// no connection is reused and maxIdleSize and maxIdleTime have no effect.
+func NewDialer(
+	// dial is called by the pool to create a connection, e.g. net.Dialer.DialContext
+	dial func(ctx context.Context, network string, address string) (net.Conn, error),
+	// maxIdleSize is the number of maximum idle connections kept per address
+	maxIdleSize int,
+	// maxIdleTime is the maximum time a connection can be idle before it is closed
+	maxIdleTime time.Duration,
+	options ...DialerOption,
+) *Dialer {
+	d := &Dialer{}
+	for _, option := range options {
+		option(d)
+	}
+
+	creator := func(ctx context.Context, key Address) (net.Conn, error) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return dial(ctx, key.Network, key.Address)
+	}
+	destroyer := pool.WithKeyOptions[Address](pool.WithDestroyer(func(conn net.Conn) error { return conn.Close() }))
+	d.pool = pool.NewKeyed(creator, maxIdleSize, maxIdleTime, append([]pool.KeyedOption[Address, net.Conn]{destroyer}, d.options...)...)
+	return d
+}
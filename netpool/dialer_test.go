@@ -0,0 +1,105 @@
+package netpool
+
+import (
+	"context"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDialer_DialContextWithTransport(t *testing.T) {
+	testCases := []struct {
+		name             string
+		options          []DialerOption
+		expectedAccepted int64
+		expectedReused   int64
+		expectedIdle     int
+	}{
+		{
+			name:             "without probe destroys connections closed by transport",
+			expectedAccepted: 2,
+		},
+		{
+			name:             "with probe reuses connections closed by transport",
+			options:          []DialerOption{WithDialerProbe(time.Millisecond)},
+			expectedAccepted: 1,
+			expectedReused:   1,
+			expectedIdle:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var accepted atomic.Int64
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, "PONG")
+			}))
+			server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					accepted.Add(1)
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			var dialer net.Dialer
+			connections := NewDialer(dialer.DialContext, maxIdleSize, maxIdleTime, tc.options...)
+			defer connections.Close()
+			client := &http.Client{Transport: &http.Transport{DialContext: connections.DialContext}}
+
+			for i := 0; i < 2; i++ {
+				response, err := client.Get(server.URL)
+				require.NoError(t, err)
+				io.ReadAll(response.Body)
+				response.Body.Close()
+				// drops the transport's own idle connection, handing it back to the pool
+				client.Transport.(*http.Transport).CloseIdleConnections()
+			}
+
+			stats := connections.Stats()
+			assert.Equal(t, tc.expectedAccepted, accepted.Load())
+			assert.Equal(t, tc.expectedReused, stats.Reused)
+			assert.Equal(t, tc.expectedIdle, stats.Idle)
+		})
+	}
+}
+
+func TestDialer_WithHostMaxActive(t *testing.T) {
+	dialer := &pipeDialer{}
+	dial := func(ctx context.Context, network string, address string) (net.Conn, error) {
+		return dialer.dial(ctx)
+	}
+	connections := NewDialer(dial, maxIdleSize, maxIdleTime, WithHostMaxActive(1))
+	_, err := connections.DialContext(nil, "tcp", "a:80")
+	require.NoError(t, err)
+
+	_, err = connections.DialContext(nil, "tcp", "a:80")
+	assert.ErrorIs(t, err, pool.ErrPoolExhausted)
+
+	_, err = connections.DialContext(nil, "tcp", "b:80")
+	assert.NoError(t, err)
+}
+
+func TestDialer_DialContextWithProbe(t *testing.T) {
+	dialer := &pipeDialer{}
+	dial := func(ctx context.Context, network string, address string) (net.Conn, error) {
+		return dialer.dial(ctx)
+	}
+	connections := NewDialer(dial, maxIdleSize, maxIdleTime, WithDialerProbe(time.Millisecond))
+	conn, _ := connections.DialContext(nil, "tcp", "a:80")
+	conn.Close()
+	dialer.peers[0].Close()
+
+	_, err := connections.DialContext(nil, "tcp", "a:80")
+
+	assert.NoError(t, err)
+	assert.Len(t, dialer.peers, 2)
+	assert.Equal(t, int64(1), connections.Stats().Evictions[pool.EvictInvalidated])
+}
@@ -0,0 +1,185 @@
+// Package netpool pools net.Conn connections, e.g. TCP connections to an
+// upstream server, on top of the resource pool.
+package netpool
+
+import (
+	"context"
+	"errors"
+	pool "example/ptran"
+	"net"
+	"sync"
+	"time"
+)
+
+// Pool is a pool of connections created by a dial function. Connections are
+// closed when the pool drops them.
+type Pool struct {
+	pool         *pool.NewPool[net.Conn]
+	options      []pool.Option[net.Conn]
+	probeTimeout time.Duration
+}
+
+// Option configures optional connection pool behavior
+type Option func(*Pool)
+
+// WithPoolOptions passes options to the underlying resource pool, e.g.
+// pool.WithMaxActive
+func WithPoolOptions(options ...pool.Option[net.Conn]) Option {
+	return func(p *Pool) {
+		p.options = append(p.options, options...)
+	}
+}
+
+// WithProbe checks idle connections are still alive before handing them out:
+// a read with a timeout deadline must time out, as an idle connection closed
+// or written to by the peer is not reusable. Dead connections are destroyed
+// and Acquire moves on to the next one. Probing adds up to timeout to each
+// acquire of an idle connection.
+func WithProbe(timeout time.Duration) Option {
+	return func(p *Pool) {
+		p.probeTimeout = timeout
+	}
+}
+
+// returns a dial function connecting to address on the named network
+func Dial(network string, address string) func(context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	return func(ctx context.Context) (net.Conn, error) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+// acquires an idle connection, or dials a new one. Closing the returned
+// connection releases it back to the pool.
+func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
+	for {
+		conn, info, err := p.pool.AcquireWithInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsReused || p.probeTimeout <= 0 || isAlive(conn, p.probeTimeout) {
+			return &Conn{Conn: conn, releaser: p.pool}, nil
+		}
+		p.pool.Invalidate(conn)
+	}
+}
+
+// returns the statistics of the pool
+func (p *Pool) Stats() pool.Stats {
+	return p.pool.Stats()
+}
+
+// returns the underlying resource pool, e.g. to register it with a collector
+func (p *Pool) Pool() *pool.NewPool[net.Conn] {
+	return p.pool
+}
+
+// closes the idle connections and rejects further acquires
+func (p *Pool) Close() {
+	p.pool.Close()
+}
+
+// reports whether an idle connection is still open and has nothing to read
+func isAlive(conn net.Conn, timeout time.Duration) bool {
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return false
+	}
+	defer conn.SetReadDeadline(time.Time{})
+
+	var b [1]byte
+	_, err := conn.Read(b[:])
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Conn is a connection acquired from a Pool. Close releases it back to the
+// pool, unless a read or write failed or Invalidate was called, in which case
+// the connection is destroyed.
+type Conn struct {
+	net.Conn
+	releaser releaser
+
+	mutex         sync.Mutex
+	isFailed      bool
+	isReleased    bool
+	isInvalidated bool
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	read, err := c.Conn.Read(b)
+	c.recordError(err)
+	return read, err
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	written, err := c.Conn.Write(b)
+	c.recordError(err)
+	return written, err
+}
+
+// marks the connection as unusable, e.g. after a protocol error, so Close
+// destroys it instead of releasing it
+func (c *Conn) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isInvalidated = true
+}
+
+// releases the connection back to the pool, clearing its deadlines; returns
+// net.ErrClosed if it was already closed
+func (c *Conn) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.isReleased {
+		return net.ErrClosed
+	}
+	c.isReleased = true
+
+	if c.isFailed || c.isInvalidated || c.Conn.SetDeadline(time.Time{}) != nil {
+		return c.releaser.Invalidate(c.Conn)
+	}
+	return c.releaser.TryRelease(c.Conn)
+}
+
+// releaser takes back the connections of a pool
+type releaser interface {
+	TryRelease(net.Conn) error
+	Invalidate(net.Conn) error
+}
+
+// records a failed read or write; the connection state is unknown after it
+func (c *Conn) recordError(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isFailed = true
+}
+
+// creates a pool of connections dialed by dial
+func New(
+	// dial is called by the pool to create a connection
+	dial func(context.Context) (net.Conn, error),
+	// maxIdleSize is the number of maximum idle connections kept in the pool
+	maxIdleSize int,
+	// maxIdleTime is the maximum time a connection can be idle before it is closed
+	maxIdleTime time.Duration,
+	options ...Option,
+) *Pool {
+	p := &Pool{}
+	for _, option := range options {
+		option(p)
+	}
+
+	destroyer := pool.WithDestroyer(func(conn net.Conn) error { return conn.Close() })
+	p.pool = pool.New(dial, maxIdleSize, maxIdleTime, append([]pool.Option[net.Conn]{destroyer}, p.options...)...)
+	return p
+}
@@ -0,0 +1,147 @@
+package netpool
+
+import (
+	"context"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+const maxIdleSize = 3
+const maxIdleTime = 5 * time.Second
+
+// pipeDialer dials in-memory connections and keeps their server ends
+type pipeDialer struct {
+	peers []net.Conn
+}
+
+func (d *pipeDialer) dial(context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	d.peers = append(d.peers, server)
+	return client, nil
+}
+
+func TestConn_Close(t *testing.T) {
+	testCases := []struct {
+		name            string
+		use             func(*Conn, net.Conn)
+		expectedIdle    int
+		expectedEvicted int64
+	}{
+		{
+			name:         "after use releases connection",
+			use:          func(*Conn, net.Conn) {},
+			expectedIdle: 1,
+		},
+		{
+			name: "after failed write destroys connection",
+			use: func(conn *Conn, peer net.Conn) {
+				peer.Close()
+				conn.Write([]byte("PING\r\n"))
+			},
+			expectedEvicted: 1,
+		},
+		{
+			name: "after invalidate destroys connection",
+			use: func(conn *Conn, peer net.Conn) {
+				conn.Invalidate()
+			},
+			expectedEvicted: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dialer := &pipeDialer{}
+			connections := New(dialer.dial, maxIdleSize, maxIdleTime)
+			conn, err := connections.Acquire(nil)
+			require.NoError(t, err)
+			tc.use(conn, dialer.peers[0])
+
+			conn.Close()
+
+			stats := connections.Stats()
+			assert.Equal(t, tc.expectedIdle, stats.Idle)
+			assert.Equal(t, tc.expectedEvicted, stats.Evictions[pool.EvictInvalidated])
+			assert.ErrorIs(t, conn.Close(), net.ErrClosed)
+		})
+	}
+}
+
+func TestPool_AcquireWithProbe(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []Option
+		breakPeer     func(net.Conn)
+		expectedDials int
+	}{
+		{
+			name:          "with live connection reuses it",
+			options:       []Option{WithProbe(time.Millisecond)},
+			breakPeer:     func(net.Conn) {},
+			expectedDials: 1,
+		},
+		{
+			name:          "with peer closed dials new connection",
+			options:       []Option{WithProbe(time.Millisecond)},
+			breakPeer:     func(peer net.Conn) { peer.Close() },
+			expectedDials: 2,
+		},
+		{
+			name:    "with unsolicited data dials new connection",
+			options: []Option{WithProbe(time.Millisecond)},
+			breakPeer: func(peer net.Conn) {
+				go peer.Write([]byte("-ERR\r\n"))
+			},
+			expectedDials: 2,
+		},
+		{
+			name:          "without probe reuses dead connection",
+			breakPeer:     func(peer net.Conn) { peer.Close() },
+			expectedDials: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dialer := &pipeDialer{}
+			connections := New(dialer.dial, maxIdleSize, maxIdleTime, tc.options...)
+			conn, _ := connections.Acquire(nil)
+			conn.Close()
+			tc.breakPeer(dialer.peers[0])
+
+			conn, err := connections.Acquire(nil)
+
+			assert.NoError(t, err)
+			assert.Len(t, dialer.peers, tc.expectedDials)
+			assert.Equal(t, 1, connections.Stats().Active)
+		})
+	}
+}
+
+func TestNew_WithPoolOptions(t *testing.T) {
+	dialer := &pipeDialer{}
+	connections := New(dialer.dial, maxIdleSize, maxIdleTime,
+		WithPoolOptions(pool.WithMaxActive[net.Conn](1)),
+	)
+	connections.Acquire(nil)
+
+	_, err := connections.Acquire(nil)
+
+	assert.ErrorIs(t, err, pool.ErrPoolExhausted)
+}
+
+func TestPool_Close(t *testing.T) {
+	dialer := &pipeDialer{}
+	connections := New(dialer.dial, maxIdleSize, maxIdleTime)
+	conn, _ := connections.Acquire(nil)
+	conn.Close()
+
+	connections.Close()
+
+	_, err := dialer.peers[0].Read(make([]byte, 1))
+	assert.Error(t, err)
+}
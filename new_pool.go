@@ -1,9 +1,11 @@
-package main
+package pool
 
 import (
+	"container/heap"
 	"context"
-	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,113 +22,1007 @@ type NewPool[T comparable] struct {
 	maxIdleSize int
 	maxIdleTime time.Duration
 	mutex       PoolMutex
-	lock        map[T]time.Time
-	unlock      map[T]time.Time
+	lock        map[T]*resourceEntry
+	unlock      map[T]*resourceEntry
+	expiries    expiryHeap[T]
+	stats       poolStats
+	destroyer   func(T) error
+	hooks       Hooks[T]
+	subscribers []chan Event
+	logger      Logger
+	scheduler   *Scheduler
+	clock       Clock
+	versioner   func(T) string
+	notify      chan struct{}
+	maxActive   int
+	maxWaiters  int
+	waiters     int
+	quota       *Quota
+	isClosed    bool
+	warmupSize  int
+	rampWindow  time.Duration
+	isWarming   bool
+	isReentrant bool
+	leaseTTL    time.Duration
+	maxHoldTime time.Duration
+
+	isLeaseReclaimed    bool
+	isReclaimDestroying bool
+	leases              map[T]*Lease[T]
+	orphaned            map[T]struct{}
+	reclaimed           map[T]*resourceEntry
+	affinities          map[string]T
+	identity            func(T) any
+	identities          map[any]T
+	tenantLimit         func(string) int
+	tenants             map[string]*tenantState
+
+	healthScorer   func(T) float64
+	validator      func(context.Context, T) error
+	latencyBounds  []time.Duration
+	minHealthScore float64
+
+	endpoints            []string
+	dial                 func(context.Context, string) (T, error)
+	happyEyeballsStagger time.Duration
+	isHappyEyeballs      bool
+	quarantine           *quarantine
+
+	handleCounter func(T) int
+	handleLimit   int
+	handles       int
+
+	reporter       func(Stats)
+	reportInterval time.Duration
+	reportTimer    Timer
+	nextReportAt   time.Time
+
+	sizing         AutoSizing
+	demand         demandSample
+	minWarmupSize  int
+	autoSizeTimer  Timer
+	nextAutoSizeAt time.Time
+	reapInterval   time.Duration
+	reapBatchSize  int
+	reapTimer      Timer
+	nextReapAt     time.Time
+
+	isPressured         func() bool
+	pressureInterval    time.Duration
+	pressuredIdleSize   int
+	isUnderPressure     bool
+	pressureTimer       Timer
+	nextPressureCheckAt time.Time
+
+	idleTimer    func(T) time.Duration
+	expiryJitter float64
+	idleTimeMode IdleTimeMode
+	maxLifetime  time.Duration
+
+	maxOverflowSize  int
+	overflowIdleTime time.Duration
+
+	createRate       *tokenBucket
+	createTimeout    time.Duration
+	isHedged         bool
+	acquirePolicy    AcquirePolicy
+	targetSize       int
+	recentErrors     []RecentError
+	slowStartInitial int
+	slowStartWindow  time.Duration
+	slowStartAt      time.Time
+	saturationHigh   float64
+	saturationLow    float64
+	onSaturation     func(isSaturated bool, saturation float64)
+	isSaturated      bool
+	onStateChange    func(from PoolState, to PoolState)
+	state            PoolState
+	isCoalescing     bool
+	createSeq        atomic.Int64
+	lastCreateSeq    int64
+	lastCreateErr    error
+	idleCreating     int
+	joined           int
+	missCreating     int
+	hedging          int
+
+	resetter func(T) error
+
+	chaos *Chaos
+
+	evictionPolicy EvictionPolicy
+
+	profileName string
+
+	slowAcquireThreshold time.Duration
+	onSlowAcquire        func(SlowAcquire)
+
+	generation             uint64
+	minGeneration          uint64
+	isRetiringOnSetCreator bool
+
+	createAttempts int
+	creating       atomic.Int64
+
+	coster     func(T) int64
+	maxCost    int64
+	activeCost int64
+
+	isLocalCached bool
+	local         *localCache[T]
+
+	isPanicContained bool
+	hookPanicLimit   int
+	disabledHooks    map[string]bool
+
+	isSynchronous bool
+
+	isPaused        atomic.Bool
+	isPauseFailFast bool
+
+	compatibilityV1 bool
 }
 
 type PoolResource struct {
 }
 
+// resourceEntry is the bookkeeping kept for a pooled resource
+type resourceEntry struct {
+	createdAt  time.Time
+	acquiredAt time.Time
+	releasedAt time.Time
+
+	healthScore float64
+	isScored    bool
+
+	scope    *reentrantScope
+	depth    int
+	tag      string
+	affinity string
+	tenant   string
+
+	handles     int
+	cost        int64
+	maxIdleTime time.Duration
+	generation  uint64
+	profileCtx  context.Context
+	metadata    *Metadata
+
+	useCount     int64
+	heldDuration time.Duration
+	isOverflow   bool
+}
+
 type PoolMutex interface {
 	Lock()
 	Unlock()
 }
 
+// Option configures optional pool behavior
+type Option[T comparable] func(*NewPool[T])
+
+// WithDestroyer sets the function called to close resources dropped by the
+// pool. Without it, resources are closed with Close when T implements
+// io.Closer; a value resource whose Close has a pointer receiver needs a
+// destroyer, and a destroyer doing nothing keeps resources open.
+func WithDestroyer[T comparable](destroyer func(T) error) Option[T] {
+	return func(n *NewPool[T]) {
+		n.destroyer = destroyer
+	}
+}
+
+// WithMaxActive caps the number of acquired resources. At capacity, Acquire
+// waits for a resource to be released until ctx is done; with a nil ctx it
+// returns ErrPoolExhausted instead of waiting. An acquire creates its
+// resource under the pool mutex and counts it as acquired right away, so
+// bursts can not overshoot the cap with creations in flight; other acquires
+// wait for the creation to finish or for a release. A zero maxActive is
+// derived from GOMAXPROCS; a negative one does not cap acquires.
+func WithMaxActive[T comparable](maxActive int) Option[T] {
+	return func(n *NewPool[T]) {
+		if maxActive == 0 {
+			maxActive = getDefaultMaxActive()
+		}
+		n.maxActive = maxActive
+	}
+}
+
 // creates or returns a ready-to-use item from the resource pool
-func (n NewPool[T]) Acquire(ctx context.Context) (T, error) {
+func (n *NewPool[T]) Acquire(ctx context.Context) (T, error) {
+	resource, _, err := n.AcquireWithInfo(ctx)
+	return resource, err
+}
+
+// acquires like Acquire and reports whether the resource was reused or freshly
+// created, and its age
+func (n *NewPool[T]) AcquireWithInfo(ctx context.Context) (T, AcquireInfo, error) {
+	return n.acquireWithInfo(ctx, nil)
+}
+
+// acquires a resource accepted by predicate, or any with a nil predicate
+func (n *NewPool[T]) acquireWithInfo(ctx context.Context, predicate func(T) bool) (T, AcquireInfo, error) {
+	defer n.runDueTasks()
+
+	if predicate == nil && !n.isPaused.Load() {
+		if resource, isHit := n.local.get(); isHit {
+			return resource, AcquireInfo{IsReused: true}, nil
+		}
+	}
+
+	start := n.now()
+	var wait acquireWait
+	n.startCoalescing(&wait)
+
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
+	defer n.recordWait(&wait)
 
+	n.stats.acquires++
+	if n.isClosed {
+		return *new(T), AcquireInfo{}, ErrPoolClosed
+	}
 	n.deleteInvalidIdleResources()
 
-	if resource, isSuccess := n.getIdleResource(); isSuccess {
-		return resource, nil
+	var scope *reentrantScope
+	if predicate == nil {
+		scope = n.getReentrantScope(ctx)
+	}
+	if resource, isHeld := n.reacquire(scope); isHeld {
+		n.recordAcquireLatency(AcquireReused, start)
+		return resource, n.getAcquireInfo(n.lock[resource], true), nil
+	}
+	if err := n.waitResume(ctx, &wait); err != nil {
+		return *new(T), AcquireInfo{}, err
 	}
 
-	// creates resource
-	resource, err := n.creator(ctx)
+	tenant, err := n.takeTenant(ctx)
 	if err != nil {
-		return *new(T), err
+		return *new(T), AcquireInfo{}, err
+	}
+	if err := n.takeQuota(ctx, &wait); err != nil {
+		n.giveTenant(tenant)
+		return *new(T), AcquireInfo{}, err
 	}
 
-	n.lock[resource] = time.Now()
-	return resource, nil
+	var resource T
+	if pin := n.getVersionPin(ctx); pin != nil && predicate == nil {
+		resource, err = n.acquirePinned(ctx, pin, &wait)
+	} else {
+		resource, err = n.acquire(ctx, predicate, &wait)
+	}
+	if err != nil {
+		n.quota.give()
+		n.giveTenant(tenant)
+		return *new(T), AcquireInfo{}, err
+	}
+
+	info := n.getAcquireInfo(n.lock[resource], false)
+	info.Waited = wait.duration
+	n.recordAcquireLatency(info.Outcome(), start)
+	n.setTenant(n.lock[resource], tenant)
+	n.handOut(resource, scope, getHolderTag(ctx))
+	n.labelHold(ctx, n.lock[resource])
+	if n.local == nil {
+		n.setAffinity(resource, n.lock[resource], getAffinity(ctx))
+	}
+	return resource, info, nil
+}
+
+// runs the acquire hook and events of a resource handed out by an acquire
+// whose holder is tagged tag
+func (n *NewPool[T]) handOut(resource T, scope *reentrantScope, tag string) {
+	entry := n.lock[resource]
+	entry.tag = tag
+	entry.useCount++
+	n.hold(scope, resource, entry)
+	n.runAcquireHook(resource, entry)
+	if !entry.releasedAt.IsZero() {
+		n.publish(EventReused, entry, nil)
+	}
+	if n.local != nil {
+		// resources of a locally cached pool are not tracked while acquired,
+		// nor drawn from a quota
+		n.markInactive(resource, entry)
+		n.notifyWaiters()
+	}
 }
 
 // releases an active resource back to the resource pool
-func (n NewPool[T]) Release(resource T) {
+func (n *NewPool[T]) Release(resource T) {
+	n.TryRelease(resource)
+}
+
+// releases an active resource back to the resource pool; returns
+// ErrNotAcquired if the resource was not acquired from the pool
+func (n *NewPool[T]) TryRelease(resource T) error {
+	defer n.runDueTasks()
+
+	if n.local != nil && !n.resetLocal(resource) {
+		return nil
+	}
+	if n.local.put(resource) {
+		return nil
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.local != nil {
+		n.evict(resource, &resourceEntry{}, EvictClosed)
+		return nil
+	}
+	return n.release(resource)
+}
+
+// destroys an acquired resource instead of returning it to the pool, e.g.
+// after it failed mid-use; returns ErrNotAcquired if the resource was not
+// acquired from the pool
+func (n *NewPool[T]) Invalidate(resource T) error {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
 
-	savedTimestamp, isFound := n.lock[resource]
+	return n.invalidate(resource)
+}
+
+// destroys an acquired resource
+func (n *NewPool[T]) invalidate(resource T) error {
+	resource = n.rekey(resource)
+	entry, isFound := n.lock[resource]
 	if !isFound {
-		fmt.Println("resource not previously acquired; not returning to idle resource pool")
-		return
+		if n.destroyReclaimed(resource) {
+			return nil
+		}
+		return ErrNotAcquired
 	}
 
-	delete(n.lock, resource)
+	n.endLease(resource)
+	n.deactivate(resource, entry)
+	n.notifyWaiters()
+	n.evict(resource, entry, EvictInvalidated)
+	return nil
+}
 
-	validTimestamp := n.getValidTimestamp()
-	if savedTimestamp.Before(validTimestamp) {
-		fmt.Println("resource already expired; not returning to idle resource pool")
-		return
+// records an acquired resource as no longer acquired, skipping its release;
+// the resource is let go of by any reentrant scope holding it
+func (n *NewPool[T]) deactivate(resource T, entry *resourceEntry) {
+	n.markInactive(resource, entry)
+	n.quota.give()
+	n.clearTenant(entry)
+	n.unlabelHold(entry)
+	n.recordHold(entry)
+	if entry.scope != nil {
+		entry.scope.clear(n)
 	}
-	if n.NumIdle() >= n.maxIdleSize {
-		fmt.Println("resource already expired; not returning to idle resource pool")
+}
+
+// destroys the idle resources and rejects further acquires; resources
+// released afterwards are destroyed
+func (n *NewPool[T]) Close() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.isClosed {
 		return
 	}
 
-	n.unlock[resource] = time.Now()
+	n.isClosed = true
+	n.stopReports()
+	n.stopAutoSize()
+	n.stopReap()
+	n.stopPressureChecks()
+	for resource, entry := range n.unlock {
+		delete(n.unlock, resource)
+		n.evict(resource, entry, EvictClosed)
+	}
+	for _, resource := range n.local.close() {
+		n.evict(resource, &resourceEntry{}, EvictClosed)
+	}
+	n.notifyWaiters()
+	n.checkState()
+}
+
+// reports whether the pool holds no resources once expired ones are swept
+func (n *NewPool[T]) isEmpty() bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.deleteInvalidIdleResources()
+	return len(n.lock) == 0 && len(n.unlock) == 0 && !n.isWarming
 }
 
 // returns the number of idle items
-func (n NewPool[T]) NumIdle() int {
+func (n *NewPool[T]) NumIdle() int {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
 
 	return len(n.unlock)
 }
 
-// cleans up expired idle resources
-func (n NewPool[T]) deleteInvalidIdleResources() {
-	validTimestamp := n.getValidTimestamp()
+// returns the number of acquired items; resources of a pool with
+// WithLocalCache are not tracked while acquired
+func (n *NewPool[T]) NumActive() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return len(n.lock)
+}
+
+// returns the number of idle and acquired items
+func (n *NewPool[T]) NumTotal() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return len(n.unlock) + len(n.lock)
+}
+
+// returns the maximum number of acquired items, as set by WithMaxActive;
+// zero means no limit
+func (n *NewPool[T]) Cap() int {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
 
-	for key, savedTimestamp := range n.unlock {
-		if savedTimestamp.Before(validTimestamp) {
-			delete(n.unlock, key)
+	return n.maxActive
+}
+
+// returns a snapshot of the pool counters and current sizes
+func (n *NewPool[T]) Stats() Stats {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.getStats()
+}
+
+// returns a snapshot of the pool counters and current sizes; the pool mutex
+// must be held
+func (n *NewPool[T]) getStats() Stats {
+	stats := n.stats.snapshot()
+	stats.Idle = len(n.unlock)
+	stats.Active = len(n.lock)
+	stats.Total = stats.Idle + stats.Active
+	stats.Cap = n.maxActive
+	stats.IdleCap = n.maxIdleSize
+	stats.ActiveCost = n.activeCost
+	stats.Waiters = n.waiters
+	stats.Creating = int(n.creating.Load())
+	stats.LocalHits = n.local.getHits()
+	stats.Quarantined = n.quarantine.snapshot()
+	stats.Tenants = n.getTenantStats()
+	if n.handleCounter != nil {
+		stats.Handles = n.handles
+		stats.HandleLimit = n.handleLimit
+	}
+	if n.scheduler != nil {
+		stats.Goroutines = n.scheduler.Stats()
+	}
+	return stats
+}
+
+// returns an idle resource accepted by predicate, any with a nil predicate,
+// or creates one if none is available, waiting for a release while the pool
+// is at capacity
+func (n *NewPool[T]) acquire(ctx context.Context, predicate func(T) bool, wait *acquireWait) (T, error) {
+	if predicate == nil {
+		predicate = func(T) bool { return true }
+	}
+
+	for {
+		if !n.isAtCapacity() {
+			if n.isCreatePreferred() {
+				return n.createResource(ctx)
+			}
+			if resource, isSuccess := n.getAffineResource(ctx, predicate); isSuccess {
+				n.stats.reused++
+				return resource, nil
+			}
+			if resource, isSuccess := n.getIdleResourceWhere(ctx, predicate); isSuccess {
+				n.stats.reused++
+				return resource, nil
+			}
+			delay := n.createRate.getDelay(n.now())
+			if delay <= 0 {
+				if err := n.getCoalescedError(wait); err != nil {
+					return *new(T), err
+				}
+				if isJoined, err := n.joinCreation(ctx, wait); isJoined {
+					if err != nil {
+						return *new(T), err
+					}
+					if n.isClosed {
+						return *new(T), ErrPoolClosed
+					}
+					continue
+				}
+			}
+			if delay <= 0 && n.isHedged && ctx != nil {
+				return n.acquireHedged(ctx, predicate, wait)
+			}
+			if delay <= 0 && n.isCoalescing {
+				return n.createCoalesced(ctx)
+			}
+			if delay <= 0 {
+				return n.createResource(ctx)
+			}
+
+			if err := n.waitCreateRate(ctx, wait, delay); err != nil {
+				return *new(T), err
+			}
+			if n.isClosed {
+				return *new(T), ErrPoolClosed
+			}
+			continue
+		}
+
+		if err := n.waitCapacity(ctx, wait, 1); err != nil {
+			return *new(T), err
 		}
+		if n.isClosed {
+			return *new(T), ErrPoolClosed
+		}
+		n.deleteInvalidIdleResources()
+	}
+}
+
+// takes a slot of the pool's quota; the pool mutex is released while waiting
+// for one
+func (n *NewPool[T]) takeQuota(ctx context.Context, wait *acquireWait) error {
+	if n.quota == nil || n.quota.take(nil) == nil {
+		return nil
+	}
+	if ctx == nil {
+		return ErrPoolExhausted
+	}
+
+	if err := n.enterWait(); err != nil {
+		return err
+	}
+	start := n.now()
+	n.mutex.Unlock()
+	err := n.quota.take(ctx)
+	n.mutex.Lock()
+	n.waiters--
+	wait.add(n.now().Sub(start))
+
+	if err == nil && n.isClosed {
+		n.quota.give()
+		return ErrPoolClosed
+	}
+	return err
+}
+
+// reports whether no more resources may be acquired without a release
+func (n *NewPool[T]) isAtCapacity() bool {
+	return !n.hasCapacityFor(1)
+}
+
+// reports whether count more resources may be acquired without a release;
+// the cost budget admits acquires while it is not used up
+func (n *NewPool[T]) hasCapacityFor(count int) bool {
+	if n.maxCost > 0 && n.activeCost >= n.maxCost {
+		return false
+	}
+	maxActive := n.getMaxActive(n.now())
+	return maxActive <= 0 || len(n.lock)+n.hedging+n.missCreating+count <= maxActive
+}
+
+// records a resource as acquired
+func (n *NewPool[T]) markActive(resource T, entry *resourceEntry) {
+	n.lock[resource] = entry
+	n.trackIdentity(resource)
+	n.activeCost += entry.cost
+	n.sampleActive()
+	n.checkSaturation()
+	n.checkState()
+}
+
+// records an acquired resource as no longer acquired
+func (n *NewPool[T]) markInactive(resource T, entry *resourceEntry) {
+	delete(n.lock, resource)
+	n.activeCost -= entry.cost
+	n.checkSaturation()
+	n.checkState()
+}
+
+// returns an acquired resource to the idle resource pool, if it is still valid
+func (n *NewPool[T]) release(resource T) error {
+	resource = n.rekey(resource)
+	entry, isFound := n.lock[resource]
+	if !isFound {
+		if n.destroyReclaimed(resource) {
+			return nil
+		}
+		if _, isOrphaned := n.orphaned[resource]; isOrphaned {
+			delete(n.orphaned, resource)
+			return nil
+		}
+		n.log(LogWarn, "resource not previously acquired; not returning to idle resource pool",
+			Field{Key: "error", Value: ErrNotAcquired},
+		)
+		return ErrNotAcquired
+	}
+	if n.unhold(entry) {
+		return nil
+	}
+	n.endLease(resource)
+
+	n.markInactive(resource, entry)
+	n.quota.give()
+	n.clearTenant(entry)
+	n.unlabelHold(entry)
+	n.recordHold(entry)
+	n.runReleaseHook(resource, entry)
+	n.notifyWaiters()
+
+	if n.isClosed {
+		n.evict(resource, entry, EvictClosed)
+		return nil
+	}
+
+	if n.isRetired(entry) {
+		n.evict(resource, entry, EvictRetired)
+		return nil
+	}
+	if n.isExpiredOnRelease(entry) {
+		n.log(LogDebug, "resource already expired; not returning to idle resource pool",
+			Field{Key: "created_at", Value: entry.createdAt},
+			Field{Key: "acquired_at", Value: entry.acquiredAt},
+			Field{Key: "max_idle_time", Value: n.getMaxIdleTime(entry)},
+		)
+		n.evict(resource, entry, EvictExpired)
+		return nil
+	}
+	if !n.reset(resource) {
+		n.evict(resource, entry, EvictResetFailed)
+		return nil
+	}
+	if n.chaos.isValidationFailed() {
+		n.evict(resource, entry, EvictChaos)
+		return nil
+	}
+
+	n.returnIdle(resource, entry)
+	return nil
+}
+
+// adds a resource to the idle resource pool, unless it is full
+func (n *NewPool[T]) returnIdle(resource T, entry *resourceEntry) {
+	if !n.updateHealthScore(resource, entry) {
+		n.log(LogDebug, "resource unhealthy; not returning to idle resource pool",
+			Field{Key: "health_score", Value: entry.healthScore},
+			Field{Key: "min_health_score", Value: n.minHealthScore},
+		)
+		n.evict(resource, entry, EvictUnhealthy)
+		return
+	}
+	entry.isOverflow = n.maxOverflowSize > n.maxIdleSize && len(n.unlock) >= n.maxIdleSize
+	if len(n.unlock) >= n.getMaxIdleCap() && !n.makeIdleRoom(resource, entry) {
+		n.log(LogDebug, "idle resource pool full; not returning resource to idle resource pool",
+			Field{Key: "max_idle_size", Value: n.maxIdleSize},
+		)
+		n.evict(resource, entry, EvictCapacity)
+		return
+	}
+
+	n.countHandles(resource, entry)
+	entry.releasedAt = n.now()
+	n.unlock[resource] = entry
+	n.pushExpiry(resource, entry)
+	n.notifyWaiters()
+	n.checkState()
+}
+
+// creates resource and marks it as acquired
+func (n *NewPool[T]) createResource(ctx context.Context) (T, error) {
+	start := n.now()
+	n.createRate.take(start)
+	resource, err := n.create(ctx, n.creator)
+	if err != nil {
+		createErr := n.recordCreateFailure(err, n.now().Sub(start))
+		n.recordCoalescedCreate(createErr)
+		return *new(T), createErr
+	}
+	n.recordCoalescedCreate(nil)
+
+	entry := &resourceEntry{createdAt: n.now(), generation: n.generation}
+	entry.acquiredAt = entry.createdAt
+	n.recordCreate(resource, entry, entry.createdAt.Sub(start))
+	n.markActive(resource, entry)
+	return resource, nil
+}
+
+// calls creator, delayed by chaos and labeled for the profiler, counting it
+// as in flight meanwhile; called with or without the pool mutex
+func (n *NewPool[T]) create(ctx context.Context, creator func(context.Context) (T, error)) (T, error) {
+	n.creating.Add(1)
+	defer n.creating.Add(-1)
+
+	if n.chaos.isCreateDelayed() {
+		n.sleep(n.chaos.CreateDelay)
+	}
+
+	var resource T
+	var err error
+	n.doLabeled(ctx, "create", func(ctx context.Context) {
+		resource, err = n.callCreator(ctx, creator)
+	})
+	return resource, err
+}
+
+// records a newly created resource in the stats, hooks and events
+func (n *NewPool[T]) recordCreate(resource T, entry *resourceEntry, elapsed time.Duration) {
+	n.stats.created++
+	if n.createAttempts > 0 {
+		n.restartSlowStart()
+	}
+	n.createAttempts = 0
+	n.countHandles(resource, entry)
+	if n.coster != nil {
+		entry.cost = n.coster(resource)
+	}
+	if n.idleTimer != nil {
+		entry.maxIdleTime = n.idleTimer(resource)
+	}
+	if n.expiryJitter > 0 {
+		entry.maxIdleTime = getJitteredIdleTime(n.getMaxIdleTime(entry), n.expiryJitter, rand.Int63n)
+	}
+	n.runCreateHook(resource, entry, elapsed)
+	n.publish(EventCreated, entry, nil)
+	n.checkState()
+}
+
+// records a failed creation in the stats; returns the creator error wrapped
+// with the context of the failure
+func (n *NewPool[T]) recordCreateFailure(err error, elapsed time.Duration) *CreateError {
+	n.stats.createFailures++
+	n.createAttempts++
+
+	createErr := &CreateError{Err: err, Attempt: n.createAttempts, Elapsed: elapsed}
+	if endpointErr, isEndpoint := err.(*endpointError); isEndpoint {
+		createErr.Endpoint = endpointErr.endpoint
+		createErr.Err = endpointErr.err
+	}
+	n.recordRecentError("create", createErr)
+	n.checkState()
+	return createErr
+}
+
+// drops a resource from the pool and destroys it
+func (n *NewPool[T]) evict(resource T, entry *resourceEntry, reason EvictReason) {
+	n.clearAffinity(resource, entry)
+	n.untrackIdentity(resource)
+	n.stats.recordEviction(reason)
+	n.uncountHandles(entry)
+	n.runEvictHook(resource, entry, reason)
+
+	start := n.now()
+	var err error
+	if n.destroyer != nil {
+		err = n.destroyer(resource)
+	}
+	n.runDestroyHook(resource, entry, reason, n.now().Sub(start), err)
+
+	n.publish(getEvictedEventType(reason), entry, nil)
+	if err != nil {
+		n.log(LogWarn, "failed to destroy resource",
+			Field{Key: "reason", Value: reason},
+			Field{Key: "error", Value: err},
+		)
+		n.publish(EventDestroyFailed, entry, err)
+		n.recordRecentError("destroy", err)
+	}
+	n.checkState()
+}
+
+// cleans up expired idle resources, soonest expiry first, up to the reaper
+// batch size
+func (n *NewPool[T]) deleteInvalidIdleResources() {
+	n.syncExpiries()
+	now := n.now()
+	evicted := 0
+	for len(n.expiries) > 0 {
+		next := n.expiries[0]
+		if !n.isCurrent(next) {
+			heap.Pop(&n.expiries)
+			continue
+		}
+		if !next.deadline.Before(now) {
+			return
+		}
+		if n.reapBatchSize > 0 && evicted >= n.reapBatchSize {
+			return
+		}
+
+		heap.Pop(&n.expiries)
+		delete(n.unlock, next.resource)
+		n.evict(next.resource, next.entry, EvictExpired)
+		evicted++
 	}
 }
 
 // retrieves idle resource
-func (n NewPool[T]) getIdleResource() (T, bool) {
-	for resource, _ := range n.unlock {
-		delete(n.unlock, resource)
-		n.lock[resource] = time.Now()
-		return resource, true
+func (n *NewPool[T]) getIdleResource(ctx context.Context) (T, bool) {
+	return n.getIdleResourceWhere(ctx, func(T) bool { return true })
+}
+
+// retrieves idle resource accepted by the predicate, destroying the ones
+// failing validation
+func (n *NewPool[T]) getIdleResourceWhere(ctx context.Context, predicate func(T) bool) (T, bool) {
+	for {
+		chosen, chosenEntry := n.chooseIdleResource(predicate)
+		if chosenEntry == nil {
+			return *new(T), false
+		}
+
+		delete(n.unlock, chosen)
+		if !n.validate(ctx, chosen) {
+			n.evict(chosen, chosenEntry, EvictValidateFailed)
+			continue
+		}
+		chosenEntry.acquiredAt = n.now()
+		n.markActive(chosen, chosenEntry)
+		return chosen, true
+	}
+}
+
+// returns the idle resource accepted by the predicate to hand out next; a
+// nil entry if there is none
+func (n *NewPool[T]) chooseIdleResource(predicate func(T) bool) (T, *resourceEntry) {
+	var chosen T
+	var chosenEntry *resourceEntry
+	for resource, entry := range n.unlock {
+		if n.isStale(entry) {
+			delete(n.unlock, resource)
+			n.evict(resource, entry, EvictRetired)
+			continue
+		}
+		if n.chaos.isExpired() {
+			delete(n.unlock, resource)
+			n.evict(resource, entry, EvictChaos)
+			continue
+		}
+		if !predicate(resource) || n.isLeftExpired(entry) {
+			continue
+		}
+
+		if chosenEntry == nil || n.isHealthier(entry, chosenEntry) {
+			chosen, chosenEntry = resource, entry
+		}
+		if n.healthScorer == nil {
+			break
+		}
+	}
+	return chosen, chosenEntry
+}
+
+// wakes up acquires waiting for a release or for the pool to close
+func (n *NewPool[T]) notifyWaiters() {
+	if n.notify != nil {
+		close(n.notify)
+		n.notify = nil
 	}
+}
+
+// returns the channel closed by the next notifyWaiters
+func (n *NewPool[T]) getNotify() <-chan struct{} {
+	if n.notify == nil {
+		n.notify = make(chan struct{})
+	}
+	return n.notify
+}
+
+// blocks until a resource is released, the pool is closed or ctx is done; the
+// pool mutex is released while waiting. Returns ErrPoolExhausted for a nil
+// ctx, and ErrAcquireTimeout if the ctx deadline passed.
+func (n *NewPool[T]) wait(ctx context.Context) error {
+	if ctx == nil {
+		return ErrPoolExhausted
+	}
+
+	notify := n.getNotify()
+
+	n.mutex.Unlock()
+	defer n.mutex.Lock()
 
-	return *new(T), false
+	var err error
+	n.doLabeled(ctx, "wait", func(context.Context) {
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			err = getWaitError(ctx)
+		}
+	})
+	return err
 }
 
-func (n NewPool[T]) getValidTimestamp() time.Time {
-	return time.Now().Add(-1 * n.maxIdleTime)
+// reports whether a resource idle since t exceeded its max idle time
+func (n *NewPool[T]) isExpired(entry *resourceEntry, t time.Time) bool {
+	return t.Before(n.now().Add(-1 * n.getMaxIdleTime(entry)))
 }
 
 func New[T comparable](
 	// creator is a function called by the pool to create a resource.
 	creator func(context.Context) (T, error),
-	// maxIdleSize is the number of maximum idle items kept in the pool
+	// maxIdleSize is the number of maximum idle items kept in the pool; zero
+	// is derived from GOMAXPROCS, a negative size keeps no idle items
 	maxIdleSize int,
-	// maxIdleTime is the maximum idle time for an idle item to be swept from the pool
+	// maxIdleTime is the maximum idle time for an idle item to be swept from the
+	// pool; see WithIdleTimeMode for what it is measured from
 	maxIdleTime time.Duration,
-) Pool[T] {
-	return &NewPool[T]{
+	// options configure optional behavior
+	options ...Option[T],
+) *NewPool[T] {
+	pool := &NewPool[T]{
 		creator:     creator,
-		maxIdleSize: maxIdleSize,
+		maxIdleSize: getMaxIdleSize(maxIdleSize),
 		maxIdleTime: maxIdleTime,
 		mutex:       &sync.Mutex{},
-		lock:        make(map[T]time.Time),
-		unlock:      make(map[T]time.Time),
+		lock:        make(map[T]*resourceEntry),
+		unlock:      make(map[T]*resourceEntry),
+	}
+
+	for _, option := range options {
+		option(pool)
+	}
+	if pool.compatibilityV1 && maxIdleSize == 0 {
+		// v1 kept no idle resources for a zero size, without a default
+		pool.maxIdleSize = 0
 	}
+	if !pool.compatibilityV1 {
+		// v1 only destroyed resources with a WithDestroyer
+		pool.destroyer = pool.getDestroyer()
+	}
+	pool.state = StateHealthy
+	if pool.validator == nil && !pool.compatibilityV1 {
+		pool.validator = getValidator[T]()
+	}
+	if pool.resetter == nil && !pool.compatibilityV1 {
+		pool.resetter = getResetter[T]()
+	}
+
+	if pool.isSynchronous {
+		// a scheduler without goroutines, on which TryGo always fails
+		pool.scheduler = &Scheduler{}
+		pool.rampWindow = 0
+	} else if pool.scheduler == nil {
+		pool.scheduler = NewScheduler(defaultGoroutineLimit)
+	}
+
+	if pool.compatibilityV1 {
+		pool.applyCompatibilityV1()
+	}
+
+	if pool.sizing.Interval > 0 && pool.sizing.Ceiling <= 0 {
+		pool.sizing.Ceiling = defaultCeilingFactor * pool.maxIdleSize
+	}
+	pool.minWarmupSize = pool.warmupSize
+	if pool.handleCounter != nil {
+		pool.handleLimit = getProcessHandleLimit()
+	}
+
+	if pool.isLocalCached {
+		pool.local = newLocalCache[T]()
+		pool.quota = nil
+	}
+
+	if len(pool.endpoints) > 0 {
+		pool.creator = pool.getEndpointCreator()
+	}
+
+	pool.mutex.Lock()
+	pool.restartSlowStart()
+	pool.scheduleReport()
+	pool.scheduleAutoSize()
+	pool.scheduleReap()
+	pool.schedulePressureCheck()
+	pool.mutex.Unlock()
+
+	if pool.warmupSize > 0 {
+		pool.isWarming = true
+		if pool.isSynchronous {
+			pool.warmup()
+		} else {
+			pool.scheduler.Go(pool.warmup)
+		}
+	}
+
+	return pool
 }
@@ -1,4 +1,4 @@
-package main
+package pool
 
 import (
 	"context"
@@ -32,7 +32,7 @@ func TestNewPool_Acquire(t *testing.T) {
 	testCases := []struct {
 		name                   string
 		creator                func(ctx context.Context) (MockResource, error)
-		idleResourcePool       map[MockResource]time.Time
+		idleResourcePool       map[MockResource]*resourceEntry
 		expectedResource       MockResource
 		expectedError          error
 		expectedUsedPoolLength int
@@ -40,20 +40,20 @@ func TestNewPool_Acquire(t *testing.T) {
 	}{
 		{
 			name: "with expired idle resource updates idle resource pool",
-			idleResourcePool: map[MockResource]time.Time{
+			idleResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 2,
-				}: time.Now().Add(-2 * maxIdleTime),
+				}: {releasedAt: time.Now().Add(-2 * maxIdleTime)},
 			},
 			expectedUsedPoolLength: 1,
 			expectedIdlePoolLength: 0,
 		},
 		{
 			name: "with non-empty idle resource pool returns existing resource",
-			idleResourcePool: map[MockResource]time.Time{
+			idleResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 2,
-				}: time.Now(),
+				}: {releasedAt: time.Now()},
 			},
 			expectedResource: MockResource{
 				id: 2,
@@ -63,7 +63,7 @@ func TestNewPool_Acquire(t *testing.T) {
 		},
 		{
 			name:             "with empty idle resource pool returns new resource",
-			idleResourcePool: map[MockResource]time.Time{},
+			idleResourcePool: map[MockResource]*resourceEntry{},
 			expectedResource: MockResource{
 				id: 1,
 			},
@@ -73,7 +73,7 @@ func TestNewPool_Acquire(t *testing.T) {
 		{
 			name:             "with creator func error response returns error",
 			creator:          getErrorMockCreatorFunc(),
-			idleResourcePool: map[MockResource]time.Time{},
+			idleResourcePool: map[MockResource]*resourceEntry{},
 			expectedResource: MockResource{
 				id: 1,
 			},
@@ -88,7 +88,7 @@ func TestNewPool_Acquire(t *testing.T) {
 				tc.creator = getMockCreatorFunc()
 			}
 			if tc.idleResourcePool == nil {
-				tc.idleResourcePool = make(map[MockResource]time.Time)
+				tc.idleResourcePool = make(map[MockResource]*resourceEntry)
 			}
 
 			mockMutex := &MockMutex{}
@@ -101,7 +101,7 @@ func TestNewPool_Acquire(t *testing.T) {
 				maxIdleSize: maxIdleSize,
 				mutex:       mockMutex,
 				unlock:      tc.idleResourcePool,
-				lock:        make(map[MockResource]time.Time),
+				lock:        make(map[MockResource]*resourceEntry),
 			}
 
 			resource, err := pool.Acquire(nil)
@@ -122,18 +122,18 @@ func TestNewPool_Release(t *testing.T) {
 	testCases := []struct {
 		name                   string
 		resource               MockResource
-		usedResourcePool       map[MockResource]time.Time
-		idleResourcePool       map[MockResource]time.Time
+		usedResourcePool       map[MockResource]*resourceEntry
+		idleResourcePool       map[MockResource]*resourceEntry
 		expectedUsedPoolLength int
 		expectedIdlePoolLength int
 	}{
 		{
 			name:     "with non-acquired resource does not update idle pool",
 			resource: MockResource{id: 2},
-			usedResourcePool: map[MockResource]time.Time{
+			usedResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 1,
-				}: time.Now(),
+				}: {acquiredAt: time.Now()},
 			},
 			expectedUsedPoolLength: 1,
 			expectedIdlePoolLength: 0,
@@ -141,10 +141,10 @@ func TestNewPool_Release(t *testing.T) {
 		{
 			name:     "with expired resource does not update idle pool",
 			resource: MockResource{id: 2},
-			usedResourcePool: map[MockResource]time.Time{
+			usedResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 2,
-				}: time.Now().Add(-2 * maxIdleTime),
+				}: {acquiredAt: time.Now().Add(-2 * maxIdleTime)},
 			},
 			expectedUsedPoolLength: 0,
 			expectedIdlePoolLength: 0,
@@ -152,10 +152,10 @@ func TestNewPool_Release(t *testing.T) {
 		{
 			name:     "with valid resource updates idle pool",
 			resource: MockResource{id: 2},
-			usedResourcePool: map[MockResource]time.Time{
+			usedResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 2,
-				}: time.Now(),
+				}: {acquiredAt: time.Now()},
 			},
 			expectedUsedPoolLength: 0,
 			expectedIdlePoolLength: 1,
@@ -163,21 +163,21 @@ func TestNewPool_Release(t *testing.T) {
 		{
 			name:     "with valid resource and full idle pool does not update idle pool",
 			resource: MockResource{id: 2},
-			usedResourcePool: map[MockResource]time.Time{
+			usedResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 2,
-				}: time.Now(),
+				}: {acquiredAt: time.Now()},
 			},
-			idleResourcePool: map[MockResource]time.Time{
+			idleResourcePool: map[MockResource]*resourceEntry{
 				MockResource{
 					id: 5,
-				}: time.Now(),
+				}: {releasedAt: time.Now()},
 				MockResource{
 					id: 6,
-				}: time.Now(),
+				}: {releasedAt: time.Now()},
 				MockResource{
 					id: 7,
-				}: time.Now(),
+				}: {releasedAt: time.Now()},
 			},
 			expectedUsedPoolLength: 0,
 			expectedIdlePoolLength: 3,
@@ -187,7 +187,7 @@ func TestNewPool_Release(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.idleResourcePool == nil {
-				tc.idleResourcePool = make(map[MockResource]time.Time)
+				tc.idleResourcePool = make(map[MockResource]*resourceEntry)
 			}
 
 			mockMutex := &MockMutex{}
@@ -237,9 +237,9 @@ func TestNewPool_NumIdle(t *testing.T) {
 			mockMutex.On("Lock")
 			mockMutex.On("Unlock")
 
-			unlock := make(map[MockResource]time.Time)
+			unlock := make(map[MockResource]*resourceEntry)
 			for i := 1; i <= tc.idlePoolCount; i++ {
-				unlock[MockResource{id: i}] = time.Now()
+				unlock[MockResource{id: i}] = &resourceEntry{releasedAt: time.Now()}
 			}
 
 			pool := NewPool[MockResource]{
@@ -247,7 +247,7 @@ func TestNewPool_NumIdle(t *testing.T) {
 				maxIdleTime: maxIdleTime,
 				maxIdleSize: maxIdleSize,
 				mutex:       mockMutex,
-				lock:        make(map[MockResource]time.Time),
+				lock:        make(map[MockResource]*resourceEntry),
 				unlock:      unlock,
 			}
 
@@ -257,6 +257,216 @@ func TestNewPool_NumIdle(t *testing.T) {
 	}
 }
 
+func TestNewPool_Stats(t *testing.T) {
+	testCases := []struct {
+		name          string
+		creator       func(ctx context.Context) (MockResource, error)
+		idle          map[MockResource]*resourceEntry
+		acquireCount  int
+		releaseCount  int
+		expectedStats Stats
+	}{
+		{
+			name:         "with empty pool counts created resources",
+			acquireCount: 2,
+			expectedStats: Stats{
+				Acquires:   2,
+				Created:    2,
+				Evictions:  map[EvictReason]int64{},
+				HookPanics: map[string]int64{},
+				IdleCap:    maxIdleSize,
+				Active:     2,
+				Total:      2,
+			},
+		},
+		{
+			name: "with idle resource counts reuse and expiry",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 10}: {releasedAt: time.Now()},
+				MockResource{id: 11}: {releasedAt: time.Now().Add(-2 * maxIdleTime)},
+			},
+			acquireCount: 1,
+			expectedStats: Stats{
+				Acquires:   1,
+				Reused:     1,
+				Evictions:  map[EvictReason]int64{EvictExpired: 1},
+				HookPanics: map[string]int64{},
+				IdleCap:    maxIdleSize,
+				Active:     1,
+				Total:      1,
+			},
+		},
+		{
+			name:         "with creator func error response counts failures",
+			creator:      getErrorMockCreatorFunc(),
+			acquireCount: 2,
+			expectedStats: Stats{
+				Acquires:       2,
+				CreateFailures: 2,
+				Evictions:      map[EvictReason]int64{},
+				HookPanics:     map[string]int64{},
+				IdleCap:        maxIdleSize,
+			},
+		},
+		{
+			name: "with full idle pool counts capacity evictions",
+			idle: map[MockResource]*resourceEntry{
+				MockResource{id: 5}: {releasedAt: time.Now()},
+				MockResource{id: 6}: {releasedAt: time.Now()},
+				MockResource{id: 7}: {releasedAt: time.Now()},
+			},
+			acquireCount: 4,
+			releaseCount: 4,
+			expectedStats: Stats{
+				Acquires:   4,
+				Reused:     3,
+				Created:    1,
+				Evictions:  map[EvictReason]int64{EvictCapacity: 1},
+				HookPanics: map[string]int64{},
+				IdleCap:    maxIdleSize,
+				Idle:       3,
+				Total:      3,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.creator == nil {
+				tc.creator = getMockCreatorFunc()
+			}
+			if tc.idle == nil {
+				tc.idle = make(map[MockResource]*resourceEntry)
+			}
+
+			mockMutex := &MockMutex{}
+			mockMutex.On("Lock")
+			mockMutex.On("Unlock")
+
+			pool := NewPool[MockResource]{
+				creator:     tc.creator,
+				maxIdleTime: maxIdleTime,
+				maxIdleSize: maxIdleSize,
+				mutex:       mockMutex,
+				lock:        make(map[MockResource]*resourceEntry),
+				unlock:      tc.idle,
+			}
+
+			var acquired []MockResource
+			for i := 0; i < tc.acquireCount; i++ {
+				if resource, err := pool.Acquire(nil); err == nil {
+					acquired = append(acquired, resource)
+				}
+			}
+			for i := 0; i < tc.releaseCount && i < len(acquired); i++ {
+				pool.Release(acquired[i])
+			}
+
+			assert.Equal(t, tc.expectedStats, pool.Stats())
+			mockMutex.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewPool_AcquireWaitsForCapacity(t *testing.T) {
+	testCases := []struct {
+		name             string
+		maxIdleSize      int
+		expectedResource MockResource
+	}{
+		{
+			name:             "with released resource kept idle returns it",
+			maxIdleSize:      maxIdleSize,
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "with released resource dropped creates new resource",
+			maxIdleSize:      -1,
+			expectedResource: MockResource{id: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), tc.maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+			first, _ := pool.Acquire(nil)
+
+			acquired := make(chan MockResource)
+			go func() {
+				resource, _ := pool.Acquire(context.Background())
+				acquired <- resource
+			}()
+
+			time.Sleep(10 * time.Millisecond)
+			pool.Release(first)
+
+			assert.Equal(t, tc.expectedResource, <-acquired)
+		})
+	}
+}
+
+func TestNewPool_Close(t *testing.T) {
+	testCases := []struct {
+		name              string
+		run               func(*NewPool[MockResource])
+		expectedDestroyed []MockResource
+	}{
+		{
+			name: "with idle resource destroys it",
+			run: func(pool *NewPool[MockResource]) {
+				resource, _ := pool.Acquire(nil)
+				pool.Release(resource)
+				pool.Close()
+			},
+			expectedDestroyed: []MockResource{{id: 1}},
+		},
+		{
+			name: "with active resource destroys it on release",
+			run: func(pool *NewPool[MockResource]) {
+				resource, _ := pool.Acquire(nil)
+				pool.Close()
+				pool.Release(resource)
+			},
+			expectedDestroyed: []MockResource{{id: 1}},
+		},
+		{
+			name: "with waiting acquire returns pool closed",
+			run: func(pool *NewPool[MockResource]) {
+				pool.Acquire(nil)
+
+				waitErr := make(chan error)
+				go func() {
+					_, err := pool.Acquire(context.Background())
+					waitErr <- err
+				}()
+				time.Sleep(10 * time.Millisecond)
+				pool.Close()
+
+				assert.ErrorIs(t, <-waitErr, ErrPoolClosed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var destroyed []MockResource
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithMaxActive[MockResource](1),
+				WithDestroyer(func(resource MockResource) error {
+					destroyed = append(destroyed, resource)
+					return nil
+				}),
+			)
+
+			tc.run(pool)
+
+			assert.Equal(t, tc.expectedDestroyed, destroyed)
+			_, err := pool.Acquire(nil)
+			assert.ErrorIs(t, err, ErrPoolClosed)
+		})
+	}
+}
+
 func getMockCreatorFunc() func(context.Context) (MockResource, error) {
 	id := 0
 	return func(ctx context.Context) (MockResource, error) {
@@ -270,3 +480,27 @@ func getErrorMockCreatorFunc() func(context.Context) (MockResource, error) {
 		return *new(MockResource), errors.New("error response")
 	}
 }
+
+func TestNewPool_Invalidate(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(nil)
+
+	assert.NoError(t, pool.Invalidate(resource))
+	assert.ErrorIs(t, pool.Invalidate(resource), ErrNotAcquired)
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictInvalidated])
+}
+
+func TestNewPool_Utilization(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](4))
+	resources, _ := pool.AcquireN(nil, 3)
+	pool.Release(resources[0])
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, 2, pool.NumActive())
+	assert.Equal(t, 3, pool.NumTotal())
+	assert.Equal(t, 4, pool.Cap())
+	stats := pool.Stats()
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 4, stats.Cap)
+}
@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var _ Pool[PoolResource] = &OverflowPool[PoolResource]{}
+
+// OverflowPool acquires from a primary pool and overflows to a secondary one
+// while the primary is exhausted, e.g. warm local connections first, then an
+// emergency burst pool with different limits. Released resources go back to
+// the pool they were acquired from.
+//
+// The primary is tried without waiting, with a nil context, so the values of
+// ctx, such as Tenant or HolderTag, only apply to acquires of the overflow
+// pool. Acquires overflow on ErrPoolExhausted; other primary errors, e.g. a
+// failed creation, are returned.
+type OverflowPool[T comparable] struct {
+	primary  *NewPool[T]
+	overflow *NewPool[T]
+	owners   sync.Map
+	// isOverflowed reports whether an acquire of the primary failing with
+	// the error goes on to the overflow pool
+	isOverflowed func(error) bool
+}
+
+// creates or returns a ready-to-use item from the primary pool, or from the
+// overflow pool if the primary is exhausted, waiting for it until ctx is done
+func (o *OverflowPool[T]) Acquire(ctx context.Context) (T, error) {
+	owner := o.primary
+	resource, err := owner.Acquire(nil)
+	if err != nil && o.isOverflowed(err) {
+		owner = o.overflow
+		resource, err = owner.Acquire(ctx)
+	}
+	if err != nil {
+		return *new(T), err
+	}
+
+	o.owners.Store(resource, owner)
+	return resource, nil
+}
+
+// releases an active resource back to the pool it was acquired from
+func (o *OverflowPool[T]) Release(resource T) {
+	o.TryRelease(resource)
+}
+
+// releases an active resource back to the pool it was acquired from; returns
+// ErrNotAcquired if the resource was not acquired from the pool
+func (o *OverflowPool[T]) TryRelease(resource T) error {
+	owner, isFound := o.owners.LoadAndDelete(resource)
+	if !isFound {
+		return ErrNotAcquired
+	}
+	return owner.(*NewPool[T]).TryRelease(resource)
+}
+
+// destroys an acquired resource instead of returning it to the pool it was
+// acquired from; returns ErrNotAcquired if the resource was not acquired from
+// the pool
+func (o *OverflowPool[T]) Invalidate(resource T) error {
+	owner, isFound := o.owners.LoadAndDelete(resource)
+	if !isFound {
+		return ErrNotAcquired
+	}
+	return owner.(*NewPool[T]).Invalidate(resource)
+}
+
+// returns the number of idle items of both pools
+func (o *OverflowPool[T]) NumIdle() int {
+	return o.primary.NumIdle() + o.overflow.NumIdle()
+}
+
+// returns the stats of both pools combined; the stats of each are available
+// from the pools themselves
+func (o *OverflowPool[T]) Stats() Stats {
+	primary := o.primary.Stats()
+	stats := MergeStats(primary, o.overflow.Stats())
+	stats.Goroutines = primary.Goroutines
+	return stats
+}
+
+// closes both pools and rejects further acquires
+func (o *OverflowPool[T]) Close() {
+	o.primary.Close()
+	o.overflow.Close()
+}
+
+// creates a pool acquiring from primary and overflowing to overflow
+func NewOverflow[T comparable](
+	// primary is the pool acquired from first
+	primary *NewPool[T],
+	// overflow is the pool acquired from while primary is exhausted
+	overflow *NewPool[T],
+) *OverflowPool[T] {
+	return &OverflowPool[T]{primary: primary, overflow: overflow, isOverflowed: isExhausted}
+}
+
+// reports whether an acquire failed because the pool is exhausted
+func isExhausted(err error) bool {
+	return errors.Is(err, ErrPoolExhausted)
+}
@@ -0,0 +1,92 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOverflowPool_Acquire(t *testing.T) {
+	testCases := []struct {
+		name             string
+		primaryCreator   func(context.Context) (MockResource, error)
+		primaryHeld      int
+		expectedResource MockResource
+		expectedError    error
+	}{
+		{
+			name:             "with primary capacity acquires from primary",
+			primaryCreator:   getMockCreatorFunc(),
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "with exhausted primary overflows",
+			primaryCreator:   getMockCreatorFunc(),
+			primaryHeld:      1,
+			expectedResource: MockResource{id: 101},
+		},
+		{
+			name: "with failing primary returns create error",
+			primaryCreator: func(context.Context) (MockResource, error) {
+				return MockResource{}, errors.New("create failed")
+			},
+			expectedError: &CreateError{Err: errors.New("create failed"), Attempt: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			primary := New(tc.primaryCreator, maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+			overflow := New(getOffsetMockCreatorFunc(100), maxIdleSize, maxIdleTime)
+			pool := NewOverflow(primary, overflow)
+			for i := 0; i < tc.primaryHeld; i++ {
+				pool.Acquire(nil)
+			}
+
+			resource, err := pool.Acquire(context.Background())
+
+			assert.Equal(t, tc.expectedResource, resource)
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOverflowPool_ReleaseRoutesToOwner(t *testing.T) {
+	primary := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+	overflow := New(getOffsetMockCreatorFunc(100), maxIdleSize, maxIdleTime)
+	pool := NewOverflow(primary, overflow)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+
+	assert.NoError(t, pool.TryRelease(second))
+	assert.NoError(t, pool.TryRelease(first))
+	assert.ErrorIs(t, pool.TryRelease(first), ErrNotAcquired)
+
+	assert.Equal(t, 1, primary.NumIdle())
+	assert.Equal(t, 1, overflow.NumIdle())
+	assert.Equal(t, 2, pool.Stats().Idle)
+}
+
+func TestOverflowPool_Invalidate(t *testing.T) {
+	primary := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	overflow := New(getOffsetMockCreatorFunc(100), maxIdleSize, maxIdleTime)
+	pool := NewOverflow(primary, overflow)
+	resource, _ := pool.Acquire(nil)
+
+	assert.NoError(t, pool.Invalidate(resource))
+	assert.ErrorIs(t, pool.Invalidate(resource), ErrNotAcquired)
+	assert.Equal(t, int64(1), primary.Stats().Evictions[EvictInvalidated])
+}
+
+func getOffsetMockCreatorFunc(offset int) func(context.Context) (MockResource, error) {
+	id := offset
+	return func(context.Context) (MockResource, error) {
+		id++
+		return MockResource{id: id}, nil
+	}
+}
@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"runtime/debug"
+)
+
+// WithPanicContainment recovers panics of the lifecycle hooks, so a faulty
+// hook can not take down the goroutine releasing or sweeping resources. A
+// recovered panic is logged at LogError with its stack and counted in
+// Stats.HookPanics; a hook which panicked limit times is disabled. A limit of
+// zero or less never disables hooks.
+func WithPanicContainment[T comparable](limit int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.isPanicContained = true
+		n.hookPanicLimit = limit
+	}
+}
+
+// runs the hook called name, recovering its panic in panic containment mode
+func (n *NewPool[T]) callHook(name string, call func()) {
+	if !n.isPanicContained {
+		call()
+		return
+	}
+	if n.disabledHooks[name] {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			n.containPanic(name, r)
+		}
+	}()
+	call()
+}
+
+// runs the hook called name like callHook, for hooks called without the pool
+// mutex; the mutex is only taken to check and record panics
+func (n *NewPool[T]) callHookUnlocked(name string, call func()) {
+	if !n.isPanicContained {
+		call()
+		return
+	}
+	n.mutex.Lock()
+	isDisabled := n.disabledHooks[name]
+	n.mutex.Unlock()
+	if isDisabled {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+			n.containPanic(name, r)
+		}
+	}()
+	call()
+}
+
+// records and logs a recovered hook panic, disabling the hook at the limit
+func (n *NewPool[T]) containPanic(name string, r any) {
+	count := n.stats.recordHookPanic(name)
+	isDisabled := n.hookPanicLimit > 0 && count >= int64(n.hookPanicLimit)
+	if isDisabled {
+		if n.disabledHooks == nil {
+			n.disabledHooks = make(map[string]bool)
+		}
+		n.disabledHooks[name] = true
+	}
+
+	n.log(LogError, "hook panicked",
+		Field{Key: "hook", Value: name},
+		Field{Key: "panic", Value: r},
+		Field{Key: "stack", Value: string(debug.Stack())},
+		Field{Key: "disabled", Value: isDisabled},
+	)
+}
@@ -0,0 +1,71 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_PanicContainment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		limit              int
+		releases           int
+		expectedCalls      int
+		expectedPanics     int64
+		expectedLogEntries int
+	}{
+		{
+			name:               "recovers and logs hook panics",
+			releases:           3,
+			expectedCalls:      3,
+			expectedPanics:     3,
+			expectedLogEntries: 3,
+		},
+		{
+			name:               "disables hook at panic limit",
+			limit:              2,
+			releases:           3,
+			expectedCalls:      2,
+			expectedPanics:     2,
+			expectedLogEntries: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := &MockLogger{}
+			calls := 0
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithLogger[MockResource](logger),
+				WithPanicContainment[MockResource](tc.limit),
+				WithHooks(Hooks[MockResource]{
+					OnRelease: func(MockResource, HookInfo) {
+						calls++
+						panic("hook failure")
+					},
+				}),
+			)
+
+			for i := 0; i < tc.releases; i++ {
+				resource, _ := pool.Acquire(nil)
+				assert.NoError(t, pool.TryRelease(resource))
+			}
+
+			assert.Equal(t, tc.expectedCalls, calls)
+			assert.Equal(t, 1, pool.NumIdle())
+			assert.Equal(t, tc.expectedPanics, pool.Stats().HookPanics["OnRelease"])
+			assert.Len(t, logger.entries, tc.expectedLogEntries)
+			assert.Equal(t, logEntry{level: LogError, msg: "hook panicked"}, logger.entries[0])
+		})
+	}
+}
+
+func TestNewPool_WithoutPanicContainment(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithHooks(Hooks[MockResource]{
+			OnAcquire: func(MockResource, HookInfo) { panic("hook failure") },
+		}),
+	)
+
+	assert.PanicsWithValue(t, "hook failure", func() { pool.Acquire(nil) })
+}
@@ -0,0 +1,46 @@
+package pool
+
+import (
+	"context"
+)
+
+// WithFailFastOnPause makes Acquire return ErrPoolPaused right away while the
+// pool is paused, instead of waiting for Resume
+func WithFailFastOnPause[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isPauseFailFast = true
+	}
+}
+
+// stops handing out resources until Resume, e.g. during a backend failover.
+// Idle resources are kept, and acquired ones can still be released. While
+// paused, Acquire waits for Resume until ctx is done; with a nil ctx, or with
+// WithFailFastOnPause, it returns ErrPoolPaused instead of waiting.
+func (n *NewPool[T]) Pause() {
+	n.isPaused.Store(true)
+}
+
+// resumes handing out resources after Pause; waiting acquires proceed
+func (n *NewPool[T]) Resume() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.isPaused.Store(false)
+	n.notifyWaiters()
+}
+
+// waits while the pool is paused; the pool mutex must be held
+func (n *NewPool[T]) waitResume(ctx context.Context, wait *acquireWait) error {
+	for n.isPaused.Load() {
+		if n.isPauseFailFast || ctx == nil {
+			return ErrPoolPaused
+		}
+		if err := n.waitAcquire(ctx, wait); err != nil {
+			return err
+		}
+		if n.isClosed {
+			return ErrPoolClosed
+		}
+	}
+	return nil
+}
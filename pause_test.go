@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWhilePaused(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []Option[MockResource]
+		ctx           context.Context
+		expectedError error
+	}{
+		{
+			name:          "with nil ctx fails fast",
+			expectedError: ErrPoolPaused,
+		},
+		{
+			name:          "with fail fast option fails fast",
+			options:       []Option[MockResource]{WithFailFastOnPause[MockResource]()},
+			ctx:           context.Background(),
+			expectedError: ErrPoolPaused,
+		},
+		{
+			name: "with local cache fails fast",
+			options: []Option[MockResource]{
+				WithLocalCache[MockResource](),
+				WithFailFastOnPause[MockResource](),
+			},
+			expectedError: ErrPoolPaused,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			pool.Pause()
+
+			_, err := pool.Acquire(tc.ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNewPool_Resume(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(nil)
+	pool.Pause()
+	pool.Release(resource)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, acquired, 0)
+	assert.Equal(t, 1, pool.NumIdle())
+
+	pool.Resume()
+	assert.Equal(t, resource, <-acquired)
+}
+
+func TestNewPool_CloseWhilePaused(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	pool.Pause()
+
+	acquired := make(chan error)
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		acquired <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pool.Close()
+	assert.ErrorIs(t, <-acquired, ErrPoolClosed)
+}
@@ -0,0 +1,154 @@
+// Package poolotel records resource pool activity with OpenTelemetry traces
+// and metrics.
+package poolotel
+
+import (
+	"context"
+	pool "example/ptran"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+const instrumentationName = "example/ptran/poolotel"
+
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// Instrumentation holds the tracer and instruments shared by the instrumented
+// acquires and creations of one named pool
+type Instrumentation struct {
+	attributes      []attribute.KeyValue
+	tracer          trace.Tracer
+	acquires        metric.Int64Counter
+	acquireDuration metric.Float64Histogram
+	creates         metric.Int64Counter
+	createDuration  metric.Float64Histogram
+}
+
+// infoAcquirer is a pool reporting how its acquires got their resource, such
+// as a *pool.NewPool
+type infoAcquirer[T any] interface {
+	AcquireWithInfo(ctx context.Context) (T, pool.AcquireInfo, error)
+}
+
+type instrumentedPool[T any] struct {
+	pool.Pool[T]
+	instrumentation *Instrumentation
+}
+
+// records the acquire as a pool.acquire span and in the acquire metrics; the
+// acquires of pools reporting their AcquireInfo are attributed with their
+// pool.acquire.outcome, reused, created or waited, to split their latencies
+func (p *instrumentedPool[T]) Acquire(ctx context.Context) (T, error) {
+	ctx, span := p.instrumentation.tracer.Start(ctx, "pool.acquire", trace.WithAttributes(p.instrumentation.attributes...))
+	defer span.End()
+
+	start := time.Now()
+	acquirer, isInfoAcquirer := p.Pool.(infoAcquirer[T])
+	if !isInfoAcquirer {
+		resource, err := p.Pool.Acquire(ctx)
+		p.instrumentation.record(ctx, span, p.instrumentation.acquires, p.instrumentation.acquireDuration, start, err)
+		return resource, err
+	}
+
+	resource, info, err := acquirer.AcquireWithInfo(ctx)
+	var attributes []attribute.KeyValue
+	if err == nil {
+		attributes = append(attributes, attribute.String("pool.acquire.outcome", string(info.Outcome())))
+		span.SetAttributes(attributes...)
+	}
+	p.instrumentation.record(ctx, span, p.instrumentation.acquires, p.instrumentation.acquireDuration, start, err, attributes...)
+
+	return resource, err
+}
+
+// wraps a pool so every Acquire is traced and measured
+func Wrap[T any](instrumentation *Instrumentation, p pool.Pool[T]) pool.Pool[T] {
+	return &instrumentedPool[T]{
+		Pool:            p,
+		instrumentation: instrumentation,
+	}
+}
+
+// returns a middleware wrapping pools like Wrap, for pool.Chain
+func Middleware[T any](instrumentation *Instrumentation) pool.Middleware[T] {
+	return func(p pool.Pool[T]) pool.Pool[T] {
+		return Wrap(instrumentation, p)
+	}
+}
+
+// wraps a creator so every creation is traced and measured; pass the result to
+// pool.New
+func WrapCreator[T any](instrumentation *Instrumentation, creator func(context.Context) (T, error)) func(context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		ctx, span := instrumentation.tracer.Start(ctx, "pool.create", trace.WithAttributes(instrumentation.attributes...))
+		defer span.End()
+
+		start := time.Now()
+		resource, err := creator(ctx)
+		instrumentation.record(ctx, span, instrumentation.creates, instrumentation.createDuration, start, err)
+
+		return resource, err
+	}
+}
+
+func (i *Instrumentation) record(
+	ctx context.Context,
+	span trace.Span,
+	counter metric.Int64Counter,
+	duration metric.Float64Histogram,
+	start time.Time,
+	err error,
+	extra ...attribute.KeyValue,
+) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeError
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	attributes := append([]attribute.KeyValue{attribute.String("pool.outcome", outcome)}, i.attributes...)
+	attributes = append(attributes, extra...)
+	counter.Add(ctx, 1, metric.WithAttributes(attributes...))
+	duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+}
+
+// creates the instrumentation of the pool called name, using the given
+// providers. The buckets of the duration histograms are those of the meter
+// provider, e.g. set for pool.acquire.duration by a view with an explicit
+// bucket histogram aggregation.
+func New(name string, tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*Instrumentation, error) {
+	meter := meterProvider.Meter(instrumentationName)
+
+	acquires, err := meter.Int64Counter("pool.acquires", metric.WithDescription("Number of Acquire calls."))
+	if err != nil {
+		return nil, err
+	}
+	acquireDuration, err := meter.Float64Histogram("pool.acquire.duration", metric.WithDescription("Duration of Acquire calls."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	creates, err := meter.Int64Counter("pool.creates", metric.WithDescription("Number of resource creations."))
+	if err != nil {
+		return nil, err
+	}
+	createDuration, err := meter.Float64Histogram("pool.create.duration", metric.WithDescription("Duration of resource creations."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		attributes:      []attribute.KeyValue{attribute.String("pool.name", name)},
+		tracer:          tracerProvider.Tracer(instrumentationName),
+		acquires:        acquires,
+		acquireDuration: acquireDuration,
+		creates:         creates,
+		createDuration:  createDuration,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package poolotel
+
+import (
+	"context"
+	"errors"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+	"time"
+)
+
+type MockResource struct {
+	id int
+}
+
+func TestInstrumentation(t *testing.T) {
+	testCases := []struct {
+		name             string
+		creatorErr       error
+		expectedSpans    []string
+		expectedOutcome  string
+		expectedAcquires int64
+		expectedAcquire  string
+	}{
+		{
+			name:             "with successful creation records acquire and create spans",
+			expectedSpans:    []string{"pool.create", "pool.acquire"},
+			expectedOutcome:  outcomeSuccess,
+			expectedAcquires: 1,
+			expectedAcquire:  string(pool.AcquireCreated),
+		},
+		{
+			name:             "with creator error records error outcome",
+			creatorErr:       errors.New("error response"),
+			expectedSpans:    []string{"pool.create", "pool.acquire"},
+			expectedOutcome:  outcomeError,
+			expectedAcquires: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := sdkmetric.NewManualReader()
+			meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			instrumentation, err := New("db", tracerProvider, meterProvider)
+			require.NoError(t, err)
+
+			creator := WrapCreator(instrumentation, func(ctx context.Context) (MockResource, error) {
+				return MockResource{id: 1}, tc.creatorErr
+			})
+			p := Wrap[MockResource](instrumentation, pool.New(creator, 1, time.Second))
+
+			_, err = p.Acquire(context.Background())
+			assert.ErrorIs(t, err, tc.creatorErr)
+
+			var spanNames []string
+			for _, span := range spanRecorder.Ended() {
+				spanNames = append(spanNames, span.Name())
+				assert.Contains(t, span.Attributes(), attribute.String("pool.name", "db"))
+			}
+			assert.Equal(t, tc.expectedSpans, spanNames)
+
+			var metrics metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &metrics))
+			acquires := findSum(t, metrics, "pool.acquires")
+			require.Len(t, acquires.DataPoints, 1)
+			assert.Equal(t, tc.expectedAcquires, acquires.DataPoints[0].Value)
+			outcome, _ := acquires.DataPoints[0].Attributes.Value("pool.outcome")
+			assert.Equal(t, tc.expectedOutcome, outcome.AsString())
+			acquire, _ := acquires.DataPoints[0].Attributes.Value("pool.acquire.outcome")
+			assert.Equal(t, tc.expectedAcquire, acquire.AsString())
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	meterProvider := sdkmetric.NewMeterProvider()
+	instrumentation, err := New("db", tracerProvider, meterProvider)
+	require.NoError(t, err)
+
+	p := pool.Chain[MockResource](pool.New(func(ctx context.Context) (MockResource, error) {
+		return MockResource{id: 1}, nil
+	}, 1, time.Second), Middleware[MockResource](instrumentation))
+	_, err = p.Acquire(context.Background())
+
+	assert.NoError(t, err)
+	require.Len(t, spanRecorder.Ended(), 1)
+	assert.Equal(t, "pool.acquire", spanRecorder.Ended()[0].Name())
+}
+
+func findSum(t *testing.T, metrics metricdata.ResourceMetrics, name string) metricdata.Sum[int64] {
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name == name {
+				return m.Data.(metricdata.Sum[int64])
+			}
+		}
+	}
+
+	t.Fatalf("metric %s not found", name)
+	return metricdata.Sum[int64]{}
+}
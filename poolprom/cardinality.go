@@ -0,0 +1,109 @@
+package poolprom
+
+import (
+	pool "example/ptran"
+	"sort"
+	"time"
+)
+
+// OtherPool is the pool label of the pools reported together beyond the
+// WithMaxPools cap
+const OtherPool = "other"
+
+// Option configures optional collector behavior
+type Option func(*Collector)
+
+// WithMaxPools caps the number of pool label values, e.g. when a pool is
+// added per tenant. Pools beyond the cap are reported summed up under the
+// OtherPool label; pools keep their label from the first collect they are
+// labelled in until they are removed or, with WithStalePoolAfter, go stale.
+// Since pools move in and out of OtherPool, its counters may decrease.
+func WithMaxPools(limit int) Option {
+	return func(c *Collector) {
+		c.maxPools = limit
+	}
+}
+
+// WithStalePoolAfter lets a pool beyond the WithMaxPools cap take the label of
+// a pool without acquires for d, which moves to OtherPool. Without it,
+// labelled pools keep their label until they are removed.
+func WithStalePoolAfter(d time.Duration) Option {
+	return func(c *Collector) {
+		c.staleAfter = d
+	}
+}
+
+// labelState is the activity of a pool with its own label
+type labelState struct {
+	acquires     int64
+	lastActiveAt time.Time
+}
+
+// returns the pool label of each snapshot name under the cardinality cap; the
+// collector mutex must be held
+func (c *Collector) assignLabels(snapshots map[string]pool.Stats) map[string]string {
+	labels := make(map[string]string, len(snapshots))
+	if c.maxPools <= 0 {
+		for name := range snapshots {
+			labels[name] = name
+		}
+		return labels
+	}
+
+	now := c.now()
+	for name, state := range c.labelled {
+		stats, isFound := snapshots[name]
+		if !isFound {
+			delete(c.labelled, name)
+			continue
+		}
+		if stats.Acquires != state.acquires {
+			state.acquires = stats.Acquires
+			state.lastActiveAt = now
+		}
+		labels[name] = name
+	}
+
+	var waiting []string
+	for name := range snapshots {
+		if _, isLabelled := c.labelled[name]; !isLabelled {
+			waiting = append(waiting, name)
+		}
+	}
+	sort.Strings(waiting)
+
+	for _, name := range waiting {
+		if len(c.labelled) >= c.maxPools {
+			stale, isFound := c.getStalest(now)
+			if !isFound {
+				labels[name] = OtherPool
+				continue
+			}
+			delete(c.labelled, stale)
+			labels[stale] = OtherPool
+		}
+		c.labelled[name] = &labelState{acquires: snapshots[name].Acquires, lastActiveAt: now}
+		labels[name] = name
+	}
+	return labels
+}
+
+// returns the labelled pool inactive for the longest time, if it is stale
+func (c *Collector) getStalest(now time.Time) (string, bool) {
+	if c.staleAfter <= 0 {
+		return "", false
+	}
+
+	var stalest string
+	var stalestAt time.Time
+	for name, state := range c.labelled {
+		if stalest == "" || state.lastActiveAt.Before(stalestAt) {
+			stalest = name
+			stalestAt = state.lastActiveAt
+		}
+	}
+	if stalest == "" || now.Sub(stalestAt) < c.staleAfter {
+		return "", false
+	}
+	return stalest, true
+}
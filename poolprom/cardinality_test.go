@@ -0,0 +1,122 @@
+package poolprom
+
+import (
+	pool "example/ptran"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector_CollectWithMaxPools(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  []Option
+		sources  map[string]StatsSource
+		late     map[string]StatsSource
+		advance  time.Duration
+		expected string
+	}{
+		{
+			name:    "beyond cap sums pools under other",
+			options: []Option{WithMaxPools(1)},
+			sources: map[string]StatsSource{
+				"a": staticSource{pool.Stats{Idle: 1}},
+				"b": staticSource{pool.Stats{Idle: 2}},
+				"c": staticSource{pool.Stats{Idle: 3}},
+			},
+			expected: `
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="a"} 1
+app_pool_idle_resources{pool="other"} 5
+`,
+		},
+		{
+			name:    "without stale timeout keeps first labelled pools",
+			options: []Option{WithMaxPools(1)},
+			sources: map[string]StatsSource{"b": staticSource{pool.Stats{Idle: 1}}},
+			late:    map[string]StatsSource{"a": staticSource{pool.Stats{Idle: 2}}},
+			advance: time.Hour,
+			expected: `
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="b"} 1
+app_pool_idle_resources{pool="other"} 2
+`,
+		},
+		{
+			name:    "with stale labelled pool hands its label over",
+			options: []Option{WithMaxPools(1), WithStalePoolAfter(time.Minute)},
+			sources: map[string]StatsSource{"b": staticSource{pool.Stats{Idle: 1}}},
+			late:    map[string]StatsSource{"a": staticSource{pool.Stats{Idle: 2}}},
+			advance: time.Minute,
+			expected: `
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="a"} 2
+app_pool_idle_resources{pool="other"} 1
+`,
+		},
+		{
+			name:    "with active labelled pool keeps its label",
+			options: []Option{WithMaxPools(1), WithStalePoolAfter(time.Minute)},
+			sources: map[string]StatsSource{"b": &countingSource{}},
+			late:    map[string]StatsSource{"a": staticSource{pool.Stats{Idle: 2}}},
+			advance: time.Minute,
+			expected: `
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="b"} 0
+app_pool_idle_resources{pool="other"} 2
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			collector := NewCollector("app", tc.options...)
+			collector.now = func() time.Time { return now }
+			for name, source := range tc.sources {
+				collector.Add(name, source)
+			}
+			if len(tc.late) > 0 {
+				testutil.CollectAndCount(collector)
+				now = now.Add(tc.advance)
+				for name, source := range tc.late {
+					collector.Add(name, source)
+				}
+			}
+
+			err := testutil.CollectAndCompare(collector, strings.NewReader(tc.expected), "app_pool_idle_resources")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// countingSource reports one more acquire on each snapshot
+type countingSource struct {
+	acquires int64
+}
+
+func (s *countingSource) Stats() pool.Stats {
+	s.acquires++
+	return pool.Stats{Acquires: s.acquires}
+}
+
+func TestCollector_CollectWithMaxPoolsKeepsUnlimitedCap(t *testing.T) {
+	collector := NewCollector("app", WithMaxPools(1))
+	collector.Add("a", staticSource{pool.Stats{Cap: 1}})
+	collector.Add("b", staticSource{pool.Stats{Cap: 2}})
+	collector.Add("c", staticSource{pool.Stats{}})
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP app_pool_max_active_resources Maximum number of acquired resources; zero means no limit.
+# TYPE app_pool_max_active_resources gauge
+app_pool_max_active_resources{pool="a"} 1
+app_pool_max_active_resources{pool="other"} 0
+`), "app_pool_max_active_resources")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,173 @@
+// Package poolprom exports resource pool statistics as Prometheus metrics.
+package poolprom
+
+import (
+	pool "example/ptran"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+var _ prometheus.Collector = &Collector{}
+
+// StatsSource is implemented by pools that can report a Stats snapshot
+type StatsSource interface {
+	Stats() pool.Stats
+}
+
+// Collector is a prometheus.Collector reporting the stats of registered pools,
+// each pool labelled by the name it was added with
+type Collector struct {
+	mutex sync.Mutex
+	pools map[string]StatsSource
+
+	maxPools   int
+	staleAfter time.Duration
+	labelled   map[string]*labelState
+	now        func() time.Time
+
+	idle           *prometheus.Desc
+	active         *prometheus.Desc
+	total          *prometheus.Desc
+	maxActive      *prometheus.Desc
+	acquires       *prometheus.Desc
+	reused         *prometheus.Desc
+	created        *prometheus.Desc
+	createFailures *prometheus.Desc
+	waits          *prometheus.Desc
+	waitDuration   *prometheus.Desc
+	maxWait        *prometheus.Desc
+	evictions      *prometheus.Desc
+	acquireLatency *prometheus.Desc
+}
+
+// adds or replaces the pool reported under name
+func (c *Collector) Add(name string, source StatsSource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pools[name] = source
+}
+
+// stops reporting the pool registered under name
+func (c *Collector) Remove(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.pools, name)
+}
+
+// sends the descriptors of all exported metrics
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.idle
+	ch <- c.active
+	ch <- c.total
+	ch <- c.maxActive
+	ch <- c.acquires
+	ch <- c.reused
+	ch <- c.created
+	ch <- c.createFailures
+	ch <- c.waits
+	ch <- c.waitDuration
+	ch <- c.maxWait
+	ch <- c.evictions
+	ch <- c.acquireLatency
+}
+
+// snapshots every registered pool and sends its metrics
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	sources := make(map[string]StatsSource, len(c.pools))
+	for name, source := range c.pools {
+		sources[name] = source
+	}
+	c.mutex.Unlock()
+
+	snapshots := make(map[string]pool.Stats, len(sources))
+	for name, source := range sources {
+		snapshots[name] = source.Stats()
+	}
+
+	c.mutex.Lock()
+	labels := c.assignLabels(snapshots)
+	c.mutex.Unlock()
+
+	grouped := make(map[string][]pool.Stats, len(snapshots))
+	for name, stats := range snapshots {
+		grouped[labels[name]] = append(grouped[labels[name]], stats)
+	}
+	labelled := make(map[string]pool.Stats, len(grouped))
+	for label, stats := range grouped {
+		labelled[label] = pool.MergeStats(stats...)
+	}
+
+	for name, stats := range labelled {
+		ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle), name)
+		ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(stats.Active), name)
+		ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stats.Total), name)
+		ch <- prometheus.MustNewConstMetric(c.maxActive, prometheus.GaugeValue, float64(stats.Cap), name)
+		ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stats.Acquires), name)
+		ch <- prometheus.MustNewConstMetric(c.reused, prometheus.CounterValue, float64(stats.Reused), name)
+		ch <- prometheus.MustNewConstMetric(c.created, prometheus.CounterValue, float64(stats.Created), name)
+		ch <- prometheus.MustNewConstMetric(c.createFailures, prometheus.CounterValue, float64(stats.CreateFailures), name)
+		ch <- prometheus.MustNewConstMetric(c.waits, prometheus.CounterValue, float64(stats.Waits), name)
+		ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds(), name)
+		ch <- prometheus.MustNewConstMetric(c.maxWait, prometheus.GaugeValue, stats.MaxWait.Seconds(), name)
+
+		for reason, count := range stats.Evictions {
+			ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(count), name, string(reason))
+		}
+		for outcome, histogram := range stats.AcquireLatency {
+			ch <- prometheus.MustNewConstHistogram(
+				c.acquireLatency, uint64(histogram.Count), histogram.Sum.Seconds(), getBuckets(histogram), name, string(outcome),
+			)
+		}
+	}
+}
+
+// returns the cumulative counts of the histogram buckets by upper bound in
+// seconds, as Prometheus expects them
+func getBuckets(histogram pool.LatencyHistogram) map[float64]uint64 {
+	buckets := make(map[float64]uint64, len(histogram.Bounds))
+	var count int64
+	for i, bound := range histogram.Bounds {
+		count += histogram.Counts[i]
+		buckets[bound.Seconds()] = uint64(count)
+	}
+	return buckets
+}
+
+// creates a collector whose metric names are prefixed with namespace
+func NewCollector(namespace string, options ...Option) *Collector {
+	newDesc := func(name string, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", name),
+			help,
+			append([]string{"pool"}, labels...),
+			nil,
+		)
+	}
+
+	collector := &Collector{
+		pools:          make(map[string]StatsSource),
+		labelled:       make(map[string]*labelState),
+		now:            time.Now,
+		idle:           newDesc("idle_resources", "Number of idle resources in the pool."),
+		active:         newDesc("active_resources", "Number of acquired resources."),
+		total:          newDesc("total_resources", "Number of idle and acquired resources."),
+		maxActive:      newDesc("max_active_resources", "Maximum number of acquired resources; zero means no limit."),
+		acquires:       newDesc("acquires_total", "Total number of Acquire calls."),
+		reused:         newDesc("reused_total", "Total number of acquires served from the idle pool."),
+		created:        newDesc("created_total", "Total number of resources created."),
+		createFailures: newDesc("create_errors_total", "Total number of failed resource creations."),
+		waits:          newDesc("waits_total", "Total number of acquires which waited for a resource."),
+		waitDuration:   newDesc("wait_seconds_total", "Total time acquires spent waiting for a resource."),
+		maxWait:        newDesc("max_wait_seconds", "Longest time an acquire spent waiting for a resource."),
+		evictions:      newDesc("evictions_total", "Total number of resources dropped by the pool.", "reason"),
+		acquireLatency: newDesc("acquire_latency_seconds", "Latency of successful acquires, by outcome; recorded with pool.WithAcquireLatency.", "outcome"),
+	}
+	for _, option := range options {
+		option(collector)
+	}
+	return collector
+}
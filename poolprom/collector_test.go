@@ -0,0 +1,149 @@
+package poolprom
+
+import (
+	pool "example/ptran"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type staticSource struct {
+	stats pool.Stats
+}
+
+func (s staticSource) Stats() pool.Stats {
+	return s.stats
+}
+
+func TestCollector_Collect(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sources     map[string]StatsSource
+		removed     []string
+		expected    string
+		metricNames []string
+	}{
+		{
+			name:     "with no pools exports nothing",
+			sources:  map[string]StatsSource{},
+			expected: "",
+		},
+		{
+			name: "with registered pool exports labelled metrics",
+			sources: map[string]StatsSource{
+				"db": staticSource{pool.Stats{
+					Acquires:       5,
+					Reused:         3,
+					Created:        2,
+					CreateFailures: 1,
+					Evictions:      map[pool.EvictReason]int64{pool.EvictExpired: 4},
+					Idle:           1,
+					Active:         2,
+					Total:          3,
+					Cap:            4,
+					Waits:          2,
+					WaitDuration:   3 * time.Second,
+					MaxWait:        2 * time.Second,
+				}},
+			},
+			expected: `
+# HELP app_pool_acquires_total Total number of Acquire calls.
+# TYPE app_pool_acquires_total counter
+app_pool_acquires_total{pool="db"} 5
+# HELP app_pool_active_resources Number of acquired resources.
+# TYPE app_pool_active_resources gauge
+app_pool_active_resources{pool="db"} 2
+# HELP app_pool_create_errors_total Total number of failed resource creations.
+# TYPE app_pool_create_errors_total counter
+app_pool_create_errors_total{pool="db"} 1
+# HELP app_pool_created_total Total number of resources created.
+# TYPE app_pool_created_total counter
+app_pool_created_total{pool="db"} 2
+# HELP app_pool_evictions_total Total number of resources dropped by the pool.
+# TYPE app_pool_evictions_total counter
+app_pool_evictions_total{pool="db",reason="expired"} 4
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="db"} 1
+# HELP app_pool_max_wait_seconds Longest time an acquire spent waiting for a resource.
+# TYPE app_pool_max_wait_seconds gauge
+app_pool_max_wait_seconds{pool="db"} 2
+# HELP app_pool_max_active_resources Maximum number of acquired resources; zero means no limit.
+# TYPE app_pool_max_active_resources gauge
+app_pool_max_active_resources{pool="db"} 4
+# HELP app_pool_reused_total Total number of acquires served from the idle pool.
+# TYPE app_pool_reused_total counter
+app_pool_reused_total{pool="db"} 3
+# HELP app_pool_total_resources Number of idle and acquired resources.
+# TYPE app_pool_total_resources gauge
+app_pool_total_resources{pool="db"} 3
+# HELP app_pool_wait_seconds_total Total time acquires spent waiting for a resource.
+# TYPE app_pool_wait_seconds_total counter
+app_pool_wait_seconds_total{pool="db"} 3
+# HELP app_pool_waits_total Total number of acquires which waited for a resource.
+# TYPE app_pool_waits_total counter
+app_pool_waits_total{pool="db"} 2
+`,
+		},
+		{
+			name: "with acquire latency exports histograms",
+			sources: map[string]StatsSource{
+				"db": staticSource{pool.Stats{
+					AcquireLatency: map[pool.AcquireOutcome]pool.LatencyHistogram{
+						pool.AcquireWaited: {
+							Bounds: []time.Duration{100 * time.Millisecond, time.Second},
+							Counts: []int64{1, 2, 1},
+							Count:  4,
+							Sum:    3 * time.Second,
+						},
+					},
+				}},
+			},
+			expected: `
+# HELP app_pool_acquire_latency_seconds Latency of successful acquires, by outcome; recorded with pool.WithAcquireLatency.
+# TYPE app_pool_acquire_latency_seconds histogram
+app_pool_acquire_latency_seconds_bucket{outcome="waited",pool="db",le="0.1"} 1
+app_pool_acquire_latency_seconds_bucket{outcome="waited",pool="db",le="1"} 3
+app_pool_acquire_latency_seconds_bucket{outcome="waited",pool="db",le="+Inf"} 4
+app_pool_acquire_latency_seconds_sum{outcome="waited",pool="db"} 3
+app_pool_acquire_latency_seconds_count{outcome="waited",pool="db"} 4
+`,
+			metricNames: []string{"app_pool_acquire_latency_seconds"},
+		},
+		{
+			name: "with removed pool stops exporting it",
+			sources: map[string]StatsSource{
+				"db":    staticSource{pool.Stats{Idle: 1}},
+				"cache": staticSource{pool.Stats{Idle: 2}},
+			},
+			removed: []string{"db"},
+			expected: `
+# HELP app_pool_idle_resources Number of idle resources in the pool.
+# TYPE app_pool_idle_resources gauge
+app_pool_idle_resources{pool="cache"} 2
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collector := NewCollector("app")
+			for name, source := range tc.sources {
+				collector.Add(name, source)
+			}
+			for _, name := range tc.removed {
+				collector.Remove(name)
+			}
+
+			metricNames := tc.metricNames
+			if len(tc.removed) > 0 {
+				metricNames = []string{"app_pool_idle_resources"}
+			}
+
+			err := testutil.CollectAndCompare(collector, strings.NewReader(tc.expected), metricNames...)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package pooltest
+
+import (
+	pool "example/ptran"
+	"sync"
+	"time"
+)
+
+var _ pool.Clock = &Clock{}
+
+// Clock is a pool.Clock which only moves when told to, so expiry, reaping
+// and other time-dependent pool behavior can be tested without sleeping:
+// pass it to pool.WithClock, then Advance it past maxIdleTime. Timers fire
+// when the clock reaches their deadline; AfterFunc functions run in their own
+// goroutine, like with time.AfterFunc. It is safe for concurrent use.
+type Clock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*timer
+}
+
+// timer is a timer of a Clock
+type timer struct {
+	clock    *Clock
+	c        chan time.Time
+	f        func()
+	deadline time.Time
+	isDone   bool
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *timer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	isActive := !t.isDone
+	t.isDone = true
+	return isActive
+}
+
+// returns the current time of the clock
+func (c *Clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// returns a timer firing once the clock advanced by d
+func (c *Clock) NewTimer(d time.Duration) pool.Timer {
+	return c.addTimer(&timer{clock: c, c: make(chan time.Time, 1), deadline: c.Now().Add(d)})
+}
+
+// calls f in its own goroutine once the clock advanced by d
+func (c *Clock) AfterFunc(d time.Duration, f func()) pool.Timer {
+	return c.addTimer(&timer{clock: c, f: f, deadline: c.Now().Add(d)})
+}
+
+// moves the clock forward by d, firing the timers which are due
+func (c *Clock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	c.fire()
+}
+
+// sets the clock to now, firing the timers which are due; moving the clock
+// backwards fires nothing
+func (c *Clock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = now
+	c.fire()
+}
+
+// returns the number of timers which did not fire and were not stopped, e.g.
+// to wait for the pool to arm one before advancing the clock
+func (c *Clock) PendingTimers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	pending := 0
+	for _, t := range c.timers {
+		if !t.isDone {
+			pending++
+		}
+	}
+	return pending
+}
+
+func (c *Clock) addTimer(t *timer) *timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.timers = append(c.timers, t)
+	c.fire()
+	return t
+}
+
+// fires the due timers and forgets the done ones; the clock mutex must be held
+func (c *Clock) fire() {
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if !t.isDone && !t.deadline.After(c.now) {
+			t.isDone = true
+			if t.f != nil {
+				go t.f()
+			} else {
+				t.c <- c.now
+			}
+		}
+		if !t.isDone {
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+}
+
+// creates a clock set to now
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
@@ -0,0 +1,75 @@
+package pooltest
+
+import (
+	"context"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestClock_Expiry(t *testing.T) {
+	testCases := []struct {
+		name            string
+		move            func(*Clock)
+		expectedCreated int64
+		expectedEvicted int64
+	}{
+		{
+			name:            "before max idle time reuses idle resource",
+			move:            func(c *Clock) { c.Advance(time.Second) },
+			expectedCreated: 1,
+		},
+		{
+			name:            "after max idle time evicts idle resource",
+			move:            func(c *Clock) { c.Advance(time.Minute) },
+			expectedCreated: 2,
+			expectedEvicted: 1,
+		},
+		{
+			name:            "set past max idle time evicts idle resource",
+			move:            func(c *Clock) { c.Set(time.Unix(0, 0).Add(time.Hour)) },
+			expectedCreated: 2,
+			expectedEvicted: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewClock(time.Unix(0, 0))
+			id := 0
+			creator := func(context.Context) (int, error) {
+				id++
+				return id, nil
+			}
+			p := pool.New(creator, 1, 5*time.Second, pool.WithClock[int](clock))
+			resource, _ := p.Acquire(nil)
+			p.Release(resource)
+
+			tc.move(clock)
+			p.Acquire(nil)
+
+			stats := p.Stats()
+			assert.Equal(t, tc.expectedCreated, stats.Created)
+			assert.Equal(t, tc.expectedEvicted, stats.Evictions[pool.EvictExpired])
+		})
+	}
+}
+
+func TestClock_Timers(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	fired := make(chan struct{})
+	timer := clock.NewTimer(time.Second)
+	stopped := clock.NewTimer(time.Second)
+	clock.AfterFunc(2*time.Second, func() { close(fired) })
+	assert.True(t, stopped.Stop())
+	assert.Equal(t, 2, clock.PendingTimers())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(1, 0), <-timer.C())
+	assert.False(t, timer.Stop())
+
+	clock.Advance(time.Second)
+	<-fired
+	assert.Equal(t, 0, clock.PendingTimers())
+}
@@ -0,0 +1,159 @@
+// Package pooltest provides a scriptable fake of pool.Pool, so code using a
+// pool can be unit tested without real resources or a hand-written mock.
+package pooltest
+
+import (
+	"context"
+	pool "example/ptran"
+	"sync"
+)
+
+var _ pool.Pool[int] = &Pool[int]{}
+
+// Call is a call recorded by a fake Pool
+type Call[T any] struct {
+	// Method is the name of the called method, e.g. "Acquire"
+	Method string
+	// Resource is the acquired or released resource; zero for a failed
+	// Acquire and for NumIdle
+	Resource T
+	// Err is the error returned by Acquire
+	Err error
+}
+
+// result is a scripted result of Acquire
+type result[T any] struct {
+	resource T
+	err      error
+}
+
+// Pool is a fake pool.Pool handing out scripted resources and errors in the
+// order they were queued, and recording the calls made to it. Once the queue
+// is empty, Acquire calls the creator set with WithCreator, or returns
+// pool.ErrPoolExhausted. It is safe for concurrent use.
+type Pool[T any] struct {
+	mutex    sync.Mutex
+	results  []result[T]
+	creator  func(context.Context) (T, error)
+	calls    []Call[T]
+	acquired int
+	released []T
+	numIdle  int
+}
+
+// Option configures an optional fake pool behavior
+type Option[T any] func(*Pool[T])
+
+// WithCreator makes Acquire call creator once the queue is empty
+func WithCreator[T any](creator func(context.Context) (T, error)) Option[T] {
+	return func(p *Pool[T]) {
+		p.creator = creator
+	}
+}
+
+// queues resources to be returned by the next acquires
+func (p *Pool[T]) Queue(resources ...T) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, resource := range resources {
+		p.results = append(p.results, result[T]{resource: resource})
+	}
+}
+
+// queues an error to be returned by the next acquire left without a queued
+// resource
+func (p *Pool[T]) QueueError(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.results = append(p.results, result[T]{err: err})
+}
+
+// sets the number of idle resources reported by NumIdle
+func (p *Pool[T]) SetNumIdle(numIdle int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.numIdle = numIdle
+}
+
+// returns the next queued resource or error; returns the error of ctx if it
+// is already done, without taking from the queue
+func (p *Pool[T]) Acquire(ctx context.Context) (T, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var next result[T]
+	switch {
+	case ctx != nil && ctx.Err() != nil:
+		next.err = ctx.Err()
+	case len(p.results) > 0:
+		next = p.results[0]
+		p.results = p.results[1:]
+	case p.creator != nil:
+		next.resource, next.err = p.creator(ctx)
+	default:
+		next.err = pool.ErrPoolExhausted
+	}
+
+	if next.err != nil {
+		p.calls = append(p.calls, Call[T]{Method: "Acquire", Err: next.err})
+		return *new(T), next.err
+	}
+	p.acquired++
+	p.calls = append(p.calls, Call[T]{Method: "Acquire", Resource: next.resource})
+	return next.resource, nil
+}
+
+// records the release of resource
+func (p *Pool[T]) Release(resource T) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.released = append(p.released, resource)
+	p.calls = append(p.calls, Call[T]{Method: "Release", Resource: resource})
+}
+
+// returns the number set with SetNumIdle
+func (p *Pool[T]) NumIdle() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.calls = append(p.calls, Call[T]{Method: "NumIdle"})
+	return p.numIdle
+}
+
+// returns the calls made to the pool, in order
+func (p *Pool[T]) Calls() []Call[T] {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]Call[T](nil), p.calls...)
+}
+
+// returns the released resources, in order
+func (p *Pool[T]) Released() []T {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]T(nil), p.released...)
+}
+
+// returns the number of resources acquired and not released, e.g. to check
+// the code under test releases everything it acquires
+func (p *Pool[T]) Outstanding() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.acquired - len(p.released)
+}
+
+// creates a fake pool with an empty queue
+func New[T any](options ...Option[T]) *Pool[T] {
+	p := &Pool[T]{}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
@@ -0,0 +1,91 @@
+package pooltest
+
+import (
+	"context"
+	"errors"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPool_Acquire(t *testing.T) {
+	testCases := []struct {
+		name             string
+		ctx              func() context.Context
+		queue            func(*Pool[string])
+		options          []Option[string]
+		expectedResource string
+		expectedError    error
+	}{
+		{
+			name:             "with queued resource returns it",
+			ctx:              func() context.Context { return nil },
+			queue:            func(p *Pool[string]) { p.Queue("a", "b") },
+			expectedResource: "a",
+		},
+		{
+			name:          "with queued error returns it",
+			ctx:           func() context.Context { return nil },
+			queue:         func(p *Pool[string]) { p.QueueError(errors.New("dial failed")) },
+			expectedError: errors.New("dial failed"),
+		},
+		{
+			name:          "with empty queue returns pool exhausted",
+			ctx:           func() context.Context { return nil },
+			queue:         func(*Pool[string]) {},
+			expectedError: pool.ErrPoolExhausted,
+		},
+		{
+			name:  "with empty queue and creator calls it",
+			ctx:   func() context.Context { return nil },
+			queue: func(*Pool[string]) {},
+			options: []Option[string]{WithCreator(func(context.Context) (string, error) {
+				return "created", nil
+			})},
+			expectedResource: "created",
+		},
+		{
+			name: "with done context returns context error",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			queue:         func(p *Pool[string]) { p.Queue("a") },
+			expectedError: context.Canceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := New(tc.options...)
+			tc.queue(fake)
+
+			resource, err := fake.Acquire(tc.ctx())
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestPool_RecordsCalls(t *testing.T) {
+	fake := New[string]()
+	fake.Queue("a")
+	fake.SetNumIdle(2)
+
+	resource, _ := fake.Acquire(nil)
+	fake.Acquire(nil)
+	assert.Equal(t, 1, fake.Outstanding())
+	fake.Release(resource)
+	assert.Equal(t, 2, fake.NumIdle())
+
+	assert.Equal(t, []Call[string]{
+		{Method: "Acquire", Resource: "a"},
+		{Method: "Acquire", Err: pool.ErrPoolExhausted},
+		{Method: "Release", Resource: "a"},
+		{Method: "NumIdle"},
+	}, fake.Calls())
+	assert.Equal(t, []string{"a"}, fake.Released())
+	assert.Equal(t, 0, fake.Outstanding())
+}
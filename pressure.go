@@ -0,0 +1,97 @@
+package pool
+
+import (
+	"runtime/metrics"
+	"time"
+)
+
+// heapObjectsMetric is the runtime metric of the memory taken by heap objects
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// WithMemoryPressure shrinks the idle pool while the process is under memory
+// pressure, e.g. for buffer pools. isPressured is polled every interval; it
+// may read runtime memory stats, as HeapAbove does, or an external signal.
+// Under pressure, idle resources past pressuredIdleSize are evicted with
+// EvictPressure and releases only keep that many idle; once the pressure
+// subsides, the idle cap is restored and the WithWarmup resources are created
+// again. In synchronous mode the signal is polled by the first Acquire or
+// Release after each interval.
+func WithMemoryPressure[T comparable](isPressured func() bool, interval time.Duration, pressuredIdleSize int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.isPressured = isPressured
+		n.pressureInterval = interval
+		n.pressuredIdleSize = pressuredIdleSize
+	}
+}
+
+// returns a memory pressure signal for WithMemoryPressure, reporting pressure
+// while heap objects take more than limit bytes
+func HeapAbove(limit uint64) func() bool {
+	return func() bool {
+		samples := []metrics.Sample{{Name: heapObjectsMetric}}
+		metrics.Read(samples)
+		return samples[0].Value.Kind() == metrics.KindUint64 && samples[0].Value.Uint64() > limit
+	}
+}
+
+// schedules the next poll of the memory pressure signal; the pool mutex must
+// be held
+func (n *NewPool[T]) schedulePressureCheck() {
+	if n.isPressured == nil || n.pressureInterval <= 0 || n.isClosed {
+		return
+	}
+
+	if n.isSynchronous {
+		n.nextPressureCheckAt = n.now().Add(n.pressureInterval)
+		return
+	}
+	n.pressureTimer = n.getClock().AfterFunc(n.pressureInterval, func() {
+		n.scheduler.Go(func() {
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+
+			if n.checkPressure() {
+				n.scheduler.Go(n.warmup)
+			}
+		})
+	})
+}
+
+// polls the memory pressure signal, shrinking or restoring the idle pool when
+// it changed, and schedules the next poll; reports whether the warmup should
+// run again to restore the idle pool. The pool mutex must be held.
+func (n *NewPool[T]) checkPressure() bool {
+	if n.isClosed {
+		return false
+	}
+	defer n.schedulePressureCheck()
+
+	isPressured := n.isPressured()
+	if isPressured == n.isUnderPressure {
+		return false
+	}
+
+	n.isUnderPressure = isPressured
+	if isPressured {
+		n.log(LogInfo, "memory pressure; shrinking idle resource pool",
+			Field{Key: "idle_size", Value: n.pressuredIdleSize},
+		)
+		n.shrinkIdle(n.pressuredIdleSize, EvictPressure)
+		return false
+	}
+
+	n.log(LogInfo, "memory pressure subsided; restoring idle resource pool")
+	if n.warmupSize <= 0 || n.isWarming {
+		return false
+	}
+	n.isWarming = true
+	return true
+}
+
+// stops the polls of the memory pressure signal; the pool mutex must be held
+func (n *NewPool[T]) stopPressureChecks() {
+	if n.pressureTimer != nil {
+		n.pressureTimer.Stop()
+		n.pressureTimer = nil
+	}
+}
@@ -0,0 +1,146 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewPool_MemoryPressure(t *testing.T) {
+	var isPressured atomic.Bool
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMemoryPressure[MockResource](isPressured.Load, time.Second, 1),
+	)
+	var held []MockResource
+	for i := 0; i < 3; i++ {
+		resource, _ := pool.Acquire(nil)
+		held = append(held, resource)
+	}
+	pool.ReleaseAll(held[:2])
+
+	isPressured.Store(true)
+	clock.Advance(time.Second)
+
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictPressure])
+
+	pool.Release(held[2])
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictCapacity])
+}
+
+func TestNewPool_MemoryPressureSubsided(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		options                []Option[MockResource]
+		expectedIdlePoolLength int
+	}{
+		{
+			name:                   "without warmup keeps idle pool",
+			expectedIdlePoolLength: 0,
+		},
+		{
+			name:                   "with warmup restores idle pool",
+			options:                []Option[MockResource]{WithWarmup[MockResource](2)},
+			expectedIdlePoolLength: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var isPressured atomic.Bool
+			isPressured.Store(true)
+			clock := &MockClock{now: time.Unix(0, 0)}
+			options := append([]Option[MockResource]{
+				WithClock[MockResource](clock),
+				WithMemoryPressure[MockResource](isPressured.Load, time.Second, 0),
+			}, tc.options...)
+			pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime, options...)
+			assert.Eventually(t, func() bool { return pool.NumIdle() == tc.expectedIdlePoolLength }, time.Second, time.Millisecond)
+			clock.Advance(time.Second)
+			assert.Eventually(t, func() bool { return pool.NumIdle() == 0 }, time.Second, time.Millisecond)
+
+			isPressured.Store(false)
+			clock.Advance(time.Second)
+
+			assert.Eventually(t, func() bool { return pool.NumIdle() == tc.expectedIdlePoolLength }, time.Second, time.Millisecond)
+			assert.Equal(t, int64(tc.expectedIdlePoolLength), pool.Stats().Evictions[EvictPressure])
+		})
+	}
+}
+
+func TestNewPool_MemoryPressureWithSynchronous(t *testing.T) {
+	isPressured := true
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithSynchronous[MockResource](),
+		WithMemoryPressure[MockResource](func() bool { return isPressured }, time.Second, 0),
+	)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+
+	clock.Advance(time.Second)
+	pool.Release(second)
+
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictPressure])
+}
+
+func TestNewPool_MemoryPressureSubsidedAboveWarmup(t *testing.T) {
+	isPressured := true
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), 10, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithSynchronous[MockResource](),
+		WithWarmup[MockResource](1),
+		WithMemoryPressure[MockResource](func() bool { return isPressured }, time.Second, 5),
+	)
+	var held []MockResource
+	for i := 0; i < 4; i++ {
+		resource, _ := pool.Acquire(nil)
+		held = append(held, resource)
+	}
+	pool.ReleaseAll(held)
+	clock.Advance(time.Second)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	isPressured = false
+	clock.Advance(time.Second)
+	resource, _ = pool.Acquire(nil)
+	pool.Release(resource)
+
+	assert.Equal(t, 4, pool.NumIdle())
+}
+
+func TestHeapAbove(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    uint64
+		expected bool
+	}{
+		{
+			name:     "heap above limit is pressured",
+			limit:    0,
+			expected: true,
+		},
+		{
+			name:     "heap below limit is not pressured",
+			limit:    math.MaxUint64,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HeapAbove(tc.limit)())
+		})
+	}
+}
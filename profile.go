@@ -0,0 +1,64 @@
+package pool
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// WithProfilerLabels applies pprof labels around the work of the pool, so CPU
+// and goroutine profiles attribute time to it: "pool" is set to name, and
+// "phase" to "create" while the creator runs, to "wait" while an acquire
+// waits for a resource, and to "hold" on the goroutine of an Acquire until
+// the resource is released. Releases restore the labels of the acquire
+// context, so they are only right for resources released on the goroutine
+// which acquired them. Batch acquires and WithLocalCache resources are not
+// labeled as held. Without the option, no labels are applied.
+func WithProfilerLabels[T comparable](name string) Option[T] {
+	return func(n *NewPool[T]) {
+		n.profileName = name
+	}
+}
+
+// runs f with the profiler labels of phase, passing it ctx with the labels
+// added; a nil ctx is passed on as is
+func (n *NewPool[T]) doLabeled(ctx context.Context, phase string, f func(context.Context)) {
+	if n.profileName == "" {
+		f(ctx)
+		return
+	}
+
+	parent := ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	pprof.Do(parent, pprof.Labels("pool", n.profileName, "phase", phase), func(labeled context.Context) {
+		if ctx == nil {
+			labeled = nil
+		}
+		f(labeled)
+	})
+}
+
+// labels the current goroutine as holding a resource acquired with ctx
+func (n *NewPool[T]) labelHold(ctx context.Context, entry *resourceEntry) {
+	if n.profileName == "" || n.local != nil {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	entry.profileCtx = ctx
+	pprof.SetGoroutineLabels(pprof.WithLabels(ctx, pprof.Labels("pool", n.profileName, "phase", "hold")))
+}
+
+// restores the labels of the context a resource was acquired with on the
+// current goroutine
+func (n *NewPool[T]) unlabelHold(entry *resourceEntry) {
+	if entry.profileCtx == nil {
+		return
+	}
+
+	pprof.SetGoroutineLabels(entry.profileCtx)
+	entry.profileCtx = nil
+}
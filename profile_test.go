@@ -0,0 +1,70 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestNewPool_ProfilerLabelsOnCreate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []Option[MockResource]
+		ctx           context.Context
+		expectedPool  string
+		expectedPhase string
+		expectedNil   bool
+	}{
+		{
+			name:          "with labels labels creator context",
+			options:       []Option[MockResource]{WithProfilerLabels[MockResource]("db")},
+			ctx:           context.Background(),
+			expectedPool:  "db",
+			expectedPhase: "create",
+		},
+		{
+			name: "without labels passes context unchanged",
+			ctx:  context.Background(),
+		},
+		{
+			name:        "with labels and nil context passes nil context",
+			options:     []Option[MockResource]{WithProfilerLabels[MockResource]("db")},
+			expectedNil: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var createCtx context.Context
+			creator := func(ctx context.Context) (MockResource, error) {
+				createCtx = ctx
+				return MockResource{}, nil
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime, tc.options...)
+
+			pool.Acquire(tc.ctx)
+
+			if tc.expectedNil {
+				assert.Nil(t, createCtx)
+				return
+			}
+			name, _ := pprof.Label(createCtx, "pool")
+			phase, _ := pprof.Label(createCtx, "phase")
+			assert.Equal(t, tc.expectedPool, name)
+			assert.Equal(t, tc.expectedPhase, phase)
+		})
+	}
+}
+
+func TestNewPool_ProfilerLabelsOnHold(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithProfilerLabels[MockResource]("db"))
+	ctx := context.Background()
+
+	resource, _ := pool.Acquire(ctx)
+	assert.Equal(t, ctx, pool.lock[resource].profileCtx)
+
+	entry := pool.lock[resource]
+	pool.Release(resource)
+	assert.Nil(t, entry.profileCtx)
+}
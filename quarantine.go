@@ -0,0 +1,131 @@
+package pool
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// WithEndpointQuarantine skips endpoints of WithEndpoints whose dial failed,
+// instead of dialing them again on every creation: a failing endpoint is
+// quarantined for initial, doubling with each consecutive failure up to max,
+// and dialed again once its quarantine is over; a successful dial ends it.
+// While every endpoint is quarantined, creations fail with
+// ErrEndpointQuarantined. Quarantined endpoints are listed in
+// Stats.Quarantined.
+func WithEndpointQuarantine[T comparable](initial time.Duration, max time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.quarantine = &quarantine{initial: initial, max: max}
+	}
+}
+
+// QuarantinedEndpoint describes an endpoint skipped after failed dials
+type QuarantinedEndpoint struct {
+	// Endpoint is the quarantined endpoint
+	Endpoint string
+	// Failures is the number of consecutive failed dials of the endpoint
+	Failures int
+	// Until is the time after which the endpoint is dialed again
+	Until time.Time
+	// Err is the error of the last failed dial
+	Err error
+}
+
+// quarantine tracks the endpoints with failed dials; a nil quarantine never
+// skips endpoints. It has its own mutex, as dials run with and without the
+// pool mutex held.
+type quarantine struct {
+	mutex     sync.Mutex
+	initial   time.Duration
+	max       time.Duration
+	endpoints map[string]*QuarantinedEndpoint
+}
+
+// returns the index of the first endpoint from start which is not
+// quarantined at now; if all are, returns the one released soonest and false
+func (q *quarantine) pick(endpoints []string, start int, now time.Time) (int, bool) {
+	if q == nil {
+		return start % len(endpoints), true
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	soonest := -1
+	for i := 0; i < len(endpoints); i++ {
+		index := (start + i) % len(endpoints)
+		entry, isQuarantined := q.endpoints[endpoints[index]]
+		if !isQuarantined || !now.Before(entry.Until) {
+			return index, true
+		}
+		if soonest < 0 || entry.Until.Before(q.endpoints[endpoints[soonest]].Until) {
+			soonest = index
+		}
+	}
+	return soonest, false
+}
+
+// quarantines endpoint after a failed dial at now
+func (q *quarantine) fail(endpoint string, now time.Time, err error) {
+	if q == nil {
+		return
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.endpoints == nil {
+		q.endpoints = make(map[string]*QuarantinedEndpoint)
+	}
+	entry, isQuarantined := q.endpoints[endpoint]
+	if !isQuarantined {
+		entry = &QuarantinedEndpoint{Endpoint: endpoint}
+		q.endpoints[endpoint] = entry
+	}
+	entry.Failures++
+	entry.Until = now.Add(getQuarantineTime(q.initial, q.max, entry.Failures))
+	entry.Err = err
+}
+
+// ends the quarantine of endpoint after a successful dial
+func (q *quarantine) succeed(endpoint string) {
+	if q == nil {
+		return
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.endpoints, endpoint)
+}
+
+// returns the quarantined endpoints sorted by endpoint; nil if there are none
+func (q *quarantine) snapshot() []QuarantinedEndpoint {
+	if q == nil {
+		return nil
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var endpoints []QuarantinedEndpoint
+	for _, entry := range q.endpoints {
+		endpoints = append(endpoints, *entry)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	})
+	return endpoints
+}
+
+// returns the quarantine time after failures consecutive failed dials
+func getQuarantineTime(initial time.Duration, max time.Duration, failures int) time.Duration {
+	quarantineTime := initial
+	for i := 1; i < failures && quarantineTime < max; i++ {
+		quarantineTime *= 2
+	}
+	if max > 0 && quarantineTime > max {
+		return max
+	}
+	return quarantineTime
+}
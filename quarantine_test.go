@@ -0,0 +1,134 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetQuarantineTime(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		failures               int
+		expectedQuarantineTime time.Duration
+	}{
+		{
+			name:                   "after first failure is initial time",
+			failures:               1,
+			expectedQuarantineTime: time.Second,
+		},
+		{
+			name:                   "after consecutive failures doubles",
+			failures:               3,
+			expectedQuarantineTime: 4 * time.Second,
+		},
+		{
+			name:                   "after many failures is capped",
+			failures:               100,
+			expectedQuarantineTime: time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedQuarantineTime, getQuarantineTime(time.Second, time.Minute, tc.failures))
+		})
+	}
+}
+
+func TestNewPool_AcquireWithEndpointQuarantine(t *testing.T) {
+	dialErr := errors.New("connection refused")
+
+	testCases := []struct {
+		name             string
+		dialErrors       map[string]error
+		advance          time.Duration
+		expectedDials    []string
+		expectedError    error
+		expectedFailures int
+		expectedUntil    time.Duration
+	}{
+		{
+			name:             "with quarantined endpoint skips it",
+			dialErrors:       map[string]error{"a": dialErr},
+			expectedDials:    []string{"a", "b", "b"},
+			expectedFailures: 1,
+			expectedUntil:    time.Second,
+		},
+		{
+			name:             "after quarantine dials endpoint again",
+			dialErrors:       map[string]error{"a": dialErr},
+			advance:          time.Second,
+			expectedDials:    []string{"a", "b", "a"},
+			expectedError:    dialErr,
+			expectedFailures: 2,
+			expectedUntil:    3 * time.Second,
+		},
+		{
+			name:             "with every endpoint quarantined fails without dialing",
+			dialErrors:       map[string]error{"a": dialErr, "b": dialErr},
+			expectedDials:    []string{"a", "b"},
+			expectedError:    ErrEndpointQuarantined,
+			expectedFailures: 1,
+			expectedUntil:    time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			var dials []string
+			dial := func(ctx context.Context, endpoint string) (EndpointResource, error) {
+				dials = append(dials, endpoint)
+				return EndpointResource{endpoint: endpoint, id: len(dials)}, tc.dialErrors[endpoint]
+			}
+			pool := New(nil, maxIdleSize, maxIdleTime,
+				WithClock[EndpointResource](clock),
+				WithEndpoints([]string{"a", "b"}, dial),
+				WithEndpointQuarantine[EndpointResource](time.Second, time.Minute),
+			)
+			pool.Acquire(nil)
+			pool.Acquire(nil)
+			clock.Advance(tc.advance)
+
+			_, err := pool.Acquire(nil)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedDials, dials)
+			quarantined := pool.Stats().Quarantined
+			if assert.NotEmpty(t, quarantined) {
+				assert.Equal(t, "a", quarantined[0].Endpoint)
+				assert.Equal(t, tc.expectedFailures, quarantined[0].Failures)
+				assert.Equal(t, time.Unix(0, 0).Add(tc.expectedUntil), quarantined[0].Until)
+				assert.ErrorIs(t, quarantined[0].Err, dialErr)
+			}
+		})
+	}
+}
+
+func TestNewPool_AcquireWithEndpointQuarantineEndsOnSuccess(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	isDown := true
+	dial := func(ctx context.Context, endpoint string) (EndpointResource, error) {
+		if isDown {
+			return EndpointResource{}, errors.New("connection refused")
+		}
+		return EndpointResource{endpoint: endpoint}, nil
+	}
+	pool := New(nil, maxIdleSize, maxIdleTime,
+		WithClock[EndpointResource](clock),
+		WithEndpoints([]string{"a"}, dial),
+		WithEndpointQuarantine[EndpointResource](time.Second, time.Minute),
+	)
+	pool.Acquire(nil)
+	assert.Len(t, pool.Stats().Quarantined, 1)
+
+	isDown = false
+	clock.Advance(time.Second)
+	_, err := pool.Acquire(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, pool.Stats().Quarantined)
+}
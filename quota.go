@@ -0,0 +1,72 @@
+package pool
+
+import (
+	"context"
+)
+
+// Quota is a capacity budget shared by several pools, e.g. "api" and
+// "reporting" pools sharing 100 connections to one database. Each acquired
+// resource of a pool holds a slot of the pool's quota and of every parent
+// quota until it is released, so a release in one pool frees budget for its
+// siblings. Pools keep their individual caps with WithMaxActive.
+type Quota struct {
+	parent *Quota
+	tokens semaphore
+}
+
+// WithQuota makes the pool draw acquired resources from quota. At the quota
+// limit, Acquire waits for a release in any pool of the quota until ctx is
+// done; with a nil ctx it returns ErrPoolExhausted instead of waiting. It has
+// no effect with WithLocalCache.
+func WithQuota[T comparable](quota *Quota) Option[T] {
+	return func(n *NewPool[T]) {
+		n.quota = quota
+	}
+}
+
+// returns a quota of limit slots drawing from q; the child is also bound by
+// the limits of q
+func (q *Quota) Child(limit int) *Quota {
+	return &Quota{parent: q, tokens: make(semaphore, limit)}
+}
+
+// returns the number of slots of the quota
+func (q *Quota) Limit() int {
+	return cap(q.tokens)
+}
+
+// returns the number of slots held by acquired resources
+func (q *Quota) Used() int {
+	return len(q.tokens)
+}
+
+// takes a slot of q and its parents, waiting for them until ctx is done
+func (q *Quota) take(ctx context.Context) error {
+	if q == nil {
+		return nil
+	}
+
+	if err := q.tokens.take(ctx); err != nil {
+		return err
+	}
+	if err := q.parent.take(ctx); err != nil {
+		q.tokens.give()
+		return err
+	}
+	return nil
+}
+
+// gives back a slot of q and its parents
+func (q *Quota) give() {
+	if q == nil {
+		return
+	}
+
+	q.tokens.give()
+	q.parent.give()
+}
+
+// creates a root quota of limit slots
+func NewQuota(limit int) *Quota {
+	return &Quota{tokens: make(semaphore, limit)}
+}
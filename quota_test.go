@@ -0,0 +1,87 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithQuota(t *testing.T) {
+	testCases := []struct {
+		name          string
+		parentLimit   int
+		childLimit    int
+		maxActive     int
+		siblingHolds  int
+		expectedError error
+	}{
+		{
+			name:        "within parent and child limits acquires",
+			parentLimit: 2,
+			childLimit:  2,
+		},
+		{
+			name:          "at parent limit held by sibling fails fast",
+			parentLimit:   2,
+			childLimit:    2,
+			siblingHolds:  2,
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name:          "at child limit fails fast",
+			parentLimit:   2,
+			childLimit:    0,
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name:          "at individual cap fails fast",
+			parentLimit:   2,
+			childLimit:    2,
+			maxActive:     1,
+			expectedError: ErrPoolExhausted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := NewQuota(tc.parentLimit)
+			child := parent.Child(tc.childLimit)
+			sibling := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithQuota[MockResource](parent.Child(2)))
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithQuota[MockResource](child),
+				WithMaxActive[MockResource](tc.maxActive),
+			)
+			for i := 0; i < tc.siblingHolds; i++ {
+				sibling.Acquire(nil)
+			}
+			if tc.maxActive > 0 {
+				pool.Acquire(nil)
+			}
+
+			_, err := pool.Acquire(nil)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, pool.Stats().Active+tc.siblingHolds, parent.Used())
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithQuotaFreesSiblings(t *testing.T) {
+	parent := NewQuota(1)
+	api := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithQuota[MockResource](parent.Child(1)))
+	reporting := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithQuota[MockResource](parent.Child(1)))
+	resource, _ := api.Acquire(nil)
+
+	acquired := make(chan error)
+	go func() {
+		_, err := reporting.Acquire(context.Background())
+		acquired <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	api.Release(resource)
+
+	assert.NoError(t, <-acquired)
+	assert.Equal(t, 1, parent.Used())
+}
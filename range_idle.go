@@ -0,0 +1,64 @@
+package pool
+
+import (
+	"time"
+)
+
+// IdleInfo describes an idle resource passed to RangeIdle
+type IdleInfo struct {
+	// CreatedAt is when the resource was created
+	CreatedAt time.Time
+	// ReleasedAt is when the resource entered the idle pool
+	ReleasedAt time.Time
+	// Metadata is the metadata attached to the resource
+	Metadata *Metadata
+}
+
+// calls f for each resource of a snapshot of the idle pool, until f returns
+// false. The pool mutex is not held while f runs, so it may call the pool,
+// e.g. EvictIdle to prune connections to a decommissioned host; resources may
+// have been acquired or dropped since the snapshot was taken. Resources in
+// the cache of WithLocalCache are not included.
+func (n *NewPool[T]) RangeIdle(f func(T, IdleInfo) bool) {
+	type idleResource struct {
+		resource T
+		info     IdleInfo
+	}
+
+	n.mutex.Lock()
+	snapshot := make([]idleResource, 0, len(n.unlock))
+	for resource, entry := range n.unlock {
+		snapshot = append(snapshot, idleResource{
+			resource: resource,
+			info: IdleInfo{
+				CreatedAt:  entry.createdAt,
+				ReleasedAt: entry.releasedAt,
+				Metadata:   entry.getMetadata(),
+			},
+		})
+	}
+	n.mutex.Unlock()
+
+	for _, idle := range snapshot {
+		if !f(idle.resource, idle.info) {
+			return
+		}
+	}
+}
+
+// destroys an idle resource with EvictPruned; returns false if the resource
+// is not idle, e.g. because it was acquired since RangeIdle saw it
+func (n *NewPool[T]) EvictIdle(resource T) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resource = n.getTracked(resource)
+	entry, isIdle := n.unlock[resource]
+	if !isIdle {
+		return false
+	}
+
+	delete(n.unlock, resource)
+	n.evict(resource, entry, EvictPruned)
+	return true
+}
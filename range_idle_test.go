@@ -0,0 +1,58 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_RangeIdle(t *testing.T) {
+	testCases := []struct {
+		name            string
+		isStopped       bool
+		expectedVisited int
+	}{
+		{
+			name:            "visits every idle resource",
+			expectedVisited: 3,
+		},
+		{
+			name:            "stops when f returns false",
+			isStopped:       true,
+			expectedVisited: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			resources, _ := pool.AcquireN(nil, 3)
+			pool.ReleaseAll(resources)
+
+			visited := 0
+			pool.RangeIdle(func(resource MockResource, info IdleInfo) bool {
+				visited++
+				assert.False(t, info.ReleasedAt.IsZero())
+				return !tc.isStopped
+			})
+
+			assert.Equal(t, tc.expectedVisited, visited)
+		})
+	}
+}
+
+func TestNewPool_RangeIdleEvictIdle(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resources, _ := pool.AcquireN(nil, 3)
+	pool.ReleaseAll(resources)
+
+	pool.RangeIdle(func(resource MockResource, info IdleInfo) bool {
+		if resource.id != 2 {
+			assert.True(t, pool.EvictIdle(resource))
+		}
+		return true
+	})
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictPruned])
+	assert.False(t, pool.EvictIdle(MockResource{id: 1}))
+}
@@ -0,0 +1,65 @@
+package pool
+
+import (
+	"time"
+)
+
+// WithReaper sweeps expired idle resources in the background every interval,
+// instead of only when acquires and releases find them, so idle connections
+// of a quiet pool are closed on time. Each sweep, including the ones of
+// acquires and releases, evicts at most batchSize resources, so a pile of
+// expired resources is destroyed over several sweeps instead of in one burst
+// under the pool mutex; zero means no limit. Expired resources left for a
+// later sweep are not handed out. In synchronous mode the sweeps are made by
+// the first Acquire or Release after each interval.
+func WithReaper[T comparable](interval time.Duration, batchSize int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.reapInterval = interval
+		n.reapBatchSize = batchSize
+	}
+}
+
+// schedules the next background sweep; the pool mutex must be held
+func (n *NewPool[T]) scheduleReap() {
+	if n.reapInterval <= 0 || n.isClosed {
+		return
+	}
+
+	if n.isSynchronous {
+		n.nextReapAt = n.now().Add(n.reapInterval)
+		return
+	}
+	n.reapTimer = n.getClock().AfterFunc(n.reapInterval, func() {
+		n.scheduler.Go(func() {
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+
+			n.reap()
+		})
+	})
+}
+
+// sweeps a batch of expired idle resources and schedules the next sweep; the
+// pool mutex must be held
+func (n *NewPool[T]) reap() {
+	if n.isClosed {
+		return
+	}
+
+	n.deleteInvalidIdleResources()
+	n.scheduleReap()
+}
+
+// stops the background sweeps; the pool mutex must be held
+func (n *NewPool[T]) stopReap() {
+	if n.reapTimer != nil {
+		n.reapTimer.Stop()
+		n.reapTimer = nil
+	}
+}
+
+// reports whether an idle resource is expired but left for a later sweep by
+// the reaper batch size
+func (n *NewPool[T]) isLeftExpired(entry *resourceEntry) bool {
+	return n.reapBatchSize > 0 && n.getIdleDeadline(entry).Before(n.now())
+}
@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Reaper(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithReaper[MockResource](time.Second, 0),
+	)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+	pool.Release(second)
+
+	clock.Advance(maxIdleTime + time.Second)
+
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictExpired])
+}
+
+func TestNewPool_ReaperBatchSize(t *testing.T) {
+	testCases := []struct {
+		name              string
+		batchSize         int
+		expectedEvictions int64
+	}{
+		{
+			name:              "without batch size sweeps every expired resource",
+			expectedEvictions: 3,
+		},
+		{
+			name:              "with batch size sweeps up to batch size",
+			batchSize:         2,
+			expectedEvictions: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithReaper[MockResource](time.Hour, tc.batchSize),
+			)
+			var held []MockResource
+			for i := 0; i < 3; i++ {
+				resource, _ := pool.Acquire(nil)
+				held = append(held, resource)
+			}
+			pool.ReleaseAll(held)
+			clock.Advance(maxIdleTime + time.Second)
+
+			resource, err := pool.Acquire(nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, MockResource{id: 4}, resource)
+			assert.Equal(t, tc.expectedEvictions, pool.Stats().Evictions[EvictExpired])
+		})
+	}
+}
+
+func TestNewPool_ReaperSynchronous(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithSynchronous[MockResource](),
+		WithReaper[MockResource](time.Second, 0),
+	)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+	clock.Advance(maxIdleTime + time.Second)
+
+	pool.TryRelease(MockResource{id: 10})
+
+	assert.Equal(t, 0, pool.NumIdle())
+}
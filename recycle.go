@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"context"
+)
+
+// replaces every resource of the pool without a hard drain, e.g. to force
+// reconnects after a backend config change. The idle resources are replaced
+// one at a time, each new resource taking the place of an old one, so the
+// pool keeps serving acquires meanwhile; acquired resources are destroyed
+// when they are released, with EvictRetired. Returns once the idle resources
+// were replaced, with the creator error if a creation failed, or with the
+// error of ctx if it is done first; the resources not yet replaced are
+// destroyed when they are acquired and released.
+func (n *NewPool[T]) Recycle(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	n.mutex.Lock()
+	if n.isClosed {
+		n.mutex.Unlock()
+		return ErrPoolClosed
+	}
+	n.generation++
+	count := len(n.unlock)
+	n.mutex.Unlock()
+
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := n.recycleOne(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creates a resource in place of a retired idle resource
+func (n *NewPool[T]) recycleOne(ctx context.Context) error {
+	n.sleep(n.reserveCreate())
+
+	n.mutex.Lock()
+	creator, generation := n.creator, n.generation
+	n.mutex.Unlock()
+
+	start := n.now()
+	resource, err := n.create(ctx, creator)
+	entry := &resourceEntry{createdAt: n.now(), generation: generation}
+	elapsed := entry.createdAt.Sub(start)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if err != nil {
+		return n.recordCreateFailure(err, elapsed)
+	}
+	n.recordCreate(resource, entry, elapsed)
+	if n.isClosed {
+		n.evict(resource, entry, EvictClosed)
+		return ErrPoolClosed
+	}
+
+	for retired, retiredEntry := range n.unlock {
+		if n.isRetired(retiredEntry) {
+			delete(n.unlock, retired)
+			n.evict(retired, retiredEntry, EvictRetired)
+			break
+		}
+	}
+	if n.isRetired(entry) {
+		n.evict(resource, entry, EvictRetired)
+		return nil
+	}
+	n.returnIdle(resource, entry)
+	return nil
+}
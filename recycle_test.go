@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_Recycle(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	resources, _ := pool.AcquireN(nil, 3)
+	pool.Release(resources[0])
+	pool.Release(resources[1])
+
+	err := pool.Recycle(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pool.Stats().Evictions[EvictRetired])
+	assert.Equal(t, 2, pool.NumIdle())
+	for resource := range pool.unlock {
+		assert.Greater(t, resource.id, 3)
+	}
+
+	pool.Release(resources[2])
+	assert.Equal(t, int64(3), pool.Stats().Evictions[EvictRetired])
+	assert.Equal(t, 2, pool.NumIdle())
+}
+
+func TestNewPool_RecycleErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           func() context.Context
+		isClosed      bool
+		expectedError error
+	}{
+		{
+			name: "with failing creator returns create error",
+			ctx: func() context.Context {
+				return context.Background()
+			},
+			expectedError: &CreateError{Err: errors.New("recycle failed"), Attempt: 1},
+		},
+		{
+			name: "with cancelled context returns context error",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			expectedError: context.Canceled,
+		},
+		{
+			name: "with closed pool returns pool closed",
+			ctx: func() context.Context {
+				return context.Background()
+			},
+			isClosed:      true,
+			expectedError: ErrPoolClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+			pool.SetCreator(func(context.Context) (MockResource, error) {
+				return MockResource{}, errors.New("recycle failed")
+			})
+			if tc.isClosed {
+				pool.Close()
+			}
+
+			err := pool.Recycle(tc.ctx())
+
+			assert.EqualError(t, err, tc.expectedError.Error())
+		})
+	}
+}
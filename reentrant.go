@@ -0,0 +1,114 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+type reentrantScopeKey struct{}
+
+// reentrantScope records the resource held by a scope in each reentrant pool
+type reentrantScope struct {
+	mutex sync.Mutex
+	held  map[any]any
+}
+
+func (s *reentrantScope) get(pool any) (any, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	resource, isHeld := s.held[pool]
+	return resource, isHeld
+}
+
+func (s *reentrantScope) set(pool any, resource any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.held == nil {
+		s.held = make(map[any]any)
+	}
+	s.held[pool] = resource
+}
+
+func (s *reentrantScope) clear(pool any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.held, pool)
+}
+
+// WithReentrantAcquire lets nested acquires within a ReentrantScope reuse the
+// resource already held by the scope instead of taking another one. This is
+// meant for singleton guard pools (WithMaxActive(1)), where a nested acquire
+// would otherwise wait forever for its own caller's release.
+func WithReentrantAcquire[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isReentrant = true
+	}
+}
+
+// ReentrantScope starts a scope in which repeat acquires with the returned
+// context return the resource already held by the scope. Every acquire must
+// still be paired with a release; the resource goes back to the pool when
+// the outermost acquire is released. Pools without WithReentrantAcquire
+// ignore the scope.
+func ReentrantScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reentrantScopeKey{}, &reentrantScope{})
+}
+
+// returns the reentrant scope of ctx, if the pool allows reentrant acquires
+func (n *NewPool[T]) getReentrantScope(ctx context.Context) *reentrantScope {
+	if !n.isReentrant || ctx == nil {
+		return nil
+	}
+
+	scope, _ := ctx.Value(reentrantScopeKey{}).(*reentrantScope)
+	return scope
+}
+
+// returns the resource held by the scope, one level deeper, if there is one
+func (n *NewPool[T]) reacquire(scope *reentrantScope) (T, bool) {
+	if scope == nil {
+		return *new(T), false
+	}
+
+	held, isHeld := scope.get(n)
+	if !isHeld {
+		return *new(T), false
+	}
+
+	resource := held.(T)
+	entry, isFound := n.lock[resource]
+	if !isFound || entry.scope != scope {
+		return *new(T), false
+	}
+
+	entry.depth++
+	return resource, true
+}
+
+// records resource as held by the scope
+func (n *NewPool[T]) hold(scope *reentrantScope, resource T, entry *resourceEntry) {
+	if scope == nil {
+		return
+	}
+
+	scope.set(n, resource)
+	entry.scope = scope
+}
+
+// releases one level of a reentrant hold; returns true while the resource
+// is still held by an outer acquire
+func (n *NewPool[T]) unhold(entry *resourceEntry) bool {
+	if entry.depth > 0 {
+		entry.depth--
+		return true
+	}
+
+	if entry.scope != nil {
+		entry.scope.clear(n)
+		entry.scope = nil
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireReentrant(t *testing.T) {
+	testCases := []struct {
+		name             string
+		options          []Option[MockResource]
+		expectedResource MockResource
+		expectedError    error
+	}{
+		{
+			name:             "with reentrant acquire reuses held resource",
+			options:          []Option[MockResource]{WithMaxActive[MockResource](1), WithReentrantAcquire[MockResource]()},
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:          "without reentrant acquire waits for release",
+			options:       []Option[MockResource]{WithMaxActive[MockResource](1)},
+			expectedError: ErrAcquireTimeout,
+		},
+		{
+			name: "with compatibility mode ignores reentrant acquire",
+			options: []Option[MockResource]{
+				WithMaxActive[MockResource](1),
+				WithReentrantAcquire[MockResource](),
+				CompatibilityV1[MockResource](),
+			},
+			expectedResource: MockResource{id: 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+
+			ctx, cancel := context.WithTimeout(ReentrantScope(context.Background()), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := pool.Acquire(ctx)
+			assert.NoError(t, err)
+			resource, err := pool.Acquire(ctx)
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNewPool_ReleaseReentrant(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](1),
+		WithReentrantAcquire[MockResource](),
+	)
+
+	ctx := ReentrantScope(context.Background())
+	outer, _ := pool.Acquire(ctx)
+	inner, _ := pool.Acquire(ctx)
+
+	pool.Release(inner)
+	assert.Equal(t, 0, pool.NumIdle())
+
+	pool.Release(outer)
+	assert.Equal(t, 1, pool.NumIdle())
+
+	// the scope holds nothing anymore; another scope may take the resource
+	other, err := pool.Acquire(ReentrantScope(context.Background()))
+	assert.NoError(t, err)
+	assert.Equal(t, outer, other)
+	assert.ErrorIs(t, pool.TryRelease(other), nil)
+	assert.ErrorIs(t, pool.TryRelease(other), ErrNotAcquired)
+}
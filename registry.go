@@ -0,0 +1,144 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var _ RegisteredPool = &NewPool[PoolResource]{}
+
+// RegisteredPool is a pool of any resource type managed by a Registry, e.g.
+// a *NewPool, an OverflowPool or a netpool.Pool
+type RegisteredPool interface {
+	Stats() Stats
+	Close()
+}
+
+// gracefulCloser is a RegisteredPool which can wait for its resources to be
+// released on close
+type gracefulCloser interface {
+	CloseGraceful(ctx context.Context) error
+}
+
+// Registry keeps the pools of a service under names, to enumerate them,
+// report their combined stats and close them together
+type Registry struct {
+	mutex sync.Mutex
+	pools map[string]RegisteredPool
+}
+
+// registers p under name; returns ErrPoolRegistered if name is taken
+func (r *Registry) Register(name string, p RegisteredPool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, isFound := r.pools[name]; isFound {
+		return ErrPoolRegistered
+	}
+	r.pools[name] = p
+	return nil
+}
+
+// removes the pool registered under name, without closing it
+func (r *Registry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.pools, name)
+}
+
+// returns the pool registered under name
+func (r *Registry) Get(name string) (RegisteredPool, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	p, isFound := r.pools[name]
+	return p, isFound
+}
+
+// returns the names of the registered pools in order
+func (r *Registry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := make([]string, 0, len(r.pools))
+	for name := range r.pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// returns the stats of each registered pool, by name
+func (r *Registry) StatsByName() map[string]Stats {
+	pools := r.getPools()
+	stats := make(map[string]Stats, len(pools))
+	for name, p := range pools {
+		stats[name] = p.Stats()
+	}
+	return stats
+}
+
+// returns the stats of the registered pools combined; Goroutines is left
+// empty, as pools may share a scheduler
+func (r *Registry) Stats() Stats {
+	var stats []Stats
+	for _, p := range r.getPools() {
+		stats = append(stats, p.Stats())
+	}
+	return MergeStats(stats...)
+}
+
+// closes every registered pool
+func (r *Registry) CloseAll() {
+	for _, p := range r.getPools() {
+		p.Close()
+	}
+}
+
+// closes every registered pool and waits, concurrently, for their acquired
+// resources to be released until ctx is done, like CloseGraceful; pools
+// which can not wait are closed like Close. Returns the errors of the pools
+// joined.
+func (r *Registry) DrainAll(ctx context.Context) error {
+	pools := r.getPools()
+	errs := make(chan error, len(pools))
+	for _, p := range pools {
+		closer, isGraceful := p.(gracefulCloser)
+		if !isGraceful {
+			p.Close()
+			errs <- nil
+			continue
+		}
+		go func() {
+			errs <- closer.CloseGraceful(ctx)
+		}()
+	}
+
+	var joined []error
+	for range pools {
+		if err := <-errs; err != nil {
+			joined = append(joined, err)
+		}
+	}
+	return errors.Join(joined...)
+}
+
+// returns a copy of the registered pools, to call them without the mutex
+func (r *Registry) getPools() map[string]RegisteredPool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	pools := make(map[string]RegisteredPool, len(r.pools))
+	for name, p := range r.pools {
+		pools[name] = p
+	}
+	return pools
+}
+
+// creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{pools: make(map[string]RegisteredPool)}
+}
@@ -0,0 +1,107 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	first := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	second := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	assert.NoError(t, registry.Register("b", first))
+	assert.NoError(t, registry.Register("a", second))
+	assert.ErrorIs(t, registry.Register("a", first), ErrPoolRegistered)
+
+	p, isFound := registry.Get("b")
+	assert.True(t, isFound)
+	assert.Equal(t, RegisteredPool(first), p)
+	assert.Equal(t, []string{"a", "b"}, registry.Names())
+
+	registry.Unregister("b")
+	_, isFound = registry.Get("b")
+	assert.False(t, isFound)
+	assert.Equal(t, []string{"a"}, registry.Names())
+}
+
+func TestRegistry_Stats(t *testing.T) {
+	registry := NewRegistry()
+	first := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	second := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	registry.Register("first", first)
+	registry.Register("second", second)
+	first.Acquire(nil)
+	resource, _ := second.Acquire(nil)
+	second.Release(resource)
+	second.Acquire(nil)
+
+	stats := registry.Stats()
+
+	assert.Equal(t, int64(3), stats.Acquires)
+	assert.Equal(t, int64(2), stats.Created)
+	assert.Equal(t, int64(1), stats.Reused)
+	assert.Equal(t, int64(2), registry.StatsByName()["second"].Acquires)
+}
+
+func TestRegistry_CloseAll(t *testing.T) {
+	registry := NewRegistry()
+	first := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	second := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	registry.Register("first", first)
+	registry.Register("second", second)
+
+	registry.CloseAll()
+
+	_, err := first.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	_, err = second.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolClosed)
+}
+
+func TestRegistry_DrainAll(t *testing.T) {
+	testCases := []struct {
+		name          string
+		isReleased    bool
+		expectedError error
+	}{
+		{
+			name:       "with resources released drains",
+			isReleased: true,
+		},
+		{
+			name:          "with resources held returns ctx error",
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry()
+			first := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+			registry.Register("first", first)
+			registry.Register("overflow", NewOverflow(
+				New(getMockCreatorFunc(), maxIdleSize, maxIdleTime),
+				New(getMockCreatorFunc(), maxIdleSize, maxIdleTime),
+			))
+			resource, _ := first.Acquire(nil)
+			if tc.isReleased {
+				first.Release(resource)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			err := registry.DrainAll(ctx)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+			_, err = first.Acquire(nil)
+			assert.ErrorIs(t, err, ErrPoolClosed)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package pool
+
+import (
+	"time"
+)
+
+// WithStatsReporter calls report with a Stats snapshot every interval, e.g. to
+// push pool stats to a telemetry system without Prometheus. Reports run on
+// the pool scheduler, outside the pool mutex, and stop when the pool is
+// closed. With WithPanicContainment, a panicking report is contained like a
+// hook named StatsReporter.
+func WithStatsReporter[T comparable](interval time.Duration, report func(Stats)) Option[T] {
+	return func(n *NewPool[T]) {
+		n.reportInterval = interval
+		n.reporter = report
+	}
+}
+
+// arms the timer of the next stats report; the pool mutex must be held
+func (n *NewPool[T]) scheduleReport() {
+	if n.reporter == nil || n.reportInterval <= 0 || n.isClosed {
+		return
+	}
+
+	if n.isSynchronous {
+		n.nextReportAt = n.now().Add(n.reportInterval)
+		return
+	}
+	n.reportTimer = n.getClock().AfterFunc(n.reportInterval, func() {
+		n.scheduler.Go(n.report)
+	})
+}
+
+// reports the current stats and schedules the next report
+func (n *NewPool[T]) report() {
+	stats := n.Stats()
+
+	n.mutex.Lock()
+	isClosed := n.isClosed
+	n.mutex.Unlock()
+	if isClosed {
+		return
+	}
+
+	n.callHookUnlocked("StatsReporter", func() {
+		n.reporter(stats)
+	})
+
+	n.mutex.Lock()
+	n.scheduleReport()
+	n.mutex.Unlock()
+}
+
+// stops the stats reports; the pool mutex must be held
+func (n *NewPool[T]) stopReports() {
+	if n.reportTimer != nil {
+		n.reportTimer.Stop()
+		n.reportTimer = nil
+	}
+}
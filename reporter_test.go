@@ -0,0 +1,59 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_StatsReporter(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	reports := make(chan Stats, 10)
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithStatsReporter[MockResource](time.Minute, func(stats Stats) { reports <- stats }),
+	)
+	pool.Acquire(nil)
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, int64(1), (<-reports).Acquires)
+
+	// the next report is armed once the previous one ran
+	assert.Eventually(t, func() bool {
+		clock.Advance(time.Minute)
+		return len(reports) > 0
+	}, time.Second, time.Millisecond)
+	<-reports
+
+	pool.Close()
+	time.Sleep(10 * time.Millisecond)
+	for len(reports) > 0 {
+		<-reports
+	}
+	clock.Advance(time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, reports, 0)
+}
+
+func TestNewPool_StatsReporterWithPanicContainment(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	calls := 0
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithSynchronous[MockResource](),
+		WithClock[MockResource](clock),
+		WithPanicContainment[MockResource](2),
+		WithStatsReporter[MockResource](time.Minute, func(Stats) {
+			calls++
+			panic("report failure")
+		}),
+	)
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Minute)
+		resource, _ := pool.Acquire(nil)
+		pool.Release(resource)
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(2), pool.Stats().HookPanics["StatsReporter"])
+}
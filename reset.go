@@ -0,0 +1,99 @@
+package pool
+
+import (
+	"reflect"
+)
+
+// WithReset sets a function sanitizing released resources before they are
+// returned to the idle pool, e.g. to truncate buffers, roll back open
+// transactions or clear session state. Resources it fails for are destroyed
+// instead of being reused. The reset runs while the pool mutex is held and is
+// covered by WithPanicContainment, a recovered panic counting as a failure;
+// with WithLocalCache, it runs before the resource enters the cache, without
+// the mutex or panic containment. Without it, resources whose type T
+// implements Reset() or Reset() error, such as *bytes.Buffer, are reset with
+// it; a reset doing nothing keeps their state.
+func WithReset[T comparable](reset func(T) error) Option[T] {
+	return func(n *NewPool[T]) {
+		n.resetter = reset
+	}
+}
+
+// resets a released resource; returns false if it can not be reused
+func (n *NewPool[T]) reset(resource T) bool {
+	if n.resetter == nil {
+		return true
+	}
+
+	isReset := false
+	n.callHook("Reset", func() {
+		err := n.resetter(resource)
+		if err != nil {
+			n.log(LogWarn, "failed to reset resource; not returning to idle resource pool",
+				Field{Key: "error", Value: err},
+			)
+		}
+		isReset = err == nil
+	})
+	return isReset
+}
+
+// resets a resource released into the local cache; destroys it if the reset
+// failed. Returns false if the resource can not be reused.
+func (n *NewPool[T]) resetLocal(resource T) bool {
+	if n.resetter == nil {
+		return true
+	}
+
+	err := n.resetter(resource)
+	if err == nil {
+		return true
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.log(LogWarn, "failed to reset resource; not returning to idle resource pool",
+		Field{Key: "error", Value: err},
+	)
+	n.evict(resource, &resourceEntry{}, EvictResetFailed)
+	return false
+}
+
+// resettable is a resource clearing its own state, such as *bytes.Buffer
+type resettable interface {
+	Reset()
+}
+
+// checkedResettable is a resource clearing its own state which may fail
+type checkedResettable interface {
+	Reset() error
+}
+
+var (
+	resettableType        = reflect.TypeOf((*resettable)(nil)).Elem()
+	checkedResettableType = reflect.TypeOf((*checkedResettable)(nil)).Elem()
+)
+
+// returns the reset used without WithReset: one calling Reset when T
+// implements Reset() or Reset() error, nil otherwise
+func getResetter[T any]() func(T) error {
+	resourceType := reflect.TypeOf((*T)(nil)).Elem()
+	switch {
+	case resourceType.Implements(resettableType):
+		return func(resource T) error {
+			if r, isResettable := any(resource).(resettable); isResettable {
+				r.Reset()
+			}
+			return nil
+		}
+	case resourceType.Implements(checkedResettableType):
+		return func(resource T) error {
+			if r, isResettable := any(resource).(checkedResettable); isResettable {
+				return r.Reset()
+			}
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_ReleaseWithReset(t *testing.T) {
+	testCases := []struct {
+		name            string
+		reset           func(MockResource) error
+		options         []Option[MockResource]
+		expectedIdle    int
+		expectedEvicted int64
+	}{
+		{
+			name:         "with successful reset returns resource to idle pool",
+			reset:        func(MockResource) error { return nil },
+			expectedIdle: 1,
+		},
+		{
+			name:            "with failed reset destroys resource",
+			reset:           func(MockResource) error { return errors.New("rollback failed") },
+			expectedEvicted: 1,
+		},
+		{
+			name:            "with panicking reset under containment destroys resource",
+			reset:           func(MockResource) error { panic("session state corrupt") },
+			options:         []Option[MockResource]{WithPanicContainment[MockResource](0)},
+			expectedEvicted: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var destroyed []MockResource
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, append(tc.options,
+				WithReset(tc.reset),
+				WithDestroyer(func(resource MockResource) error {
+					destroyed = append(destroyed, resource)
+					return nil
+				}),
+			)...)
+			resource, _ := pool.Acquire(nil)
+
+			pool.Release(resource)
+
+			stats := pool.Stats()
+			assert.Equal(t, tc.expectedIdle, stats.Idle)
+			assert.Equal(t, tc.expectedEvicted, stats.Evictions[EvictResetFailed])
+			assert.Len(t, destroyed, int(tc.expectedEvicted))
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithResetInReentrantScope(t *testing.T) {
+	resets := 0
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithReentrantAcquire[MockResource](),
+		WithReset(func(MockResource) error {
+			resets++
+			return nil
+		}),
+	)
+	ctx := ReentrantScope(context.Background())
+	outer, _ := pool.Acquire(ctx)
+	inner, _ := pool.Acquire(ctx)
+
+	pool.Release(inner)
+	assert.Equal(t, 0, resets)
+
+	pool.Release(outer)
+	assert.Equal(t, 1, resets)
+}
+
+func TestNewPool_TryReleaseWithResetAndLocalCache(t *testing.T) {
+	pool := New(getMockBufferCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithLocalCache[*MockBuffer](),
+		WithReset(func(buffer *MockBuffer) error {
+			if buffer.id == 1 {
+				return errors.New("buffer still referenced")
+			}
+			return nil
+		}),
+	)
+	buffer, _ := pool.Acquire(nil)
+
+	assert.NoError(t, pool.TryRelease(buffer))
+
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictResetFailed])
+	reacquired, _ := pool.Acquire(nil)
+	assert.NotEqual(t, buffer, reacquired)
+}
+
+// checkedResetResource is a resource whose Reset may fail
+type checkedResetResource struct {
+	id       int
+	resetErr error
+}
+
+func (r *checkedResetResource) Reset() error {
+	return r.resetErr
+}
+
+func TestNewPool_ReleaseWithResetMethod(t *testing.T) {
+	pool := New(func(context.Context) (*bytes.Buffer, error) { return &bytes.Buffer{}, nil }, maxIdleSize, maxIdleTime)
+	buffer, _ := pool.Acquire(nil)
+	buffer.WriteString("previous borrower")
+
+	pool.Release(buffer)
+
+	assert.Equal(t, 0, buffer.Len())
+	assert.Equal(t, 1, pool.NumIdle())
+}
+
+func TestNewPool_ReleaseWithCheckedResetMethod(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		resetErr               error
+		options                []Option[*checkedResetResource]
+		expectedIdlePoolLength int
+		expectedEvictions      int64
+	}{
+		{
+			name:                   "with successful reset keeps resource",
+			expectedIdlePoolLength: 1,
+		},
+		{
+			name:              "with failed reset destroys resource",
+			resetErr:          errors.New("reset failed"),
+			expectedEvictions: 1,
+		},
+		{
+			name:                   "with compatibility mode does not reset",
+			resetErr:               errors.New("reset failed"),
+			options:                []Option[*checkedResetResource]{CompatibilityV1[*checkedResetResource]()},
+			expectedIdlePoolLength: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creator := func(context.Context) (*checkedResetResource, error) {
+				return &checkedResetResource{id: 1, resetErr: tc.resetErr}, nil
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+
+			pool.Release(resource)
+
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+			assert.Equal(t, tc.expectedEvictions, pool.Stats().Evictions[EvictResetFailed])
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithResetOverridingResetMethod(t *testing.T) {
+	pool := New(func(context.Context) (*bytes.Buffer, error) { return &bytes.Buffer{}, nil }, maxIdleSize, maxIdleTime,
+		WithReset(func(*bytes.Buffer) error { return nil }),
+	)
+	buffer, _ := pool.Acquire(nil)
+	buffer.WriteString("kept")
+
+	pool.Release(buffer)
+
+	assert.Equal(t, "kept", buffer.String())
+}
+
+func TestGetResetter_WithoutMethod(t *testing.T) {
+	assert.Nil(t, getResetter[MockResource]())
+}
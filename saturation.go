@@ -0,0 +1,79 @@
+package pool
+
+// WithSaturationWatermarks calls onChange when the pool saturation, as
+// returned by Saturation, rises to high or more, with isSaturated set, and
+// again once it falls to low or less, so upstream admission control can shed
+// load before acquires start waiting. low should be below high, so the
+// signal does not flap. The crossings are also published as EventSaturated
+// and EventSaturationCleared; onChange may be nil to only publish them.
+// onChange runs under the pool mutex, so it must not call the pool.
+func WithSaturationWatermarks[T comparable](high float64, low float64, onChange func(isSaturated bool, saturation float64)) Option[T] {
+	return func(n *NewPool[T]) {
+		if onChange == nil {
+			onChange = func(bool, float64) {}
+		}
+		n.saturationHigh = high
+		n.saturationLow = low
+		n.onSaturation = onChange
+	}
+}
+
+// returns how close the pool is to making acquires wait, from 0 to 1: the
+// share of the WithMaxActive cap, or of the WithMaxCost budget, in use,
+// whichever is higher, and 1 while acquires are waiting. Without a cap or
+// budget it is 0 unless acquires wait.
+func (n *NewPool[T]) Saturation() float64 {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.getSaturation()
+}
+
+// returns the pool saturation; the pool mutex must be held
+func (n *NewPool[T]) getSaturation() float64 {
+	if n.waiters > 0 {
+		return 1
+	}
+
+	var saturation float64
+	if maxActive := n.getMaxActive(n.now()); maxActive > 0 {
+		saturation = float64(len(n.lock)+n.hedging+n.missCreating) / float64(maxActive)
+	}
+	if n.maxCost > 0 {
+		if costSaturation := float64(n.activeCost) / float64(n.maxCost); costSaturation > saturation {
+			saturation = costSaturation
+		}
+	}
+	if saturation > 1 {
+		return 1
+	}
+	return saturation
+}
+
+// calls the saturation callback and publishes its event if the saturation
+// crossed a watermark; the pool mutex must be held
+func (n *NewPool[T]) checkSaturation() {
+	if n.onSaturation == nil {
+		return
+	}
+
+	saturation := n.getSaturation()
+	switch {
+	case !n.isSaturated && saturation >= n.saturationHigh:
+		n.isSaturated = true
+	case n.isSaturated && saturation <= n.saturationLow:
+		n.isSaturated = false
+	default:
+		return
+	}
+
+	isSaturated := n.isSaturated
+	if isSaturated {
+		n.publish(EventSaturated, &resourceEntry{}, nil)
+	} else {
+		n.publish(EventSaturationCleared, &resourceEntry{}, nil)
+	}
+	n.callHook("Saturation", func() {
+		n.onSaturation(isSaturated, saturation)
+	})
+}
@@ -0,0 +1,87 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_Saturation(t *testing.T) {
+	testCases := []struct {
+		name               string
+		options            []Option[MockResource]
+		held               int
+		expectedSaturation float64
+	}{
+		{
+			name:               "without cap is not saturated",
+			held:               2,
+			expectedSaturation: 0,
+		},
+		{
+			name:               "with cap is share of cap in use",
+			options:            []Option[MockResource]{WithMaxActive[MockResource](4)},
+			held:               1,
+			expectedSaturation: 0.25,
+		},
+		{
+			name:               "at cap is saturated",
+			options:            []Option[MockResource]{WithMaxActive[MockResource](2)},
+			held:               2,
+			expectedSaturation: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, tc.options...)
+			for i := 0; i < tc.held; i++ {
+				pool.Acquire(nil)
+			}
+
+			assert.Equal(t, tc.expectedSaturation, pool.Saturation())
+		})
+	}
+}
+
+func TestNewPool_SaturationWatermarks(t *testing.T) {
+	var changes []bool
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](4),
+		WithSaturationWatermarks[MockResource](0.75, 0.25, func(isSaturated bool, saturation float64) {
+			changes = append(changes, isSaturated)
+		}),
+	)
+	var held []MockResource
+	for i := 0; i < 4; i++ {
+		resource, _ := pool.Acquire(nil)
+		held = append(held, resource)
+	}
+	assert.Equal(t, []bool{true}, changes)
+
+	pool.Release(held[0])
+	pool.Release(held[1])
+	assert.Equal(t, []bool{true}, changes)
+	pool.Release(held[2])
+
+	assert.Equal(t, []bool{true, false}, changes)
+}
+
+func TestNewPool_SaturationWatermarksEvents(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](2),
+		WithSaturationWatermarks[MockResource](1, 0, nil),
+	)
+	events := pool.Subscribe()
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+	pool.Release(second)
+
+	var eventTypes []EventType
+	for len(events) > 0 {
+		if event := <-events; event.Type == EventSaturated || event.Type == EventSaturationCleared {
+			eventTypes = append(eventTypes, event.Type)
+		}
+	}
+	assert.Equal(t, []EventType{EventSaturated, EventSaturationCleared}, eventTypes)
+}
@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"sync"
+)
+
+// defaultGoroutineLimit is the goroutine budget of a pool created without
+// WithScheduler
+const defaultGoroutineLimit = 8
+
+// Scheduler runs the background work of one or more pools on a bounded number
+// of goroutines. Goroutines are started on demand and exit once no work is
+// queued, so an idle scheduler holds none.
+type Scheduler struct {
+	mutex   sync.Mutex
+	limit   int
+	running int
+	queue   []func()
+}
+
+// SchedulerStats reports the goroutine budget and usage of a Scheduler
+type SchedulerStats struct {
+	// Limit is the maximum number of goroutines
+	Limit int
+	// Running is the number of goroutines currently running tasks
+	Running int
+	// Queued is the number of tasks waiting for a goroutine
+	Queued int
+}
+
+// WithScheduler runs the pool's background work on scheduler, which may be
+// shared with other pools to cap their goroutines together
+func WithScheduler[T comparable](scheduler *Scheduler) Option[T] {
+	return func(n *NewPool[T]) {
+		n.scheduler = scheduler
+	}
+}
+
+// runs task on a scheduler goroutine, queueing it while the limit is reached
+func (s *Scheduler) Go(task func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running >= s.limit {
+		s.queue = append(s.queue, task)
+		return
+	}
+
+	s.running++
+	go s.work(task)
+}
+
+// runs task on a scheduler goroutine if one is available right away; returns
+// false, without running task, if the limit is reached
+func (s *Scheduler) TryGo(task func()) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running >= s.limit {
+		return false
+	}
+
+	s.running++
+	go s.work(task)
+	return true
+}
+
+// returns the goroutine budget and usage
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return SchedulerStats{
+		Limit:   s.limit,
+		Running: s.running,
+		Queued:  len(s.queue),
+	}
+}
+
+// runs task, then queued tasks until the queue is empty
+func (s *Scheduler) work(task func()) {
+	for task != nil {
+		task()
+
+		s.mutex.Lock()
+		task = nil
+		if len(s.queue) > 0 {
+			task = s.queue[0]
+			s.queue = s.queue[1:]
+		} else {
+			s.running--
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// creates a scheduler running at most limit goroutines at once; limit is at
+// least 1
+func NewScheduler(limit int) *Scheduler {
+	if limit < 1 {
+		limit = 1
+	}
+
+	return &Scheduler{limit: limit}
+}
@@ -0,0 +1,94 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Go(t *testing.T) {
+	testCases := []struct {
+		name          string
+		limit         int
+		taskCount     int
+		expectedStats SchedulerStats
+	}{
+		{
+			name:          "with tasks below limit runs all of them",
+			limit:         3,
+			taskCount:     2,
+			expectedStats: SchedulerStats{Limit: 3, Running: 2},
+		},
+		{
+			name:          "with tasks above limit queues the rest",
+			limit:         2,
+			taskCount:     5,
+			expectedStats: SchedulerStats{Limit: 2, Running: 2, Queued: 3},
+		},
+		{
+			name:          "with limit below 1 runs one task at a time",
+			limit:         0,
+			taskCount:     2,
+			expectedStats: SchedulerStats{Limit: 1, Running: 1, Queued: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduler := NewScheduler(tc.limit)
+
+			var wg sync.WaitGroup
+			wg.Add(tc.taskCount)
+			block := make(chan struct{})
+			for i := 0; i < tc.taskCount; i++ {
+				scheduler.Go(func() {
+					defer wg.Done()
+					<-block
+				})
+			}
+
+			assert.Equal(t, tc.expectedStats, scheduler.Stats())
+			if tc.expectedStats.Running == tc.expectedStats.Limit {
+				assert.False(t, scheduler.TryGo(func() {}))
+			}
+
+			close(block)
+			wg.Wait()
+			assert.Eventually(t, func() bool {
+				return scheduler.Stats() == SchedulerStats{Limit: tc.expectedStats.Limit}
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestRaceDial_WithoutFreeGoroutine(t *testing.T) {
+	scheduler := NewScheduler(1)
+	block := make(chan struct{})
+	defer close(block)
+	scheduler.Go(func() { <-block })
+
+	dialErr := errors.New("dial error")
+	dial := func(ctx context.Context, endpoint string) (EndpointResource, error) {
+		if endpoint == "a" {
+			return EndpointResource{}, dialErr
+		}
+		return EndpointResource{endpoint: endpoint}, nil
+	}
+
+	resource, err := raceDial(context.Background(), "a", "b", time.Millisecond, dial, nil, scheduler, realClock{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, EndpointResource{endpoint: "b"}, resource)
+}
+
+func TestNewPool_WithScheduler(t *testing.T) {
+	scheduler := NewScheduler(2)
+	warmed := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithScheduler[MockResource](scheduler), WithWarmup[MockResource](1))
+	other := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithScheduler[MockResource](scheduler))
+
+	assert.Eventually(t, func() bool { return warmed.NumIdle() == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, 2, other.Stats().Goroutines.Limit)
+}
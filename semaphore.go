@@ -0,0 +1,49 @@
+package pool
+
+import (
+	"context"
+)
+
+// semaphore counts acquired slots of a capacity budget; a nil semaphore has
+// no limit
+type semaphore chan struct{}
+
+func newSemaphore(limit int) semaphore {
+	if limit <= 0 {
+		return nil
+	}
+
+	return make(semaphore, limit)
+}
+
+// takes a slot, waiting for one until ctx is done; with a nil ctx it returns
+// ErrPoolExhausted instead of waiting
+func (s semaphore) take(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	default:
+	}
+	if ctx == nil {
+		return ErrPoolExhausted
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return getWaitError(ctx)
+	}
+}
+
+// gives back a slot; extra calls are ignored
+func (s semaphore) give() {
+	select {
+	case <-s:
+	default:
+	}
+}
@@ -0,0 +1,237 @@
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Pool[PoolResource] = &ShardedPool[PoolResource]{}
+
+// ShardedPool spreads resources over independent pools, each with its own
+// mutex. Acquires pick shards round robin; idle resources are not moved
+// between shards. The shards share the pool-wide capacity budget set with
+// WithShardedMaxActive.
+//
+// Release and Invalidate look up the shard of a resource in a map shared by
+// all shards; AcquireResource returns a ShardedResource which knows its shard
+// and skips that lookup. Whether sharding beats a single pool depends on the
+// workload and the number of CPUs; compare BenchmarkNewPool_AcquireRelease and
+// BenchmarkShardedPool_AcquireResource with -cpu on the target machine.
+type ShardedPool[T comparable] struct {
+	shards []*NewPool[T]
+	next   atomic.Uint64
+	owners sync.Map
+	tokens semaphore
+
+	shardCount int
+	maxActive  int
+	options    []Option[T]
+}
+
+// ShardedOption configures optional sharded pool behavior
+type ShardedOption[T comparable] func(*ShardedPool[T])
+
+// WithShards sets the number of shards; defaults to GOMAXPROCS
+func WithShards[T comparable](count int) ShardedOption[T] {
+	return func(s *ShardedPool[T]) {
+		s.shardCount = count
+	}
+}
+
+// WithShardedMaxActive caps the number of acquired resources across all
+// shards. At capacity, Acquire waits for a release until ctx is done; with a
+// nil ctx it returns ErrPoolExhausted instead of waiting. A zero maxActive is
+// derived from GOMAXPROCS; a negative one does not cap acquires.
+func WithShardedMaxActive[T comparable](maxActive int) ShardedOption[T] {
+	return func(s *ShardedPool[T]) {
+		if maxActive == 0 {
+			maxActive = getDefaultMaxActive()
+		}
+		s.maxActive = maxActive
+	}
+}
+
+// WithShardOptions applies options to every shard
+func WithShardOptions[T comparable](options ...Option[T]) ShardedOption[T] {
+	return func(s *ShardedPool[T]) {
+		s.options = append(s.options, options...)
+	}
+}
+
+// ShardedResource is a resource acquired with AcquireResource, released
+// straight back to the shard it was acquired from
+type ShardedResource[T comparable] struct {
+	Resource T
+	pool     *ShardedPool[T]
+	shard    *NewPool[T]
+}
+
+// creates or returns a ready-to-use item from the next shard
+func (s *ShardedPool[T]) Acquire(ctx context.Context) (T, error) {
+	acquired, err := s.AcquireResource(ctx)
+	if err != nil {
+		return *new(T), err
+	}
+
+	s.owners.Store(acquired.Resource, acquired.shard)
+	return acquired.Resource, nil
+}
+
+// creates or returns a ready-to-use item from the next shard, tagged with the
+// shard; it is released with its own Release or Invalidate, not through the
+// pool
+func (s *ShardedPool[T]) AcquireResource(ctx context.Context) (ShardedResource[T], error) {
+	if err := s.tokens.take(ctx); err != nil {
+		return ShardedResource[T]{}, err
+	}
+
+	shard := s.shards[(s.next.Add(1)-1)%uint64(len(s.shards))]
+	resource, err := shard.Acquire(ctx)
+	if err != nil {
+		s.tokens.give()
+		return ShardedResource[T]{}, err
+	}
+
+	return ShardedResource[T]{Resource: resource, pool: s, shard: shard}, nil
+}
+
+// releases the resource back to its shard; returns ErrNotAcquired if it was
+// already released
+func (r ShardedResource[T]) Release() error {
+	if r.shard == nil {
+		return ErrNotAcquired
+	}
+
+	err := r.shard.TryRelease(r.Resource)
+	if err == nil {
+		r.pool.tokens.give()
+	}
+	return err
+}
+
+// destroys the resource instead of returning it to its shard; returns
+// ErrNotAcquired if it was already released
+func (r ShardedResource[T]) Invalidate() error {
+	if r.shard == nil {
+		return ErrNotAcquired
+	}
+
+	err := r.shard.Invalidate(r.Resource)
+	if err == nil {
+		r.pool.tokens.give()
+	}
+	return err
+}
+
+// releases an active resource back to the shard it was acquired from
+func (s *ShardedPool[T]) Release(resource T) {
+	s.TryRelease(resource)
+}
+
+// releases an active resource back to the shard it was acquired from; returns
+// ErrNotAcquired if the resource was not acquired from the pool
+func (s *ShardedPool[T]) TryRelease(resource T) error {
+	owner, isFound := s.owners.LoadAndDelete(resource)
+	if !isFound {
+		return ErrNotAcquired
+	}
+
+	err := owner.(*NewPool[T]).TryRelease(resource)
+	s.tokens.give()
+	return err
+}
+
+// destroys an acquired resource instead of returning it to its shard;
+// returns ErrNotAcquired if the resource was not acquired from the pool
+func (s *ShardedPool[T]) Invalidate(resource T) error {
+	owner, isFound := s.owners.LoadAndDelete(resource)
+	if !isFound {
+		return ErrNotAcquired
+	}
+
+	err := owner.(*NewPool[T]).Invalidate(resource)
+	s.tokens.give()
+	return err
+}
+
+// returns the number of idle items across all shards
+func (s *ShardedPool[T]) NumIdle() int {
+	idle := 0
+	for _, shard := range s.shards {
+		idle += shard.NumIdle()
+	}
+	return idle
+}
+
+// returns the stats of all shards combined
+func (s *ShardedPool[T]) Stats() Stats {
+	shardStats := make([]Stats, 0, len(s.shards))
+	for _, shard := range s.shards {
+		shardStats = append(shardStats, shard.Stats())
+	}
+	stats := MergeStats(shardStats...)
+	stats.Goroutines = s.shards[0].Stats().Goroutines
+	if s.maxActive > 0 {
+		stats.Cap = s.maxActive
+	}
+	return stats
+}
+
+// closes every shard and rejects further acquires
+func (s *ShardedPool[T]) Close() {
+	for _, shard := range s.shards {
+		shard.Close()
+	}
+}
+
+func NewSharded[T comparable](
+	// creator is a function called by the pool to create a resource.
+	creator func(context.Context) (T, error),
+	// maxIdleSize is the number of maximum idle items kept across all shards;
+	// zero is derived from GOMAXPROCS, a negative size keeps no idle items
+	maxIdleSize int,
+	// maxIdleTime is the maximum idle time for an idle item to be swept from the pool
+	maxIdleTime time.Duration,
+	// options configure optional behavior
+	options ...ShardedOption[T],
+) *ShardedPool[T] {
+	sharded := &ShardedPool[T]{
+		shardCount: runtime.GOMAXPROCS(0),
+	}
+
+	for _, option := range options {
+		option(sharded)
+	}
+
+	if sharded.shardCount < 1 {
+		sharded.shardCount = 1
+	}
+	sharded.tokens = newSemaphore(sharded.maxActive)
+
+	shardOptions := append([]Option[T]{WithScheduler[T](NewScheduler(defaultGoroutineLimit))}, sharded.options...)
+	for _, shardIdleSize := range getShardIdleSizes(getMaxIdleSize(maxIdleSize), sharded.shardCount) {
+		sharded.shards = append(sharded.shards, New(creator, shardIdleSize, maxIdleTime, shardOptions...))
+	}
+
+	return sharded
+}
+
+// returns the max idle sizes of count shards, splitting maxIdleSize evenly
+// with the remainder spread over the first shards; a shard without any
+// gets -1, which keeps no idle items
+func getShardIdleSizes(maxIdleSize int, count int) []int {
+	sizes := make([]int, count)
+	for i := range sizes {
+		sizes[i] = maxIdleSize / count
+		if i < maxIdleSize%count {
+			sizes[i]++
+		}
+		if sizes[i] == 0 {
+			sizes[i] = -1
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,211 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func getAtomicMockCreatorFunc() func(context.Context) (MockResource, error) {
+	var id atomic.Int64
+	return func(ctx context.Context) (MockResource, error) {
+		return MockResource{int(id.Add(1))}, nil
+	}
+}
+
+func TestShardedPool_AcquireRelease(t *testing.T) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 4, maxIdleTime, WithShards[MockResource](2))
+
+	first, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	second, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	assert.NoError(t, pool.TryRelease(first))
+	assert.NoError(t, pool.TryRelease(second))
+	assert.ErrorIs(t, pool.TryRelease(second), ErrNotAcquired)
+	assert.Equal(t, 2, pool.NumIdle())
+
+	// round robin comes back to the shard holding the first resource
+	reused, err := pool.Acquire(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, first, reused)
+
+	stats := pool.Stats()
+	assert.Equal(t, int64(3), stats.Acquires)
+	assert.Equal(t, int64(2), stats.Created)
+	assert.Equal(t, int64(1), stats.Reused)
+	assert.Equal(t, 1, stats.Idle)
+	assert.Equal(t, 1, stats.Active)
+}
+
+func TestShardedPool_AcquireResource(t *testing.T) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 4, maxIdleTime,
+		WithShards[MockResource](2),
+		WithShardedMaxActive[MockResource](1),
+	)
+
+	acquired, err := pool.AcquireResource(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, MockResource{id: 1}, acquired.Resource)
+	_, err = pool.AcquireResource(nil)
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+
+	assert.NoError(t, acquired.Release())
+	assert.ErrorIs(t, acquired.Release(), ErrNotAcquired)
+	assert.ErrorIs(t, ShardedResource[MockResource]{}.Release(), ErrNotAcquired)
+	assert.Equal(t, 1, pool.NumIdle())
+
+	acquired, err = pool.AcquireResource(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, acquired.Invalidate())
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictInvalidated])
+	_, err = pool.AcquireResource(nil)
+	assert.NoError(t, err)
+}
+
+func TestNewSharded_IdleCap(t *testing.T) {
+	testCases := []struct {
+		name          string
+		maxIdleSize   int
+		shards        int
+		expectedSizes []int
+	}{
+		{
+			name:          "with even split",
+			maxIdleSize:   4,
+			shards:        2,
+			expectedSizes: []int{2, 2},
+		},
+		{
+			name:          "with remainder spread over first shards",
+			maxIdleSize:   5,
+			shards:        3,
+			expectedSizes: []int{2, 2, 1},
+		},
+		{
+			name:          "with fewer idle items than shards",
+			maxIdleSize:   1,
+			shards:        3,
+			expectedSizes: []int{1, -1, -1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSizes, getShardIdleSizes(tc.maxIdleSize, tc.shards))
+
+			pool := NewSharded(getAtomicMockCreatorFunc(), tc.maxIdleSize, maxIdleTime, WithShards[MockResource](tc.shards))
+			assert.Equal(t, tc.maxIdleSize, pool.Stats().IdleCap)
+		})
+	}
+}
+
+func TestShardedPool_MaxActive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           func() (context.Context, context.CancelFunc)
+		expectedError error
+	}{
+		{
+			name:          "with nil ctx fails fast",
+			ctx:           func() (context.Context, context.CancelFunc) { return nil, func() {} },
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name: "with deadline times out",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			expectedError: ErrAcquireTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := NewSharded(getAtomicMockCreatorFunc(), 4, maxIdleTime,
+				WithShards[MockResource](4),
+				WithShardedMaxActive[MockResource](2),
+			)
+			pool.Acquire(nil)
+			pool.Acquire(nil)
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			_, err := pool.Acquire(ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestShardedPool_MaxActiveWaitsForRelease(t *testing.T) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 4, maxIdleTime,
+		WithShards[MockResource](2),
+		WithShardedMaxActive[MockResource](1),
+	)
+	first, _ := pool.Acquire(nil)
+
+	acquired := make(chan error)
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		acquired <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pool.Release(first)
+
+	assert.NoError(t, <-acquired)
+}
+
+func TestShardedPool_Close(t *testing.T) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 4, maxIdleTime, WithShards[MockResource](2))
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	pool.Close()
+	_, err := pool.Acquire(nil)
+
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, 0, pool.NumIdle())
+}
+
+func BenchmarkNewPool_AcquireRelease(b *testing.B) {
+	pool := New(getAtomicMockCreatorFunc(), 1024, time.Minute)
+	benchmarkAcquireRelease(b, pool)
+}
+
+func BenchmarkShardedPool_AcquireRelease(b *testing.B) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 1024, time.Minute)
+	benchmarkAcquireRelease(b, pool)
+}
+
+func BenchmarkShardedPool_AcquireResource(b *testing.B) {
+	pool := NewSharded(getAtomicMockCreatorFunc(), 1024, time.Minute)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			acquired, err := pool.AcquireResource(nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			acquired.Release()
+		}
+	})
+}
+
+func benchmarkAcquireRelease(b *testing.B, pool Pool[MockResource]) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			resource, err := pool.Acquire(nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pool.Release(resource)
+		}
+	})
+}
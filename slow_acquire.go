@@ -0,0 +1,39 @@
+package pool
+
+import (
+	"time"
+)
+
+// SlowAcquire describes an acquire which waited longer than the threshold of
+// WithSlowAcquireThreshold
+type SlowAcquire struct {
+	// Wait is the time the acquire spent waiting, over all its waits
+	Wait time.Duration
+	// Stats is a snapshot of the pool at the end of the wait
+	Stats Stats
+}
+
+// WithSlowAcquireThreshold calls onSlow when an acquire waited longer than
+// threshold for a resource, whether it got one or not, as an early signal of
+// saturation before acquires start timing out. onSlow runs under the pool
+// mutex once the wait is over, so it must not call the pool; the pool state
+// is passed in SlowAcquire.
+func WithSlowAcquireThreshold[T comparable](threshold time.Duration, onSlow func(SlowAcquire)) Option[T] {
+	return func(n *NewPool[T]) {
+		n.slowAcquireThreshold = threshold
+		n.onSlowAcquire = onSlow
+	}
+}
+
+// calls the slow acquire callback if wait exceeds its threshold; the pool
+// mutex must be held
+func (n *NewPool[T]) reportSlowAcquire(wait time.Duration) {
+	if n.onSlowAcquire == nil || wait <= n.slowAcquireThreshold {
+		return
+	}
+
+	slow := SlowAcquire{Wait: wait, Stats: n.getStats()}
+	n.callHook("SlowAcquire", func() {
+		n.onSlowAcquire(slow)
+	})
+}
@@ -0,0 +1,66 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_SlowAcquire(t *testing.T) {
+	testCases := []struct {
+		name           string
+		threshold      time.Duration
+		expectedReport bool
+	}{
+		{
+			name:           "with wait past threshold reports slow acquire",
+			threshold:      time.Millisecond,
+			expectedReport: true,
+		},
+		{
+			name:      "with wait below threshold does not report",
+			threshold: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reports []SlowAcquire
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithMaxActive[MockResource](1),
+				WithSlowAcquireThreshold[MockResource](tc.threshold, func(slow SlowAcquire) {
+					reports = append(reports, slow)
+				}),
+			)
+			pool.Acquire(nil)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			_, err := pool.Acquire(ctx)
+
+			assert.ErrorIs(t, err, ErrAcquireTimeout)
+			if !tc.expectedReport {
+				assert.Empty(t, reports)
+				return
+			}
+			assert.Len(t, reports, 1)
+			assert.GreaterOrEqual(t, reports[0].Wait, tc.threshold)
+			assert.Equal(t, 1, reports[0].Stats.Active)
+			assert.Equal(t, int64(1), reports[0].Stats.Waits)
+		})
+	}
+}
+
+func TestNewPool_SlowAcquireWithoutWait(t *testing.T) {
+	isReported := false
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithSlowAcquireThreshold[MockResource](0, func(SlowAcquire) {
+			isReported = true
+		}),
+	)
+
+	pool.Acquire(context.Background())
+
+	assert.False(t, isReported)
+}
@@ -0,0 +1,71 @@
+package pool
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// WithSlowStart ramps the WithMaxActive cap up from initial to the configured
+// maximum over window, after the pool is created and again once the backend
+// recovers, i.e. a creation succeeds after failed ones, so reconnecting
+// clients do not stampede a database. Acquires above the ramped cap wait for
+// it to grow, or for a release. It has no effect without WithMaxActive.
+func WithSlowStart[T comparable](initial int, window time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		if initial < 1 {
+			initial = 1
+		}
+		n.slowStartInitial = initial
+		n.slowStartWindow = window
+	}
+}
+
+// returns the cap of acquired resources at now, ramped by WithSlowStart;
+// zero means no limit
+func (n *NewPool[T]) getMaxActive(now time.Time) int {
+	if !n.isSlowStarting(now) {
+		return n.maxActive
+	}
+
+	elapsed := now.Sub(n.slowStartAt)
+	ramp := float64(n.maxActive-n.slowStartInitial) * float64(elapsed) / float64(n.slowStartWindow)
+	return n.slowStartInitial + int(ramp)
+}
+
+// reports whether the cap of acquired resources is still ramping up at now
+func (n *NewPool[T]) isSlowStarting(now time.Time) bool {
+	return n.slowStartWindow > 0 && n.maxActive > n.slowStartInitial && now.Sub(n.slowStartAt) < n.slowStartWindow
+}
+
+// returns the time until the ramped cap admits count more resources; zero if
+// it already does or only a release can make room
+func (n *NewPool[T]) getSlowStartDelay(count int) time.Duration {
+	now := n.now()
+	if !n.isSlowStarting(now) {
+		return 0
+	}
+
+	needed := len(n.lock) + n.hedging + n.missCreating + count
+	if needed > n.maxActive || needed <= n.getMaxActive(now) {
+		return 0
+	}
+	offset := float64(n.slowStartWindow) * float64(needed-n.slowStartInitial) / float64(n.maxActive-n.slowStartInitial)
+	return n.slowStartAt.Add(time.Duration(math.Ceil(offset))).Sub(now)
+}
+
+// restarts the ramp of the cap, e.g. once the backend recovered
+func (n *NewPool[T]) restartSlowStart() {
+	if n.slowStartWindow > 0 {
+		n.slowStartAt = n.now()
+	}
+}
+
+// waits for room for count more acquired resources: for a release, or for
+// the ramped cap to grow; the pool mutex is released while waiting
+func (n *NewPool[T]) waitCapacity(ctx context.Context, wait *acquireWait, count int) error {
+	if delay := n.getSlowStartDelay(count); delay > 0 {
+		return n.waitCreateRate(ctx, wait, delay)
+	}
+	return n.waitAcquire(ctx, wait)
+}
@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithSlowStart(t *testing.T) {
+	testCases := []struct {
+		name          string
+		elapsed       time.Duration
+		held          int
+		expectedError error
+	}{
+		{
+			name:          "at start caps to initial",
+			held:          1,
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name:    "halfway through window ramps cap",
+			elapsed: time.Second,
+			held:    1,
+		},
+		{
+			name:          "halfway through window caps to ramped cap",
+			elapsed:       time.Second,
+			held:          2,
+			expectedError: ErrPoolExhausted,
+		},
+		{
+			name:    "after window allows max active",
+			elapsed: 2 * time.Second,
+			held:    2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithMaxActive[MockResource](3),
+				WithSlowStart[MockResource](1, 2*time.Second),
+			)
+			clock.Advance(tc.elapsed)
+			for i := 0; i < tc.held; i++ {
+				pool.Acquire(nil)
+			}
+
+			_, err := pool.Acquire(nil)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNewPool_AcquireWithSlowStartWaitsForRamp(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMaxActive[MockResource](3),
+		WithSlowStart[MockResource](1, 2*time.Second),
+	)
+	pool.Acquire(nil)
+	errs := make(chan error)
+	go func() {
+		_, err := pool.Acquire(context.Background())
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+
+	clock.Advance(time.Second)
+
+	assert.NoError(t, <-errs)
+}
+
+func TestNewPool_AcquireWithSlowStartRestartsOnRecovery(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	isFailing := false
+	mockCreator := getMockCreatorFunc()
+	creator := func(ctx context.Context) (MockResource, error) {
+		if isFailing {
+			return MockResource{}, errors.New("error response")
+		}
+		return mockCreator(ctx)
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMaxActive[MockResource](3),
+		WithSlowStart[MockResource](1, 2*time.Second),
+	)
+	clock.Advance(2 * time.Second)
+	isFailing = true
+	pool.Acquire(nil)
+	isFailing = false
+	pool.Acquire(nil)
+
+	_, err := pool.Acquire(nil)
+
+	assert.Equal(t, ErrPoolExhausted, err)
+}
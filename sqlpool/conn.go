@@ -0,0 +1,190 @@
+package sqlpool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	pool "example/ptran"
+	"sync"
+)
+
+// errNonDefaultTx is returned by BeginTx for transaction options the base
+// connection does not support
+var errNonDefaultTx = errors.New("sqlpool: driver does not support non-default transaction options")
+
+// conn is a connection acquired from a Connector. It forwards the optional
+// driver interfaces to the base connection, falling back the way database/sql
+// does for connections not implementing them. Once closed, it fails with
+// driver.ErrBadConn, as the base connection is back in the pool.
+type conn struct {
+	driver.Conn
+	pool *pool.NewPool[driver.Conn]
+
+	mutex      sync.Mutex
+	isBad      bool
+	isReleased bool
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	stmt, err := c.Conn.Prepare(query)
+	return stmt, c.recordError(err)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	preparer, isPreparer := c.Conn.(driver.ConnPrepareContext)
+	if !isPreparer {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return c.Prepare(query)
+	}
+
+	stmt, err := preparer.PrepareContext(ctx, query)
+	return stmt, c.recordError(err)
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	tx, err := c.Conn.Begin()
+	return tx, c.recordError(err)
+}
+
+func (c *conn) BeginTx(ctx context.Context, options driver.TxOptions) (driver.Tx, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	beginner, isBeginner := c.Conn.(driver.ConnBeginTx)
+	if !isBeginner {
+		if options.Isolation != 0 || options.ReadOnly {
+			return nil, errNonDefaultTx
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return c.Begin()
+	}
+
+	tx, err := beginner.BeginTx(ctx, options)
+	return tx, c.recordError(err)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	execer, isExecer := c.Conn.(driver.ExecerContext)
+	if !isExecer {
+		return nil, driver.ErrSkip
+	}
+
+	result, err := execer.ExecContext(ctx, query, args)
+	return result, c.recordError(err)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.getReleasedError(); err != nil {
+		return nil, err
+	}
+	queryer, isQueryer := c.Conn.(driver.QueryerContext)
+	if !isQueryer {
+		return nil, driver.ErrSkip
+	}
+
+	rows, err := queryer.QueryContext(ctx, query, args)
+	return rows, c.recordError(err)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if err := c.getReleasedError(); err != nil {
+		return err
+	}
+	pinger, isPinger := c.Conn.(driver.Pinger)
+	if !isPinger {
+		return nil
+	}
+	return c.recordError(pinger.Ping(ctx))
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+
+	resetter, isResetter := c.Conn.(driver.SessionResetter)
+	if !isResetter {
+		return nil
+	}
+	return c.recordError(resetter.ResetSession(ctx))
+}
+
+func (c *conn) IsValid() bool {
+	c.mutex.Lock()
+	isBad := c.isBad || c.isReleased
+	c.mutex.Unlock()
+	if isBad {
+		return false
+	}
+
+	validator, isValidator := c.Conn.(driver.Validator)
+	return !isValidator || validator.IsValid()
+}
+
+func (c *conn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, isChecker := c.Conn.(driver.NamedValueChecker)
+	if !isChecker {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+
+// releases the connection back to the pool, or destroys it if it is bad;
+// closing it twice is a no-op
+func (c *conn) Close() error {
+	isValid := c.IsValid()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.isReleased {
+		return nil
+	}
+	c.isReleased = true
+
+	if !isValid {
+		return c.pool.Invalidate(c.Conn)
+	}
+	return c.pool.TryRelease(c.Conn)
+}
+
+// returns driver.ErrBadConn once the connection was closed, as another
+// caller may hold the base connection since
+func (c *conn) getReleasedError() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.isReleased {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// marks the connection bad if err is driver.ErrBadConn; returns err
+func (c *conn) recordError(err error) error {
+	if !errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isBad = true
+	return err
+}
@@ -0,0 +1,155 @@
+// Package sqlpool puts the resource pool underneath database/sql, so its
+// policies, such as warmup, caps and quotas, apply to database connections.
+package sqlpool
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	pool "example/ptran"
+	"time"
+)
+
+// Connector is a driver.Connector drawing connections from a pool of
+// connections created by a base connector. Closing a connection returned by
+// Connect releases it back to the pool, so the pool, and not database/sql,
+// decides which connections are kept: open the database with sql.OpenDB and
+// call SetMaxIdleConns(0) on it to hand every idle connection back.
+//
+// Connections are reset with driver.SessionResetter before they are reused,
+// and checked with driver.Validator. A connection that reported
+// driver.ErrBadConn, failed its reset or is no longer valid is destroyed
+// instead of being reused.
+type Connector struct {
+	base     driver.Connector
+	pool     *pool.NewPool[driver.Conn]
+	options  []pool.Option[driver.Conn]
+	isPinged bool
+}
+
+// Option configures optional connector behavior
+type Option func(*Connector)
+
+// WithPoolOptions passes options to the underlying resource pool, e.g.
+// pool.WithMaxActive
+func WithPoolOptions(options ...pool.Option[driver.Conn]) Option {
+	return func(c *Connector) {
+		c.options = append(c.options, options...)
+	}
+}
+
+// WithPing pings idle connections with driver.Pinger before they are reused;
+// connections failing the ping are destroyed and Connect moves on to the next
+// one
+func WithPing() Option {
+	return func(c *Connector) {
+		c.isPinged = true
+	}
+}
+
+// acquires an idle connection, or connects a new one with the base connector.
+// A failed connect, a *pool.CreateError, also matches driver.ErrBadConn, so
+// database/sql retries it; errors.As still finds the CreateError. Other pool
+// errors, e.g. pool.ErrPoolExhausted, and context errors are returned
+// unchanged, so database/sql does not retry them.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	for {
+		pooled, info, err := c.pool.AcquireWithInfo(ctx)
+		if err != nil {
+			return nil, getConnectError(err)
+		}
+		if !info.IsReused || c.isReusable(ctx, pooled) {
+			return &conn{Conn: pooled, pool: c.pool}, nil
+		}
+		c.pool.Invalidate(pooled)
+	}
+}
+
+// returns the driver of the base connector
+func (c *Connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// returns the underlying resource pool, e.g. to register it with a collector
+func (c *Connector) Pool() *pool.NewPool[driver.Conn] {
+	return c.pool
+}
+
+// closes the idle connections and rejects further connects; called by
+// sql.DB.Close
+func (c *Connector) Close() error {
+	c.pool.Close()
+	return nil
+}
+
+// badConnError is a pool error matching driver.ErrBadConn, so database/sql
+// retries the operation with another connection
+type badConnError struct {
+	err error
+}
+
+func (e *badConnError) Error() string {
+	return e.err.Error()
+}
+
+func (e *badConnError) Unwrap() error {
+	return e.err
+}
+
+func (e *badConnError) Is(target error) bool {
+	return target == driver.ErrBadConn
+}
+
+// maps an acquire error onto driver.ErrBadConn semantics: failed creations
+// are bad connections, unless the context ended them
+func getConnectError(err error) error {
+	var createErr *pool.CreateError
+	if !errors.As(err, &createErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &badConnError{err: err}
+}
+
+// reports whether an idle connection can be handed out again
+func (c *Connector) isReusable(ctx context.Context, pooled driver.Conn) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if resetter, isResetter := pooled.(driver.SessionResetter); isResetter && resetter.ResetSession(ctx) != nil {
+		return false
+	}
+	if validator, isValidator := pooled.(driver.Validator); isValidator && !validator.IsValid() {
+		return false
+	}
+	if pinger, isPinger := pooled.(driver.Pinger); c.isPinged && isPinger && pinger.Ping(ctx) != nil {
+		return false
+	}
+	return true
+}
+
+// creates a connector drawing connections from a pool of connections created
+// by base
+func New(
+	// base is called by the pool to create a connection
+	base driver.Connector,
+	// maxIdleSize is the number of maximum idle connections kept in the pool
+	maxIdleSize int,
+	// maxIdleTime is the maximum time a connection can be idle before it is closed
+	maxIdleTime time.Duration,
+	options ...Option,
+) *Connector {
+	c := &Connector{base: base}
+	for _, option := range options {
+		option(c)
+	}
+
+	creator := func(ctx context.Context) (driver.Conn, error) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return base.Connect(ctx)
+	}
+	destroyer := pool.WithDestroyer(func(conn driver.Conn) error { return conn.Close() })
+	c.pool = pool.New(creator, maxIdleSize, maxIdleTime, append([]pool.Option[driver.Conn]{destroyer}, c.options...)...)
+	return c
+}
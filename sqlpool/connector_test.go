@@ -0,0 +1,227 @@
+package sqlpool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const maxIdleSize = 3
+const maxIdleTime = 5 * time.Second
+
+// MockConn is a driver connection failing the operations it is told to
+type MockConn struct {
+	execErr  error
+	resetErr error
+	pingErr  error
+	isClosed bool
+}
+
+func (c *MockConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *MockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *MockConn) Close() error {
+	c.isClosed = true
+	return nil
+}
+
+func (c *MockConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), c.execErr
+}
+
+func (c *MockConn) ResetSession(context.Context) error {
+	return c.resetErr
+}
+
+func (c *MockConn) Ping(context.Context) error {
+	return c.pingErr
+}
+
+// MockConnector connects MockConns, handing them to configure first, or
+// fails with connectErr
+type MockConnector struct {
+	conns      []*MockConn
+	configure  func(*MockConn)
+	connectErr error
+	attempts   int
+}
+
+func (c *MockConnector) Connect(context.Context) (driver.Conn, error) {
+	c.attempts++
+	if c.connectErr != nil {
+		return nil, c.connectErr
+	}
+	conn := &MockConn{}
+	if c.configure != nil && len(c.conns) == 0 {
+		c.configure(conn)
+	}
+	c.conns = append(c.conns, conn)
+	return conn, nil
+}
+
+func (c *MockConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestConnector_Exec(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         []Option
+		configure       func(*MockConn)
+		expectedConns   int
+		expectedEvicted int64
+	}{
+		{
+			name:          "with healthy connection reuses it",
+			expectedConns: 1,
+		},
+		{
+			name:            "with bad connection retries on new connection",
+			configure:       func(conn *MockConn) { conn.execErr = driver.ErrBadConn },
+			expectedConns:   2,
+			expectedEvicted: 1,
+		},
+		{
+			name:            "with failed session reset connects new connection",
+			configure:       func(conn *MockConn) { conn.resetErr = driver.ErrBadConn },
+			expectedConns:   2,
+			expectedEvicted: 1,
+		},
+		{
+			name:          "with failed ping without ping option reuses connection",
+			configure:     func(conn *MockConn) { conn.pingErr = errors.New("broken pipe") },
+			expectedConns: 1,
+		},
+		{
+			name:            "with failed ping connects new connection",
+			options:         []Option{WithPing()},
+			configure:       func(conn *MockConn) { conn.pingErr = errors.New("broken pipe") },
+			expectedConns:   2,
+			expectedEvicted: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			base := &MockConnector{configure: tc.configure}
+			connector := New(base, maxIdleSize, maxIdleTime, tc.options...)
+			db := sql.OpenDB(connector)
+			db.SetMaxIdleConns(0)
+
+			for i := 0; i < 2; i++ {
+				db.ExecContext(context.Background(), "UPDATE jobs SET state = 'done'")
+			}
+
+			assert.Len(t, base.conns, tc.expectedConns)
+			assert.Equal(t, tc.expectedEvicted, connector.Pool().Stats().Evictions[pool.EvictInvalidated])
+			assert.Equal(t, 0, connector.Pool().Stats().Active)
+		})
+	}
+}
+
+func TestConnector_ConnectWithPoolError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		connectErr    error
+		setup         func(*Connector)
+		expectedError error
+		isBadConn     bool
+	}{
+		{
+			name:          "with failed connect returns bad connection",
+			connectErr:    errors.New("connection refused"),
+			expectedError: driver.ErrBadConn,
+			isBadConn:     true,
+		},
+		{
+			name:          "with connect ended by context returns context error",
+			connectErr:    context.DeadlineExceeded,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			name: "with exhausted pool returns exhausted",
+			setup: func(connector *Connector) {
+				connector.Connect(nil)
+			},
+			expectedError: pool.ErrPoolExhausted,
+		},
+		{
+			name:          "with closed pool returns closed",
+			setup:         func(connector *Connector) { connector.Close() },
+			expectedError: pool.ErrPoolClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			connector := New(&MockConnector{connectErr: tc.connectErr}, maxIdleSize, maxIdleTime,
+				WithPoolOptions(pool.WithMaxActive[driver.Conn](1)),
+			)
+			if tc.setup != nil {
+				tc.setup(connector)
+			}
+
+			_, err := connector.Connect(nil)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if !tc.isBadConn {
+				assert.NotErrorIs(t, err, driver.ErrBadConn)
+				return
+			}
+			var createErr *pool.CreateError
+			assert.ErrorAs(t, err, &createErr)
+		})
+	}
+}
+
+func TestConnector_ConnectRetriesFailedConnect(t *testing.T) {
+	base := &MockConnector{connectErr: errors.New("connection refused")}
+	db := sql.OpenDB(New(base, maxIdleSize, maxIdleTime))
+
+	_, err := db.ExecContext(context.Background(), "DELETE FROM jobs")
+
+	var createErr *pool.CreateError
+	assert.ErrorAs(t, err, &createErr)
+	// database/sql retries bad connections before giving up
+	assert.Greater(t, base.attempts, 1)
+}
+
+func TestConn_UseAfterClose(t *testing.T) {
+	connector := New(&MockConnector{}, maxIdleSize, maxIdleTime)
+	conn, err := connector.Connect(nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	_, err = conn.(driver.ExecerContext).ExecContext(context.Background(), "DELETE FROM jobs", nil)
+
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.False(t, conn.(driver.Validator).IsValid())
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, 1, connector.Pool().NumIdle())
+}
+
+func TestConnector_Close(t *testing.T) {
+	base := &MockConnector{}
+	connector := New(base, maxIdleSize, maxIdleTime)
+	db := sql.OpenDB(connector)
+	db.SetMaxIdleConns(0)
+	db.ExecContext(context.Background(), "DELETE FROM jobs")
+
+	assert.NoError(t, db.Close())
+
+	require.Len(t, base.conns, 1)
+	assert.True(t, base.conns[0].isClosed)
+	_, err := connector.Connect(nil)
+	assert.ErrorIs(t, err, pool.ErrPoolClosed)
+}
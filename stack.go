@@ -0,0 +1,229 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Pool[PoolResource] = &StackPool[PoolResource]{}
+
+// StackPool is a pool for very hot paths: idle resources wait on a lock-free
+// stack, pushed and popped with atomic compare-and-swap, and the mutex is only
+// taken by the slow paths, sweeping expired resources, resizing and closing.
+// The most recently released resource is reused first. Like ChannelPool,
+// resources are not tracked while acquired.
+type StackPool[T comparable] struct {
+	creator     func(context.Context) (T, error)
+	maxIdleTime time.Duration
+	maxIdleSize atomic.Int64
+	destroyer   func(T) error
+	clock       Clock
+	head        atomic.Pointer[stackNode[T]]
+	size        atomic.Int64
+	tokens      semaphore
+	mutex       sync.Mutex
+	isClosed    atomic.Bool
+}
+
+// stackNode is an idle resource on the stack. A node is pushed only once, so
+// a pop racing with other pops and pushes can not swap in a stale next node.
+type stackNode[T any] struct {
+	idleResource[T]
+	next *stackNode[T]
+}
+
+// returns an idle resource, or creates one if none is available, waiting for
+// a release while the pool is at capacity
+func (s *StackPool[T]) Acquire(ctx context.Context) (T, error) {
+	if s.isClosed.Load() {
+		return *new(T), ErrPoolClosed
+	}
+	if err := s.tokens.take(ctx); err != nil {
+		return *new(T), err
+	}
+
+	for node := s.pop(); node != nil; node = s.pop() {
+		if !node.releasedAt.Before(s.getValidTimestamp()) {
+			return node.resource, nil
+		}
+		// the resources below were released before this one, so they expired
+		// too
+		s.destroy(node.resource)
+		s.sweep()
+	}
+
+	resource, err := s.creator(ctx)
+	if err != nil {
+		s.tokens.give()
+		return *new(T), &CreateError{Err: err}
+	}
+	return resource, nil
+}
+
+// releases an active resource back to the idle stack, destroying it if the
+// stack is full or the pool is closed
+func (s *StackPool[T]) Release(resource T) {
+	defer s.tokens.give()
+
+	if s.isClosed.Load() || !s.reserve() {
+		s.destroy(resource)
+		return
+	}
+
+	s.push(&stackNode[T]{idleResource: idleResource[T]{resource: resource, releasedAt: s.clock.Now()}})
+	// a Close racing with the push may have drained the stack before it
+	if s.isClosed.Load() {
+		s.drain()
+	}
+}
+
+// returns the number of idle items
+func (s *StackPool[T]) NumIdle() int {
+	return int(s.size.Load())
+}
+
+// sets the maximum number of idle items, destroying the least recently
+// released idle resources past it
+func (s *StackPool[T]) SetMaxIdleSize(size int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.maxIdleSize.Store(int64(size))
+	s.retain(func(position int, _ idleResource[T]) bool {
+		return position < size
+	})
+}
+
+// destroys the idle resources and rejects further acquires; resources
+// released afterwards are destroyed
+func (s *StackPool[T]) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.isClosed.Store(true)
+	s.drain()
+}
+
+// counts a resource about to be pushed; reports false if the stack is full
+func (s *StackPool[T]) reserve() bool {
+	for {
+		size := s.size.Load()
+		if size >= s.maxIdleSize.Load() {
+			return false
+		}
+		if s.size.CompareAndSwap(size, size+1) {
+			return true
+		}
+	}
+}
+
+// pushes a reserved node on top of the stack
+func (s *StackPool[T]) push(node *stackNode[T]) {
+	for {
+		head := s.head.Load()
+		node.next = head
+		if s.head.CompareAndSwap(head, node) {
+			return
+		}
+	}
+}
+
+// pops the top node of the stack; nil if the stack is empty
+func (s *StackPool[T]) pop() *stackNode[T] {
+	for {
+		head := s.head.Load()
+		if head == nil {
+			return nil
+		}
+		if s.head.CompareAndSwap(head, head.next) {
+			s.size.Add(-1)
+			return head
+		}
+	}
+}
+
+// destroys the expired idle resources
+func (s *StackPool[T]) sweep() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	validTimestamp := s.getValidTimestamp()
+	s.retain(func(_ int, idle idleResource[T]) bool {
+		return !idle.releasedAt.Before(validTimestamp)
+	})
+}
+
+// keeps the idle resources accepted by isKept, given their position from the
+// top, and destroys the others. The stack is taken off at once, so acquires
+// and releases meanwhile see an empty stack, and the kept resources are
+// pushed back in new nodes in their order. The mutex must be held.
+func (s *StackPool[T]) retain(isKept func(position int, idle idleResource[T]) bool) {
+	var kept []idleResource[T]
+	position := 0
+	for node := s.head.Swap(nil); node != nil; node = node.next {
+		if isKept(position, node.idleResource) {
+			kept = append(kept, node.idleResource)
+		} else {
+			s.size.Add(-1)
+			s.destroy(node.resource)
+		}
+		position++
+	}
+	for i := len(kept) - 1; i >= 0; i-- {
+		s.push(&stackNode[T]{idleResource: kept[i]})
+	}
+}
+
+// destroys every idle resource
+func (s *StackPool[T]) drain() {
+	for node := s.head.Swap(nil); node != nil; node = node.next {
+		s.size.Add(-1)
+		s.destroy(node.resource)
+	}
+}
+
+func (s *StackPool[T]) getValidTimestamp() time.Time {
+	return s.clock.Now().Add(-1 * s.maxIdleTime)
+}
+
+func (s *StackPool[T]) destroy(resource T) {
+	if s.destroyer != nil {
+		s.destroyer(resource)
+	}
+}
+
+// NewStack creates a StackPool. Of the options, it supports WithMaxActive,
+// WithDestroyer and WithClock, and CompatibilityV1 only keeps io.Closer
+// resources from being closed; other options have no effect.
+func NewStack[T comparable](
+	// creator is a function called by the pool to create a resource.
+	creator func(context.Context) (T, error),
+	// maxIdleSize is the number of maximum idle items kept in the pool; zero
+	// is derived from GOMAXPROCS, a negative size keeps no idle items
+	maxIdleSize int,
+	// maxIdleTime is the maximum idle time for an idle item to be swept from the pool
+	maxIdleTime time.Duration,
+	// options configure optional behavior
+	options ...Option[T],
+) *StackPool[T] {
+	config := &NewPool[T]{}
+	for _, option := range options {
+		option(config)
+	}
+	destroyer := config.destroyer
+	if !config.compatibilityV1 {
+		destroyer = config.getDestroyer()
+	}
+
+	stack := &StackPool[T]{
+		creator:     creator,
+		maxIdleTime: maxIdleTime,
+		destroyer:   destroyer,
+		clock:       config.getClock(),
+		tokens:      newSemaphore(config.maxActive),
+	}
+	stack.maxIdleSize.Store(int64(getMaxIdleSize(maxIdleSize)))
+	return stack
+}
@@ -0,0 +1,226 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStackPool_Acquire(t *testing.T) {
+	testCases := []struct {
+		name             string
+		advance          time.Duration
+		expectedResource MockResource
+		expectedDestroys int
+	}{
+		{
+			name:             "reuses most recently released resource",
+			expectedResource: MockResource{id: 2},
+		},
+		{
+			name:             "destroys expired idle resources",
+			advance:          maxIdleTime + time.Nanosecond,
+			expectedResource: MockResource{id: 3},
+			expectedDestroys: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &MockClock{now: time.Unix(0, 0)}
+			destroys := 0
+			pool := NewStack(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithClock[MockResource](clock),
+				WithDestroyer(func(MockResource) error { destroys++; return nil }),
+			)
+
+			first, _ := pool.Acquire(nil)
+			second, _ := pool.Acquire(nil)
+			pool.Release(first)
+			pool.Release(second)
+			clock.Advance(tc.advance)
+
+			resource, err := pool.Acquire(nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedDestroys, destroys)
+		})
+	}
+}
+
+func TestStackPool_AcquireKeepsFreshResources(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := NewStack(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+	expired, _ := pool.Acquire(nil)
+	fresh, _ := pool.Acquire(nil)
+	pool.Release(expired)
+	clock.Advance(maxIdleTime + time.Nanosecond)
+	pool.Release(fresh)
+
+	pool.sweep()
+
+	assert.Equal(t, 1, pool.NumIdle())
+	resource, _ := pool.Acquire(nil)
+	assert.Equal(t, fresh, resource)
+}
+
+func TestStackPool_Release(t *testing.T) {
+	destroys := 0
+	pool := NewStack(getAtomicMockCreatorFunc(), 1, maxIdleTime,
+		WithDestroyer(func(MockResource) error { destroys++; return nil }),
+	)
+
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.Release(first)
+	pool.Release(second)
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, 1, destroys)
+}
+
+func TestStackPool_SetMaxIdleSize(t *testing.T) {
+	var destroyed []MockResource
+	pool := NewStack(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithDestroyer(func(resource MockResource) error { destroyed = append(destroyed, resource); return nil }),
+	)
+	var held []MockResource
+	for i := 0; i < 3; i++ {
+		resource, _ := pool.Acquire(nil)
+		held = append(held, resource)
+	}
+	for _, resource := range held {
+		pool.Release(resource)
+	}
+
+	pool.SetMaxIdleSize(1)
+	pool.Release(MockResource{id: 4})
+
+	assert.Equal(t, 1, pool.NumIdle())
+	assert.Equal(t, []MockResource{{id: 2}, {id: 1}, {id: 4}}, destroyed)
+	resource, _ := pool.Acquire(nil)
+	assert.Equal(t, MockResource{id: 3}, resource)
+}
+
+func TestStackPool_MaxActive(t *testing.T) {
+	pool := NewStack(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+	first, _ := pool.Acquire(nil)
+
+	_, err := pool.Acquire(nil)
+	assert.ErrorIs(t, err, ErrPoolExhausted)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	pool.Release(first)
+
+	assert.Equal(t, first, <-acquired)
+}
+
+func TestStackPool_Close(t *testing.T) {
+	destroys := 0
+	pool := NewStack(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithDestroyer(func(MockResource) error { destroys++; return nil }),
+	)
+	idle, _ := pool.Acquire(nil)
+	active, _ := pool.Acquire(nil)
+	pool.Release(idle)
+
+	pool.Close()
+	pool.Release(active)
+	_, err := pool.Acquire(nil)
+
+	assert.ErrorIs(t, err, ErrPoolClosed)
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Equal(t, 2, destroys)
+}
+
+func TestStackPool_Concurrent(t *testing.T) {
+	var destroys atomic.Int64
+	pool := NewStack(getAtomicMockCreatorFunc(), 8, maxIdleTime,
+		WithDestroyer(func(MockResource) error { destroys.Add(1); return nil }),
+	)
+	var acquired sync.Map
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				resource, err := pool.Acquire(nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				_, isHeld := acquired.LoadOrStore(resource, true)
+				assert.False(t, isHeld, "resource acquired twice")
+				acquired.Delete(resource)
+				pool.Release(resource)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pool.Close()
+	assert.Equal(t, 0, pool.NumIdle())
+	assert.Positive(t, destroys.Load())
+}
+
+func BenchmarkStackPool_AcquireRelease(b *testing.B) {
+	pool := NewStack(getAtomicMockCreatorFunc(), 1024, time.Minute)
+	benchmarkAcquireRelease(b, pool)
+}
+
+func BenchmarkStackPool_AcquireReleaseWithManyIdle(b *testing.B) {
+	pool := NewStack(getAtomicMockCreatorFunc(), 4096, time.Minute)
+	var resources []MockResource
+	for i := 0; i < 4096; i++ {
+		resource, _ := pool.Acquire(nil)
+		resources = append(resources, resource)
+	}
+	for _, resource := range resources {
+		pool.Release(resource)
+	}
+	benchmarkAcquireRelease(b, pool)
+}
+
+func TestNewStack_ClosesCloserResources(t *testing.T) {
+	testCases := []struct {
+		name           string
+		options        []Option[*closableResource]
+		expectedCloses int
+	}{
+		{
+			name:           "without destroyer closes resources",
+			expectedCloses: 1,
+		},
+		{
+			name:           "with compatibility mode does not close resources",
+			options:        []Option[*closableResource]{CompatibilityV1[*closableResource]()},
+			expectedCloses: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closes := 0
+			creator := func(context.Context) (*closableResource, error) {
+				return &closableResource{closes: &closes}, nil
+			}
+			pool := NewStack(creator, maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			pool.Close()
+
+			assert.Equal(t, tc.expectedCloses, closes)
+		})
+	}
+}
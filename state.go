@@ -0,0 +1,83 @@
+package pool
+
+import (
+	"sort"
+	"time"
+)
+
+// recentErrorLimit is the number of recent errors a pool keeps for its State
+const recentErrorLimit = 10
+
+// State is a detailed dump of a pool, e.g. to log when it saturates. Its JSON
+// field names are stable across releases; durations are in nanoseconds.
+type State struct {
+	Stats Stats `json:"stats"`
+	// Config is the effective configuration of the pool
+	Config Config `json:"config"`
+	// Resources are the idle and acquired resources, oldest first
+	Resources []ResourceState `json:"resources"`
+	// RecentErrors are the last creation and destruction errors, oldest first
+	RecentErrors []RecentError `json:"recent_errors"`
+}
+
+// ResourceState is the state of a pooled resource in a State
+type ResourceState struct {
+	// Age is the time since the resource was created
+	Age time.Duration `json:"age_ns"`
+	// SinceUsed is the time since the resource was last acquired or released
+	SinceUsed    time.Duration `json:"since_used_ns"`
+	UseCount     int64         `json:"use_count"`
+	HeldDuration time.Duration `json:"held_duration_ns"`
+	IsAcquired   bool          `json:"is_acquired"`
+}
+
+// RecentError is a failure of a pool recorded in its State
+type RecentError struct {
+	Time time.Time `json:"time"`
+	// Operation is what failed, "create" or "destroy"
+	Operation string `json:"operation"`
+	Err       string `json:"error"`
+}
+
+// records a failure for the pool state, dropping the oldest one past
+// recentErrorLimit; the pool mutex must be held
+func (n *NewPool[T]) recordRecentError(operation string, err error) {
+	if len(n.recentErrors) >= recentErrorLimit {
+		n.recentErrors = n.recentErrors[1:]
+	}
+	n.recentErrors = append(n.recentErrors, RecentError{Time: n.now(), Operation: operation, Err: err.Error()})
+}
+
+// returns a detailed dump of the pool: its stats, its configuration, its
+// resources, oldest first, and its recent errors
+func (n *NewPool[T]) State() State {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	now := n.now()
+	resources := make([]ResourceState, 0, len(n.lock)+len(n.unlock))
+	add := func(entry *resourceEntry, isAcquired bool) {
+		info := n.getResourceInfo(entry, isAcquired)
+		resources = append(resources, ResourceState{
+			Age:          now.Sub(info.CreatedAt),
+			SinceUsed:    now.Sub(info.LastUsedAt),
+			UseCount:     info.UseCount,
+			HeldDuration: info.HeldDuration,
+			IsAcquired:   isAcquired,
+		})
+	}
+	for _, entry := range n.lock {
+		add(entry, true)
+	}
+	for _, entry := range n.unlock {
+		add(entry, false)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Age > resources[j].Age })
+
+	return State{
+		Stats:        n.getStats(),
+		Config:       n.getConfig(),
+		Resources:    resources,
+		RecentErrors: append([]RecentError(nil), n.recentErrors...),
+	}
+}
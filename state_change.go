@@ -0,0 +1,124 @@
+package pool
+
+const (
+	// stateSaturationHigh is the saturation at which the pool becomes
+	// saturated, and stateSaturationLow the one at or below which it stops
+	// being saturated
+	stateSaturationHigh = 0.8
+	stateSaturationLow  = 0.6
+	// stateIdleShareHigh is the share of idle resources at which the pool
+	// becomes idle-heavy, and stateIdleShareLow the one at or below which it
+	// stops being idle-heavy
+	stateIdleShareHigh = 0.8
+	stateIdleShareLow  = 0.5
+	// stateMinIdle is the number of idle resources an idle-heavy pool keeps at
+	// least, so a pool resting on a single idle resource stays healthy
+	stateMinIdle = 2
+	// stateCreateFailures is the number of consecutive failed creations at
+	// which the creator is failing
+	stateCreateFailures = 3
+)
+
+// PoolState is the condition of a pool, as notified by WithStateChange
+type PoolState string
+
+const (
+	// StateHealthy is the state of a pool serving acquires without strain
+	StateHealthy PoolState = "healthy"
+	// StateIdleHeavy is the state of a pool keeping mostly idle resources,
+	// e.g. one sized above its load
+	StateIdleHeavy PoolState = "idle-heavy"
+	// StateSaturated is the state of a pool close to its WithMaxActive cap
+	// or WithMaxCost budget
+	StateSaturated PoolState = "saturated"
+	// StateExhausted is the state of a pool whose acquires wait, or which
+	// is at its cap so that the next acquire would wait
+	StateExhausted PoolState = "exhausted"
+	// StateCreatorFailing is the state of a pool whose creations keep
+	// failing, until one succeeds
+	StateCreatorFailing PoolState = "creator-failing"
+	// StateClosed is the state of a closed pool
+	StateClosed PoolState = "closed"
+)
+
+// reports whether the state calls for attention: the pool is exhausted, its
+// creator is failing or it is closed
+func (s PoolState) IsUnhealthy() bool {
+	return s == StateExhausted || s == StateCreatorFailing || s == StateClosed
+}
+
+// WithStateChange calls onChange when the pool moves from one PoolState to
+// another, e.g. to alert on IsUnhealthy states from a single hook instead of
+// deriving them from the stats. States are entered and left at different
+// thresholds, so that the state does not flap: the pool is saturated from 80%
+// saturation, as returned by Saturation, until 60%; idle-heavy from 80% of its
+// resources idle, with at least 2 idle, until 50%; exhausted while acquires
+// wait and until it falls below its cap; and creator-failing from 3
+// consecutive failed creations until one succeeds. onChange runs under the
+// pool mutex, so it must not call the pool.
+func WithStateChange[T comparable](onChange func(from PoolState, to PoolState)) Option[T] {
+	return func(n *NewPool[T]) {
+		n.onStateChange = onChange
+	}
+}
+
+// returns the state of the pool, as last notified by WithStateChange; without
+// it, the state is derived from the pool as it is now
+func (n *NewPool[T]) PoolState() PoolState {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.onStateChange == nil {
+		return n.getNextState(StateHealthy)
+	}
+	return n.state
+}
+
+// returns the state the pool moves to from state; the pool mutex must be held
+func (n *NewPool[T]) getNextState(state PoolState) PoolState {
+	saturation := n.getSaturation()
+	isLoaded := state == StateSaturated || state == StateExhausted
+	switch {
+	case n.isClosed:
+		return StateClosed
+	case n.createAttempts >= stateCreateFailures || (state == StateCreatorFailing && n.createAttempts > 0):
+		return StateCreatorFailing
+	case n.waiters > 0 || (state == StateExhausted && saturation >= 1):
+		return StateExhausted
+	case saturation >= stateSaturationHigh || (isLoaded && saturation > stateSaturationLow):
+		return StateSaturated
+	}
+
+	idle := len(n.unlock)
+	if idle < stateMinIdle {
+		return StateHealthy
+	}
+	idleShare := float64(idle) / float64(idle+len(n.lock))
+	if idleShare >= stateIdleShareHigh || (state == StateIdleHeavy && idleShare > stateIdleShareLow) {
+		return StateIdleHeavy
+	}
+	return StateHealthy
+}
+
+// calls the state change callback if the pool changed state; the pool mutex
+// must be held
+func (n *NewPool[T]) checkState() {
+	if n.onStateChange == nil {
+		return
+	}
+
+	from := n.state
+	to := n.getNextState(from)
+	if to == from {
+		return
+	}
+
+	n.state = to
+	n.log(LogInfo, "pool state changed",
+		Field{Key: "from", Value: from},
+		Field{Key: "to", Value: to},
+	)
+	n.callHook("StateChange", func() {
+		n.onStateChange(from, to)
+	})
+}
@@ -0,0 +1,172 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type stateChange struct {
+	from PoolState
+	to   PoolState
+}
+
+func TestNewPool_StateChange(t *testing.T) {
+	var changes []stateChange
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](5),
+		WithStateChange[MockResource](func(from PoolState, to PoolState) {
+			changes = append(changes, stateChange{from, to})
+		}),
+	)
+
+	var held []MockResource
+	for i := 0; i < 5; i++ {
+		resource, _ := pool.Acquire(nil)
+		held = append(held, resource)
+	}
+	assert.Equal(t, StateSaturated, pool.PoolState())
+
+	// stays saturated until it falls to the low threshold
+	pool.Release(held[0])
+	assert.Equal(t, StateSaturated, pool.PoolState())
+	pool.Release(held[1])
+	assert.Equal(t, StateHealthy, pool.PoolState())
+
+	for _, resource := range held[2:] {
+		pool.Release(resource)
+	}
+	assert.Equal(t, StateIdleHeavy, pool.PoolState())
+
+	pool.Close()
+	assert.Equal(t, []stateChange{
+		{StateHealthy, StateSaturated},
+		{StateSaturated, StateHealthy},
+		{StateHealthy, StateIdleHeavy},
+		{StateIdleHeavy, StateClosed},
+	}, changes)
+	assert.True(t, pool.PoolState().IsUnhealthy())
+}
+
+func TestNewPool_StateChangeExhausted(t *testing.T) {
+	var changes []stateChange
+	pool := New(getAtomicMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](1),
+		WithStateChange[MockResource](func(from PoolState, to PoolState) {
+			changes = append(changes, stateChange{from, to})
+		}),
+	)
+	resource, _ := pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.PoolState() == StateExhausted }, time.Second, time.Millisecond)
+	pool.Release(resource)
+	resource = <-acquired
+
+	// the waiter took the released resource, so the pool is still at its cap
+	assert.Equal(t, StateExhausted, pool.PoolState())
+	pool.Release(resource)
+	assert.Equal(t, []stateChange{
+		{StateHealthy, StateSaturated},
+		{StateSaturated, StateExhausted},
+		{StateExhausted, StateHealthy},
+	}, changes)
+}
+
+func TestNewPool_StateChangeCreatorFailing(t *testing.T) {
+	createErr := errors.New("connection refused")
+	var isFailing bool
+	creator := func(ctx context.Context) (MockResource, error) {
+		if isFailing {
+			return MockResource{}, createErr
+		}
+		return MockResource{}, nil
+	}
+	var changes []stateChange
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithStateChange[MockResource](func(from PoolState, to PoolState) {
+			changes = append(changes, stateChange{from, to})
+		}),
+	)
+
+	isFailing = true
+	for i := 0; i < stateCreateFailures; i++ {
+		pool.Acquire(nil)
+	}
+	assert.Equal(t, StateCreatorFailing, pool.PoolState())
+
+	isFailing = false
+	pool.Acquire(nil)
+	assert.Equal(t, StateHealthy, pool.PoolState())
+	assert.Equal(t, []stateChange{
+		{StateHealthy, StateCreatorFailing},
+		{StateCreatorFailing, StateHealthy},
+	}, changes)
+}
+
+func TestNewPool_PoolState(t *testing.T) {
+	testCases := []struct {
+		name          string
+		held          int
+		released      int
+		expectedState PoolState
+	}{
+		{
+			name:          "empty is healthy",
+			expectedState: StateHealthy,
+		},
+		{
+			name:          "near cap is saturated",
+			held:          4,
+			expectedState: StateSaturated,
+		},
+		{
+			name:          "mostly idle is idle-heavy",
+			held:          3,
+			released:      3,
+			expectedState: StateIdleHeavy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithMaxActive[MockResource](5))
+			var held []MockResource
+			for i := 0; i < tc.held; i++ {
+				resource, _ := pool.Acquire(nil)
+				held = append(held, resource)
+			}
+			for _, resource := range held[:tc.released] {
+				pool.Release(resource)
+			}
+
+			assert.Equal(t, tc.expectedState, pool.PoolState())
+		})
+	}
+}
+
+func TestPoolState_IsUnhealthy(t *testing.T) {
+	testCases := []struct {
+		state    PoolState
+		expected bool
+	}{
+		{state: StateHealthy, expected: false},
+		{state: StateIdleHeavy, expected: false},
+		{state: StateSaturated, expected: false},
+		{state: StateExhausted, expected: true},
+		{state: StateCreatorFailing, expected: true},
+		{state: StateClosed, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.state), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.state.IsUnhealthy())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pool
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_State(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+	first, _ := pool.Acquire(nil)
+	clock.Advance(time.Second)
+	pool.Acquire(nil)
+	pool.Release(first)
+	clock.Advance(time.Second)
+
+	state := pool.State()
+
+	assert.Equal(t, 2, state.Stats.Total)
+	assert.Equal(t, []ResourceState{
+		{Age: 2 * time.Second, SinceUsed: time.Second, UseCount: 1, HeldDuration: time.Second},
+		{Age: time.Second, SinceUsed: time.Second, UseCount: 1, HeldDuration: time.Second, IsAcquired: true},
+	}, state.Resources)
+	assert.Empty(t, state.RecentErrors)
+}
+
+func TestNewPool_StateKeepsRecentErrors(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+	for i := 0; i < recentErrorLimit+2; i++ {
+		pool.recordRecentError("create", errors.New("error response"))
+	}
+
+	state := pool.State()
+
+	assert.Len(t, state.RecentErrors, recentErrorLimit)
+}
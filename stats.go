@@ -0,0 +1,285 @@
+package pool
+
+import (
+	"time"
+)
+
+// EvictReason describes why a resource was dropped by the pool
+type EvictReason string
+
+const (
+	// EvictExpired is used when a resource exceeded maxIdleTime, or the max
+	// lifetime of WithMaxLifetime
+	EvictExpired EvictReason = "expired"
+	// EvictCapacity is used when a released resource did not fit in the idle pool
+	EvictCapacity EvictReason = "capacity"
+	// EvictUnhealthy is used when a resource's health score fell below the minimum
+	EvictUnhealthy EvictReason = "unhealthy"
+	// EvictClosed is used for resources destroyed because the pool was closed
+	EvictClosed EvictReason = "closed"
+	// EvictInvalidated is used for acquired resources invalidated by the caller
+	EvictInvalidated EvictReason = "invalidated"
+	// EvictWarmFailed is used for background-created resources the OnWarm
+	// hook failed for
+	EvictWarmFailed EvictReason = "warm-failed"
+	// EvictResetFailed is used for released resources the WithReset function
+	// failed for
+	EvictResetFailed EvictReason = "reset-failed"
+	// EvictRetired is used for resources created before they were retired,
+	// e.g. by SetCreator with WithRetireOnSetCreator, Recycle or
+	// BumpGeneration
+	EvictRetired EvictReason = "retired"
+	// EvictValidateFailed is used for idle resources the WithValidator
+	// function failed for when they were about to be handed out
+	EvictValidateFailed EvictReason = "validate-failed"
+	// EvictPruned is used for idle resources dropped by EvictIdle
+	EvictPruned EvictReason = "pruned"
+	// EvictChaos is used for resources destroyed by faults injected with
+	// WithChaos
+	EvictChaos EvictReason = "chaos"
+	// EvictPressure is used for idle resources dropped under memory pressure,
+	// as detected by WithMemoryPressure
+	EvictPressure EvictReason = "pressure"
+	// EvictReclaimed is used for the resources of leases reclaimed by
+	// WithLeaseReclaim
+	EvictReclaimed EvictReason = "reclaimed"
+	// EvictOrphaned is used for acquired resources destroyed by a forced
+	// close; their later release is ignored
+	EvictOrphaned EvictReason = "orphaned"
+)
+
+// Stats is a point-in-time snapshot of pool activity
+type Stats struct {
+	// Acquires is the number of Acquire calls, successful or not
+	Acquires int64
+	// Reused is the number of acquires served from the idle pool
+	Reused int64
+	// AffinityHits is the number of acquires served with the idle resource
+	// last used with their Affinity key; they are counted in Reused too
+	AffinityHits int64
+	// Created is the number of resources successfully created
+	Created int64
+	// CreateFailures is the number of creator calls that returned an error
+	CreateFailures int64
+	// Evictions counts dropped resources by reason
+	Evictions map[EvictReason]int64
+	// Idle is the number of idle resources at the time of the snapshot
+	Idle int
+	// Active is the number of acquired resources at the time of the snapshot
+	Active int
+	// Total is the number of idle and acquired resources at the time of the
+	// snapshot
+	Total int
+	// Creating is the number of creations in flight at the time of the
+	// snapshot, from acquires and from background work such as warmup
+	Creating int
+	// Cap is the maximum number of acquired resources; zero means no limit
+	Cap int
+	// IdleCap is the max idle size, as given to New, derived from GOMAXPROCS
+	// or adjusted by WithAutoSizing
+	IdleCap int
+	// ActiveCost is the total cost of the acquired resources, as weighed by
+	// WithCost
+	ActiveCost int64
+	// Handles is the number of OS handles held by the pool's resources, as
+	// reported by WithHandleCount
+	Handles int
+	// HandleLimit is the process limit of open file descriptors on unix, as
+	// read when the pool was created, or zero where there is none or it is
+	// unknown; set with WithHandleCount
+	HandleLimit int
+	// Waits is the number of acquires which had to wait for a resource
+	Waits int64
+	// WaitDuration is the total time acquires spent waiting for a resource
+	WaitDuration time.Duration
+	// MaxWait is the longest time an acquire spent waiting for a resource
+	MaxWait time.Duration
+	// Waiters is the number of acquires waiting for a resource at the time of
+	// the snapshot
+	Waiters int
+	// WaitRejections is the number of acquires failed by the WithMaxWaiters
+	// cap
+	WaitRejections int64
+	// Hedges is the number of creations started by WithHedgedAcquire
+	Hedges int64
+	// HedgeReleaseWins is the number of hedged acquires which took a released
+	// resource before their creation finished
+	HedgeReleaseWins int64
+	// Coalesced is the number of acquires which joined the creation of
+	// another miss or of warmup with WithCreateCoalescing, or took the error
+	// of a failed one, instead of creating their own
+	Coalesced int64
+	// AcquireLatency is the latency of successful acquires by outcome, as
+	// recorded with WithAcquireLatency
+	AcquireLatency map[AcquireOutcome]LatencyHistogram
+	// Reclaimed is the number of leases reclaimed by WithLeaseReclaim after
+	// they expired without being released
+	Reclaimed int64
+	// Quarantined lists the endpoints skipped after failed dials, as set by
+	// WithEndpointQuarantine
+	Quarantined []QuarantinedEndpoint
+	// Tenants is the activity of each tenant set with Tenant, by tenant
+	Tenants map[string]TenantStats
+	// LocalHits is the number of acquires served by the local cache, which
+	// are not counted in Acquires
+	LocalHits int64
+	// HookPanics counts the panics recovered from each hook in panic
+	// containment mode, by hook name (e.g. "OnRelease")
+	HookPanics map[string]int64
+	// Goroutines is the budget and usage of the scheduler running the pool's
+	// background work, which may be shared with other pools
+	Goroutines SchedulerStats
+}
+
+// poolStats holds the cumulative counters of a pool; guarded by the pool mutex
+type poolStats struct {
+	acquires         int64
+	reused           int64
+	affinityHits     int64
+	created          int64
+	createFailures   int64
+	waitRejections   int64
+	hedges           int64
+	hedgeReleaseWins int64
+	coalesced        int64
+	reclaimed        int64
+	waits            int64
+	waitDuration     time.Duration
+	maxWait          time.Duration
+	evictions        map[EvictReason]int64
+	hookPanics       map[string]int64
+	acquireLatency   map[AcquireOutcome]*LatencyHistogram
+}
+
+func (s *poolStats) recordEviction(reason EvictReason) {
+	if s.evictions == nil {
+		s.evictions = make(map[EvictReason]int64)
+	}
+	s.evictions[reason]++
+}
+
+// counts a recovered panic of the hook called name; returns its total count
+func (s *poolStats) recordHookPanic(name string) int64 {
+	if s.hookPanics == nil {
+		s.hookPanics = make(map[string]int64)
+	}
+	s.hookPanics[name]++
+	return s.hookPanics[name]
+}
+
+func (s *poolStats) snapshot() Stats {
+	evictions := make(map[EvictReason]int64, len(s.evictions))
+	for reason, count := range s.evictions {
+		evictions[reason] = count
+	}
+	hookPanics := make(map[string]int64, len(s.hookPanics))
+	for name, count := range s.hookPanics {
+		hookPanics[name] = count
+	}
+
+	var acquireLatency map[AcquireOutcome]LatencyHistogram
+	if s.acquireLatency != nil {
+		acquireLatency = make(map[AcquireOutcome]LatencyHistogram, len(s.acquireLatency))
+		for outcome, histogram := range s.acquireLatency {
+			acquireLatency[outcome] = histogram.copy()
+		}
+	}
+
+	return Stats{
+		Acquires:         s.acquires,
+		Reused:           s.reused,
+		AffinityHits:     s.affinityHits,
+		Created:          s.created,
+		CreateFailures:   s.createFailures,
+		WaitRejections:   s.waitRejections,
+		Hedges:           s.hedges,
+		HedgeReleaseWins: s.hedgeReleaseWins,
+		Coalesced:        s.coalesced,
+		Reclaimed:        s.reclaimed,
+		Waits:            s.waits,
+		WaitDuration:     s.waitDuration,
+		MaxWait:          s.maxWait,
+		Evictions:        evictions,
+		HookPanics:       hookPanics,
+		AcquireLatency:   acquireLatency,
+	}
+}
+
+// MergeStats returns the stats of several pools combined, e.g. to report them
+// as one: counters and sizes are summed, and Cap is zero, no limit, if any of
+// the pools has no limit. Goroutines is left empty, as pools may share a
+// scheduler.
+func MergeStats(stats ...Stats) Stats {
+	var merged Stats
+	isUnlimited := false
+	for _, other := range stats {
+		merged.add(other)
+		isUnlimited = isUnlimited || other.Cap == 0
+	}
+	if isUnlimited {
+		merged.Cap = 0
+	}
+	return merged
+}
+
+// adds the counters and sizes of other to s; Cap is summed, see MergeStats
+// for pools without a limit
+func (s *Stats) add(other Stats) {
+	s.Acquires += other.Acquires
+	s.Reused += other.Reused
+	s.AffinityHits += other.AffinityHits
+	s.Created += other.Created
+	s.CreateFailures += other.CreateFailures
+	if s.Evictions == nil {
+		s.Evictions = make(map[EvictReason]int64, len(other.Evictions))
+	}
+	for reason, count := range other.Evictions {
+		s.Evictions[reason] += count
+	}
+	if s.HookPanics == nil {
+		s.HookPanics = make(map[string]int64, len(other.HookPanics))
+	}
+	for name, count := range other.HookPanics {
+		s.HookPanics[name] += count
+	}
+	s.ActiveCost += other.ActiveCost
+	s.Handles += other.Handles
+	if other.HandleLimit > s.HandleLimit {
+		s.HandleLimit = other.HandleLimit
+	}
+	s.Waiters += other.Waiters
+	s.WaitRejections += other.WaitRejections
+	s.Hedges += other.Hedges
+	s.HedgeReleaseWins += other.HedgeReleaseWins
+	s.Coalesced += other.Coalesced
+	s.Reclaimed += other.Reclaimed
+	if other.AcquireLatency != nil && s.AcquireLatency == nil {
+		s.AcquireLatency = make(map[AcquireOutcome]LatencyHistogram, len(other.AcquireLatency))
+	}
+	for outcome, histogram := range other.AcquireLatency {
+		s.AcquireLatency[outcome] = s.AcquireLatency[outcome].Merge(histogram)
+	}
+	s.Waits += other.Waits
+	s.WaitDuration += other.WaitDuration
+	if other.MaxWait > s.MaxWait {
+		s.MaxWait = other.MaxWait
+	}
+	s.Quarantined = append(s.Quarantined, other.Quarantined...)
+	if s.Tenants == nil && len(other.Tenants) > 0 {
+		s.Tenants = make(map[string]TenantStats, len(other.Tenants))
+	}
+	for tenant, tenantStats := range other.Tenants {
+		sum := s.Tenants[tenant]
+		sum.Active += tenantStats.Active
+		sum.Acquires += tenantStats.Acquires
+		sum.Rejections += tenantStats.Rejections
+		s.Tenants[tenant] = sum
+	}
+	s.LocalHits += other.LocalHits
+	s.Idle += other.Idle
+	s.Active += other.Active
+	s.Total += other.Total
+	s.Creating += other.Creating
+	s.Cap += other.Cap
+	s.IdleCap += other.IdleCap
+}
@@ -0,0 +1,199 @@
+package pool
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	_ json.Marshaler   = Stats{}
+	_ json.Unmarshaler = &Stats{}
+)
+
+// jsonStats is the JSON encoding of Stats. Its field names are stable across
+// releases; durations are in nanoseconds.
+type jsonStats struct {
+	Acquires         int64                                   `json:"acquires"`
+	Reused           int64                                   `json:"reused"`
+	AffinityHits     int64                                   `json:"affinity_hits"`
+	Created          int64                                   `json:"created"`
+	CreateFailures   int64                                   `json:"create_failures"`
+	Evictions        map[EvictReason]int64                   `json:"evictions"`
+	Idle             int                                     `json:"idle"`
+	Active           int                                     `json:"active"`
+	Total            int                                     `json:"total"`
+	Creating         int                                     `json:"creating"`
+	Cap              int                                     `json:"cap"`
+	IdleCap          int                                     `json:"idle_cap"`
+	ActiveCost       int64                                   `json:"active_cost"`
+	Handles          int                                     `json:"handles"`
+	HandleLimit      int                                     `json:"handle_limit"`
+	Waits            int64                                   `json:"waits"`
+	WaitDuration     time.Duration                           `json:"wait_duration_ns"`
+	MaxWait          time.Duration                           `json:"max_wait_ns"`
+	Waiters          int                                     `json:"waiters"`
+	WaitRejections   int64                                   `json:"wait_rejections"`
+	Hedges           int64                                   `json:"hedges"`
+	HedgeReleaseWins int64                                   `json:"hedge_release_wins"`
+	Coalesced        int64                                   `json:"coalesced"`
+	Reclaimed        int64                                   `json:"reclaimed"`
+	Quarantined      []jsonQuarantinedEndpoint               `json:"quarantined"`
+	Tenants          map[string]jsonTenantStats              `json:"tenants"`
+	LocalHits        int64                                   `json:"local_hits"`
+	HookPanics       map[string]int64                        `json:"hook_panics"`
+	AcquireLatency   map[AcquireOutcome]jsonLatencyHistogram `json:"acquire_latency"`
+	Goroutines       jsonSchedulerStats                      `json:"goroutines"`
+}
+
+// jsonQuarantinedEndpoint is the JSON encoding of QuarantinedEndpoint; the
+// error is encoded as its message
+type jsonQuarantinedEndpoint struct {
+	Endpoint string    `json:"endpoint"`
+	Failures int       `json:"failures"`
+	Until    time.Time `json:"until"`
+	Err      string    `json:"error"`
+}
+
+// jsonTenantStats is the JSON encoding of TenantStats
+type jsonTenantStats struct {
+	Active     int   `json:"active"`
+	Acquires   int64 `json:"acquires"`
+	Rejections int64 `json:"rejections"`
+}
+
+// jsonLatencyHistogram is the JSON encoding of LatencyHistogram
+type jsonLatencyHistogram struct {
+	Bounds []time.Duration `json:"bounds_ns"`
+	Counts []int64         `json:"counts"`
+	Count  int64           `json:"count"`
+	Sum    time.Duration   `json:"sum_ns"`
+}
+
+// jsonSchedulerStats is the JSON encoding of SchedulerStats
+type jsonSchedulerStats struct {
+	Limit   int `json:"limit"`
+	Running int `json:"running"`
+	Queued  int `json:"queued"`
+}
+
+// MarshalJSON encodes the stats with stable snake_case field names, e.g. to
+// log them or diff snapshots; durations are in nanoseconds
+func (s Stats) MarshalJSON() ([]byte, error) {
+	encoded := jsonStats{
+		Acquires:         s.Acquires,
+		Reused:           s.Reused,
+		AffinityHits:     s.AffinityHits,
+		Created:          s.Created,
+		CreateFailures:   s.CreateFailures,
+		Evictions:        s.Evictions,
+		Idle:             s.Idle,
+		Active:           s.Active,
+		Total:            s.Total,
+		Creating:         s.Creating,
+		Cap:              s.Cap,
+		IdleCap:          s.IdleCap,
+		ActiveCost:       s.ActiveCost,
+		Handles:          s.Handles,
+		HandleLimit:      s.HandleLimit,
+		Waits:            s.Waits,
+		WaitDuration:     s.WaitDuration,
+		MaxWait:          s.MaxWait,
+		Waiters:          s.Waiters,
+		WaitRejections:   s.WaitRejections,
+		Hedges:           s.Hedges,
+		HedgeReleaseWins: s.HedgeReleaseWins,
+		Coalesced:        s.Coalesced,
+		Reclaimed:        s.Reclaimed,
+		LocalHits:        s.LocalHits,
+		HookPanics:       s.HookPanics,
+		Goroutines:       jsonSchedulerStats(s.Goroutines),
+	}
+	for _, endpoint := range s.Quarantined {
+		quarantined := jsonQuarantinedEndpoint{
+			Endpoint: endpoint.Endpoint,
+			Failures: endpoint.Failures,
+			Until:    endpoint.Until,
+		}
+		if endpoint.Err != nil {
+			quarantined.Err = endpoint.Err.Error()
+		}
+		encoded.Quarantined = append(encoded.Quarantined, quarantined)
+	}
+	if s.Tenants != nil {
+		encoded.Tenants = make(map[string]jsonTenantStats, len(s.Tenants))
+		for tenant, tenantStats := range s.Tenants {
+			encoded.Tenants[tenant] = jsonTenantStats(tenantStats)
+		}
+	}
+	if s.AcquireLatency != nil {
+		encoded.AcquireLatency = make(map[AcquireOutcome]jsonLatencyHistogram, len(s.AcquireLatency))
+		for outcome, histogram := range s.AcquireLatency {
+			encoded.AcquireLatency[outcome] = jsonLatencyHistogram(histogram)
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes stats encoded by MarshalJSON; the errors of
+// quarantined endpoints are restored as plain errors with their message
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	var decoded jsonStats
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*s = Stats{
+		Acquires:         decoded.Acquires,
+		Reused:           decoded.Reused,
+		AffinityHits:     decoded.AffinityHits,
+		Created:          decoded.Created,
+		CreateFailures:   decoded.CreateFailures,
+		Evictions:        decoded.Evictions,
+		Idle:             decoded.Idle,
+		Active:           decoded.Active,
+		Total:            decoded.Total,
+		Creating:         decoded.Creating,
+		Cap:              decoded.Cap,
+		IdleCap:          decoded.IdleCap,
+		ActiveCost:       decoded.ActiveCost,
+		Handles:          decoded.Handles,
+		HandleLimit:      decoded.HandleLimit,
+		Waits:            decoded.Waits,
+		WaitDuration:     decoded.WaitDuration,
+		MaxWait:          decoded.MaxWait,
+		Waiters:          decoded.Waiters,
+		WaitRejections:   decoded.WaitRejections,
+		Hedges:           decoded.Hedges,
+		HedgeReleaseWins: decoded.HedgeReleaseWins,
+		Coalesced:        decoded.Coalesced,
+		Reclaimed:        decoded.Reclaimed,
+		LocalHits:        decoded.LocalHits,
+		HookPanics:       decoded.HookPanics,
+		Goroutines:       SchedulerStats(decoded.Goroutines),
+	}
+	for _, endpoint := range decoded.Quarantined {
+		quarantined := QuarantinedEndpoint{
+			Endpoint: endpoint.Endpoint,
+			Failures: endpoint.Failures,
+			Until:    endpoint.Until,
+		}
+		if endpoint.Err != "" {
+			quarantined.Err = errors.New(endpoint.Err)
+		}
+		s.Quarantined = append(s.Quarantined, quarantined)
+	}
+	if decoded.Tenants != nil {
+		s.Tenants = make(map[string]TenantStats, len(decoded.Tenants))
+		for tenant, tenantStats := range decoded.Tenants {
+			s.Tenants[tenant] = TenantStats(tenantStats)
+		}
+	}
+	if decoded.AcquireLatency != nil {
+		s.AcquireLatency = make(map[AcquireOutcome]LatencyHistogram, len(decoded.AcquireLatency))
+		for outcome, histogram := range decoded.AcquireLatency {
+			s.AcquireLatency[outcome] = LatencyHistogram(histogram)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package pool
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestStats_MarshalJSON(t *testing.T) {
+	stats := Stats{
+		Acquires:     3,
+		Evictions:    map[EvictReason]int64{EvictExpired: 1},
+		Idle:         1,
+		WaitDuration: time.Millisecond,
+		Quarantined: []QuarantinedEndpoint{
+			{Endpoint: "a:80", Failures: 2, Until: time.Unix(10, 0).UTC(), Err: errors.New("refused")},
+		},
+		Tenants: map[string]TenantStats{"tenant-1": {Active: 1}},
+		AcquireLatency: map[AcquireOutcome]LatencyHistogram{
+			AcquireCreated: {Bounds: []time.Duration{time.Millisecond}, Counts: []int64{1, 0}, Count: 1, Sum: time.Microsecond},
+		},
+		Goroutines: SchedulerStats{Limit: 4},
+	}
+
+	data, err := json.Marshal(stats)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"acquires": 3, "reused": 0, "affinity_hits": 0, "created": 0, "create_failures": 0,
+		"evictions": {"expired": 1},
+		"idle": 1, "active": 0, "total": 0, "creating": 0, "cap": 0, "idle_cap": 0,
+		"active_cost": 0, "handles": 0, "handle_limit": 0,
+		"waits": 0, "wait_duration_ns": 1000000, "max_wait_ns": 0, "waiters": 0, "wait_rejections": 0,
+		"hedges": 0, "hedge_release_wins": 0, "coalesced": 0, "reclaimed": 0,
+		"quarantined": [{"endpoint": "a:80", "failures": 2, "until": "1970-01-01T00:00:10Z", "error": "refused"}],
+		"tenants": {"tenant-1": {"active": 1, "acquires": 0, "rejections": 0}},
+		"local_hits": 0, "hook_panics": null,
+		"acquire_latency": {"created": {"bounds_ns": [1000000], "counts": [1, 0], "count": 1, "sum_ns": 1000}},
+		"goroutines": {"limit": 4, "running": 0, "queued": 0}
+	}`, string(data))
+
+	var decoded Stats
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "refused", decoded.Quarantined[0].Err.Error())
+	decoded.Quarantined[0].Err = stats.Quarantined[0].Err
+	assert.Equal(t, stats, decoded)
+}
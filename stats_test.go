@@ -0,0 +1,44 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMergeStats(t *testing.T) {
+	testCases := []struct {
+		name        string
+		stats       []Stats
+		expectedCap int
+	}{
+		{
+			name:        "with limited pools sums caps",
+			stats:       []Stats{{Cap: 1}, {Cap: 2}},
+			expectedCap: 3,
+		},
+		{
+			name:        "with unlimited pool has no cap",
+			stats:       []Stats{{Cap: 1}, {}},
+			expectedCap: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := MergeStats(tc.stats...)
+
+			assert.Equal(t, tc.expectedCap, merged.Cap)
+		})
+	}
+}
+
+func TestMergeStats_SumsCounters(t *testing.T) {
+	merged := MergeStats(
+		Stats{Acquires: 1, Idle: 1, Evictions: map[EvictReason]int64{EvictExpired: 1}},
+		Stats{Acquires: 2, Idle: 3, Evictions: map[EvictReason]int64{EvictExpired: 2}},
+	)
+
+	assert.Equal(t, int64(3), merged.Acquires)
+	assert.Equal(t, 4, merged.Idle)
+	assert.Equal(t, map[EvictReason]int64{EvictExpired: 3}, merged.Evictions)
+}
@@ -0,0 +1,64 @@
+package pool
+
+import (
+	"time"
+)
+
+// WithSynchronous runs the pool without background goroutines, e.g. for WASM
+// or environments where spawning them is undesirable. Maintenance is done
+// inline instead:
+//   - the warmup runs in New, ignoring WithStartupRamp
+//   - with WithHappyEyeballs, endpoints are dialed one after the other
+//   - WithStatsReporter reports, WithAutoSizing adjustments, WithReaper
+//     sweeps and WithMemoryPressure polls are made by the first Acquire or
+//     Release after each interval
+//   - lease deadlines are enforced when the lease is used, by Context and
+//     Extend, and WithLeaseReclaim reclaims expired leases on the first
+//     Acquire or Release after their deadline
+//
+// It replaces the scheduler of WithScheduler. Calls blocking the caller, such
+// as acquires with a deadline context and CloseWithin, still wake on timers.
+func WithSynchronous[T comparable]() Option[T] {
+	return func(n *NewPool[T]) {
+		n.isSynchronous = true
+	}
+}
+
+// runs the maintenance which is due in synchronous mode; the pool mutex must
+// not be held
+func (n *NewPool[T]) runDueTasks() {
+	if !n.isSynchronous || (n.reporter == nil && n.sizing.Interval <= 0 && n.reapInterval <= 0 && n.isPressured == nil && !n.isLeaseReclaimed) {
+		return
+	}
+
+	n.mutex.Lock()
+	isReportDue := n.isDue(&n.nextReportAt)
+	isGrown := n.isDue(&n.nextAutoSizeAt) && n.autoSize()
+	if n.isDue(&n.nextReapAt) {
+		n.reap()
+	}
+	isRestored := n.isDue(&n.nextPressureCheckAt) && n.checkPressure()
+	if n.isLeaseReclaimed && !n.isClosed {
+		n.reclaimExpiredLeases()
+	}
+	n.mutex.Unlock()
+
+	if isGrown || isRestored {
+		n.warmup()
+	}
+
+	if isReportDue {
+		n.report()
+	}
+}
+
+// reports whether the task due at *at is due, and clears *at if so; the pool
+// mutex must be held
+func (n *NewPool[T]) isDue(at *time.Time) bool {
+	if n.isClosed || at.IsZero() || n.now().Before(*at) {
+		return false
+	}
+
+	*at = time.Time{}
+	return true
+}
@@ -0,0 +1,61 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_SynchronousWarmup(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithSynchronous[MockResource](),
+		WithWarmup[MockResource](2),
+		WithStartupRamp[MockResource](time.Hour),
+	)
+
+	assert.Equal(t, 2, pool.NumIdle())
+	assert.Equal(t, SchedulerStats{}, pool.Stats().Goroutines)
+}
+
+func TestNewPool_SynchronousStatsReporter(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	var reports []Stats
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithSynchronous[MockResource](),
+		WithClock[MockResource](clock),
+		WithStatsReporter[MockResource](time.Minute, func(stats Stats) { reports = append(reports, stats) }),
+	)
+	resource, _ := pool.Acquire(nil)
+
+	clock.Advance(time.Minute)
+	assert.Len(t, reports, 0)
+
+	pool.Release(resource)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, int64(1), reports[0].Acquires)
+
+	pool.Acquire(nil)
+	assert.Len(t, reports, 1)
+
+	clock.Advance(time.Minute)
+	pool.Close()
+	pool.Acquire(nil)
+	assert.Len(t, reports, 1)
+}
+
+func TestLease_SynchronousDeadline(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithSynchronous[MockResource](),
+		WithClock[MockResource](clock),
+		WithLeaseTTL[MockResource](time.Minute),
+	)
+	lease, _ := pool.AcquireLease(nil)
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	assert.NoError(t, lease.Context().Err())
+
+	clock.Advance(time.Nanosecond)
+	assert.Error(t, lease.Context().Err())
+	assert.ErrorIs(t, lease.Extend(time.Minute), ErrLeaseExpired)
+}
@@ -0,0 +1,119 @@
+package pool
+
+import (
+	"context"
+)
+
+type tenantKey struct{}
+
+// TenantStats is the activity of a tenant in Stats
+type TenantStats struct {
+	// Active is the number of resources acquired by the tenant at the time of
+	// the snapshot
+	Active int
+	// Acquires is the number of successful acquires of the tenant
+	Acquires int64
+	// Rejections is the number of acquires failed with ErrTenantQuotaExceeded
+	Rejections int64
+}
+
+// tenantState is the bookkeeping kept for a tenant; guarded by the pool mutex
+type tenantState struct {
+	active     int
+	acquires   int64
+	rejections int64
+}
+
+// Tenant returns a context whose acquires are accounted to tenant, e.g. a
+// customer ID, for WithTenantMaxActive and the per-tenant stats
+func Tenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// returns the tenant of ctx; empty if it has none
+func getTenant(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// WithTenantMaxActive caps the resources each tenant, as set with Tenant, may
+// hold at once, so one noisy tenant can not exhaust the shared pool. limit
+// returns the cap of a tenant; zero or less means no limit. Acquires over the
+// cap fail right away with ErrTenantQuotaExceeded instead of waiting.
+// Acquires without a tenant are not limited. It has no effect with
+// WithLocalCache, and batch acquires are not accounted to tenants.
+func WithTenantMaxActive[T comparable](limit func(tenant string) int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.tenantLimit = limit
+	}
+}
+
+// takes a slot of the tenant of ctx, if it is below its cap; returns the
+// tenant the slot is taken for, empty if the acquire is not accounted
+func (n *NewPool[T]) takeTenant(ctx context.Context) (string, error) {
+	tenant := getTenant(ctx)
+	if tenant == "" || n.local != nil {
+		return "", nil
+	}
+
+	if n.tenants == nil {
+		n.tenants = make(map[string]*tenantState)
+	}
+	state, isFound := n.tenants[tenant]
+	if !isFound {
+		state = &tenantState{}
+		n.tenants[tenant] = state
+	}
+
+	if n.tenantLimit != nil {
+		if limit := n.tenantLimit(tenant); limit > 0 && state.active >= limit {
+			state.rejections++
+			return "", ErrTenantQuotaExceeded
+		}
+	}
+	state.active++
+	return tenant, nil
+}
+
+// gives back a slot of tenant, e.g. after a failed acquire
+func (n *NewPool[T]) giveTenant(tenant string) {
+	if tenant == "" {
+		return
+	}
+	n.tenants[tenant].active--
+}
+
+// accounts an acquired resource to the tenant its slot was taken for
+func (n *NewPool[T]) setTenant(entry *resourceEntry, tenant string) {
+	entry.tenant = tenant
+	if tenant != "" {
+		n.tenants[tenant].acquires++
+	}
+}
+
+// gives back the slot of the tenant an acquired resource is accounted to
+func (n *NewPool[T]) clearTenant(entry *resourceEntry) {
+	n.giveTenant(entry.tenant)
+	entry.tenant = ""
+}
+
+// returns the per-tenant stats; nil without tenants
+func (n *NewPool[T]) getTenantStats() map[string]TenantStats {
+	if len(n.tenants) == 0 {
+		return nil
+	}
+
+	stats := make(map[string]TenantStats, len(n.tenants))
+	for tenant, state := range n.tenants {
+		stats[tenant] = TenantStats{
+			Active:     state.active,
+			Acquires:   state.acquires,
+			Rejections: state.rejections,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,94 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPool_AcquireWithTenantMaxActive(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tenant        string
+		held          int
+		expectedError error
+	}{
+		{
+			name:   "below tenant cap acquires",
+			tenant: "tenant-1",
+			held:   1,
+		},
+		{
+			name:          "at tenant cap returns tenant quota exceeded",
+			tenant:        "tenant-1",
+			held:          2,
+			expectedError: ErrTenantQuotaExceeded,
+		},
+		{
+			name:   "with tenant without cap acquires",
+			tenant: "tenant-2",
+			held:   2,
+		},
+		{
+			name: "without tenant acquires",
+			held: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithTenantMaxActive[MockResource](func(tenant string) int {
+					if tenant == "tenant-1" {
+						return 2
+					}
+					return 0
+				}),
+			)
+			ctx := Tenant(context.Background(), tc.tenant)
+			for i := 0; i < tc.held; i++ {
+				pool.Acquire(ctx)
+			}
+
+			_, err := pool.Acquire(ctx)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNewPool_ReleaseWithTenant(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithTenantMaxActive[MockResource](func(string) int { return 1 }),
+	)
+	ctx := Tenant(context.Background(), "tenant-1")
+	first, _ := pool.Acquire(ctx)
+	other, _ := pool.Acquire(Tenant(context.Background(), "tenant-2"))
+	_, err := pool.Acquire(ctx)
+	assert.ErrorIs(t, err, ErrTenantQuotaExceeded)
+
+	pool.Release(first)
+	pool.Invalidate(other)
+	_, err = pool.Acquire(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]TenantStats{
+		"tenant-1": {Active: 1, Acquires: 2, Rejections: 1},
+		"tenant-2": {Acquires: 1},
+	}, pool.Stats().Tenants)
+}
+
+func TestNewPool_AcquireWithTenantFailureGivesBackSlot(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithMaxActive[MockResource](1),
+		WithTenantMaxActive[MockResource](func(string) int { return 1 }),
+	)
+	pool.Acquire(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := pool.Acquire(Tenant(ctx, "tenant-1"))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, TenantStats{}, pool.Stats().Tenants["tenant-1"])
+}
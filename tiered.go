@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"errors"
+)
+
+var _ Pool[PoolResource] = &TieredPool[PoolResource]{}
+
+// TieredPool acquires from a cheap local tier of pre-established resources
+// and cascades to an expensive fallback tier created on demand, e.g. local
+// replica connections first, cross-region ones second. Each tier is a pool of
+// its own, with its own limits and idle times; released resources go back to
+// the tier they were acquired from. To keep the local tier to pre-established
+// resources, cap it with WithMaxActive and fill it with WithWarmup.
+//
+// Like OverflowPool, the local tier is tried without waiting, with a nil
+// context, and the fallback tier is waited for until ctx is done. Unlike it,
+// acquires also cascade when the local tier is paused or fails to create a
+// resource, e.g. while the local replica is down.
+type TieredPool[T comparable] struct {
+	OverflowPool[T]
+}
+
+// creates a pool acquiring from local and cascading to fallback
+func NewTiered[T comparable](
+	// local is the tier acquired from first
+	local *NewPool[T],
+	// fallback is the tier acquired from while local is exhausted, paused or
+	// failing to create
+	fallback *NewPool[T],
+) *TieredPool[T] {
+	return &TieredPool[T]{
+		OverflowPool: OverflowPool[T]{primary: local, overflow: fallback, isOverflowed: isTierUnavailable},
+	}
+}
+
+// reports whether an acquire of a tier failed for a reason the next tier may
+// not share
+func isTierUnavailable(err error) bool {
+	var createErr *CreateError
+	return isExhausted(err) || errors.Is(err, ErrPoolPaused) || errors.As(err, &createErr)
+}
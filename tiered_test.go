@@ -0,0 +1,100 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTieredPool_Acquire(t *testing.T) {
+	testCases := []struct {
+		name             string
+		localCreator     func(context.Context) (MockResource, error)
+		localHeld        int
+		isLocalPaused    bool
+		isLocalClosed    bool
+		expectedResource MockResource
+		expectedError    error
+	}{
+		{
+			name:             "with local capacity acquires from local tier",
+			localCreator:     getMockCreatorFunc(),
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:             "with exhausted local tier cascades",
+			localCreator:     getMockCreatorFunc(),
+			localHeld:        1,
+			expectedResource: MockResource{id: 101},
+		},
+		{
+			name: "with failing local tier cascades",
+			localCreator: func(context.Context) (MockResource, error) {
+				return MockResource{}, errors.New("replica down")
+			},
+			expectedResource: MockResource{id: 101},
+		},
+		{
+			name:             "with paused local tier cascades",
+			localCreator:     getMockCreatorFunc(),
+			isLocalPaused:    true,
+			expectedResource: MockResource{id: 101},
+		},
+		{
+			name:          "with closed local tier returns error",
+			localCreator:  getMockCreatorFunc(),
+			isLocalClosed: true,
+			expectedError: ErrPoolClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			local := New(tc.localCreator, maxIdleSize, maxIdleTime, WithMaxActive[MockResource](1))
+			fallback := New(getOffsetMockCreatorFunc(100), maxIdleSize, maxIdleTime)
+			pool := NewTiered(local, fallback)
+			for i := 0; i < tc.localHeld; i++ {
+				pool.Acquire(nil)
+			}
+			if tc.isLocalPaused {
+				local.Pause()
+			}
+			if tc.isLocalClosed {
+				local.Close()
+			}
+
+			resource, err := pool.Acquire(context.Background())
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedResource, resource)
+		})
+	}
+}
+
+func TestTieredPool_ReleaseRoutesToTier(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	local := New(getMockCreatorFunc(), maxIdleSize, time.Hour,
+		WithClock[MockResource](clock),
+		WithMaxActive[MockResource](1),
+	)
+	fallback := New(getOffsetMockCreatorFunc(100), maxIdleSize, time.Minute, WithClock[MockResource](clock))
+	pool := NewTiered(local, fallback)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+
+	assert.NoError(t, pool.TryRelease(second))
+	assert.NoError(t, pool.TryRelease(first))
+	assert.ErrorIs(t, pool.TryRelease(first), ErrNotAcquired)
+	assert.Equal(t, 1, local.NumIdle())
+	assert.Equal(t, 1, fallback.NumIdle())
+
+	clock.Advance(2 * time.Minute)
+	first, _ = pool.Acquire(nil)
+	second, _ = pool.Acquire(nil)
+
+	assert.Equal(t, MockResource{id: 1}, first)
+	assert.Equal(t, MockResource{id: 102}, second)
+	assert.Equal(t, int64(1), fallback.Stats().Evictions[EvictExpired])
+}
@@ -0,0 +1,77 @@
+package pool
+
+import (
+	"time"
+)
+
+// ResourceInfo is the usage of a pooled resource, e.g. to spot one old
+// connection doing all the work
+type ResourceInfo struct {
+	// CreatedAt is when the resource was created
+	CreatedAt time.Time
+	// LastUsedAt is when the resource was last acquired or released
+	LastUsedAt time.Time
+	// UseCount is the number of times the resource was handed out
+	UseCount int64
+	// HeldDuration is the total time the resource was acquired, including the
+	// current hold of an acquired resource
+	HeldDuration time.Duration
+	// IsAcquired is set when the resource is acquired
+	IsAcquired bool
+}
+
+// returns the usage of an idle or acquired resource; false if the pool does
+// not track the resource. Resources of a pool with WithLocalCache are not
+// tracked while acquired or in the cache.
+func (n *NewPool[T]) ResourceInfo(resource T) (ResourceInfo, bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resource = n.getTracked(resource)
+	if entry, isFound := n.lock[resource]; isFound {
+		return n.getResourceInfo(entry, true), true
+	}
+	if entry, isFound := n.unlock[resource]; isFound {
+		return n.getResourceInfo(entry, false), true
+	}
+	return ResourceInfo{}, false
+}
+
+// returns the usage of every idle and acquired resource
+func (n *NewPool[T]) Resources() map[T]ResourceInfo {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resources := make(map[T]ResourceInfo, len(n.lock)+len(n.unlock))
+	for resource, entry := range n.lock {
+		resources[resource] = n.getResourceInfo(entry, true)
+	}
+	for resource, entry := range n.unlock {
+		resources[resource] = n.getResourceInfo(entry, false)
+	}
+	return resources
+}
+
+// returns the usage of a resource; the pool mutex must be held
+func (n *NewPool[T]) getResourceInfo(entry *resourceEntry, isAcquired bool) ResourceInfo {
+	info := ResourceInfo{
+		CreatedAt:    entry.createdAt,
+		LastUsedAt:   entry.acquiredAt,
+		UseCount:     entry.useCount,
+		HeldDuration: entry.heldDuration,
+		IsAcquired:   isAcquired,
+	}
+	if entry.releasedAt.After(info.LastUsedAt) {
+		info.LastUsedAt = entry.releasedAt
+	}
+	if isAcquired {
+		info.HeldDuration += n.now().Sub(entry.acquiredAt)
+	}
+	return info
+}
+
+// adds the hold of an acquired resource which is no longer acquired to its
+// usage
+func (n *NewPool[T]) recordHold(entry *resourceEntry) {
+	entry.heldDuration += n.now().Sub(entry.acquiredAt)
+}
@@ -0,0 +1,47 @@
+package pool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_ResourceInfo(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime, WithClock[MockResource](clock))
+	resource, _ := pool.Acquire(nil)
+	clock.Advance(time.Second)
+	pool.Release(resource)
+	clock.Advance(time.Second)
+	pool.Acquire(nil)
+	clock.Advance(time.Second)
+
+	info, isFound := pool.ResourceInfo(resource)
+
+	assert.True(t, isFound)
+	assert.Equal(t, ResourceInfo{
+		CreatedAt:    time.Unix(0, 0),
+		LastUsedAt:   time.Unix(2, 0),
+		UseCount:     2,
+		HeldDuration: 2 * time.Second,
+		IsAcquired:   true,
+	}, info)
+
+	pool.Release(resource)
+	assert.Equal(t, map[MockResource]ResourceInfo{
+		resource: {
+			CreatedAt:    time.Unix(0, 0),
+			LastUsedAt:   time.Unix(3, 0),
+			UseCount:     2,
+			HeldDuration: 2 * time.Second,
+		},
+	}, pool.Resources())
+}
+
+func TestNewPool_ResourceInfoOfUnknownResource(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime)
+
+	_, isFound := pool.ResourceInfo(MockResource{id: 1})
+
+	assert.False(t, isFound)
+}
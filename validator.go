@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"context"
+	"reflect"
+)
+
+// validatable is a resource checking itself, used by default as the
+// validator of WithValidator
+type validatable interface {
+	Validate(context.Context) error
+}
+
+// aliveChecker is a resource reporting whether it is still usable, used by
+// default as the validator of WithValidator
+type aliveChecker interface {
+	IsAlive() bool
+}
+
+var (
+	validatableType  = reflect.TypeOf((*validatable)(nil)).Elem()
+	aliveCheckerType = reflect.TypeOf((*aliveChecker)(nil)).Elem()
+)
+
+// WithValidator sets a borrow-time check of idle resources: validate is
+// called with the acquire ctx before an idle resource is handed out, and
+// resources it fails for are destroyed with EvictValidateFailed while the
+// next idle resource is tried, or a new one created. New resources are not
+// validated. Without it, resources whose type T implements Validate(ctx)
+// error, or else IsAlive() bool, are checked with it. The validator runs
+// while the pool mutex is held and is covered by WithPanicContainment, a
+// recovered panic counting as a failure.
+func WithValidator[T comparable](validate func(context.Context, T) error) Option[T] {
+	return func(n *NewPool[T]) {
+		n.validator = validate
+	}
+}
+
+// returns the validator used without WithValidator: one calling Validate or
+// IsAlive when T implements them, nil otherwise
+func getValidator[T any]() func(context.Context, T) error {
+	resourceType := reflect.TypeOf((*T)(nil)).Elem()
+	switch {
+	case resourceType.Implements(validatableType):
+		return func(ctx context.Context, resource T) error {
+			if v, isValidatable := any(resource).(validatable); isValidatable {
+				return v.Validate(ctx)
+			}
+			return nil
+		}
+	case resourceType.Implements(aliveCheckerType):
+		return func(_ context.Context, resource T) error {
+			if checker, isChecker := any(resource).(aliveChecker); isChecker && !checker.IsAlive() {
+				return ErrNotAlive
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// validates an idle resource about to be handed out; returns false if it
+// failed and should be destroyed. The pool mutex must be held.
+func (n *NewPool[T]) validate(ctx context.Context, resource T) bool {
+	if n.validator == nil {
+		return true
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// a validator disabled by panic containment hands resources out unchecked
+	isValid := n.disabledHooks["Validate"]
+	n.callHook("Validate", func() {
+		err := n.validator(ctx, resource)
+		if err != nil {
+			n.log(LogWarn, "idle resource failed validation; destroying it",
+				Field{Key: "error", Value: err},
+			)
+		}
+		isValid = err == nil
+	})
+	return isValid
+}
@@ -0,0 +1,191 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// validatingResource is a resource implementing Validate
+type validatingResource struct {
+	id      int
+	isValid bool
+}
+
+func (r validatingResource) Validate(context.Context) error {
+	if !r.isValid {
+		return errors.New("broken")
+	}
+	return nil
+}
+
+// aliveResource is a resource implementing IsAlive
+type aliveResource struct {
+	id      int
+	isAlive bool
+}
+
+func (r aliveResource) IsAlive() bool {
+	return r.isAlive
+}
+
+func TestNewPool_AcquireWithValidator(t *testing.T) {
+	testCases := []struct {
+		name              string
+		validator         func(context.Context, MockResource) error
+		expectedResource  MockResource
+		expectedEvictions int64
+	}{
+		{
+			name:             "with passing validator reuses idle resource",
+			validator:        func(context.Context, MockResource) error { return nil },
+			expectedResource: MockResource{id: 1},
+		},
+		{
+			name:              "with failing validator destroys idle resource and creates one",
+			validator:         func(context.Context, MockResource) error { return errors.New("broken") },
+			expectedResource:  MockResource{id: 2},
+			expectedEvictions: 1,
+		},
+		{
+			name: "with panicking validator destroys idle resource when contained",
+			validator: func(context.Context, MockResource) error {
+				panic("validator panic")
+			},
+			expectedResource:  MockResource{id: 2},
+			expectedEvictions: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				WithValidator(tc.validator),
+				WithPanicContainment[MockResource](0),
+			)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			resource, err := pool.Acquire(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.Equal(t, tc.expectedEvictions, pool.Stats().Evictions[EvictValidateFailed])
+		})
+	}
+}
+
+func TestNewPool_AcquireValidatesNextIdleResource(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithValidator(func(_ context.Context, resource MockResource) error {
+			if resource.id == 1 {
+				return errors.New("broken")
+			}
+			return nil
+		}),
+	)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.ReleaseAll([]MockResource{first, second})
+
+	resource, _ := pool.Acquire(nil)
+	again, _ := pool.Acquire(nil)
+
+	assert.ElementsMatch(t, []MockResource{{id: 2}, {id: 3}}, []MockResource{resource, again})
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictValidateFailed])
+}
+
+func TestNewPool_AcquireWithValidateMethod(t *testing.T) {
+	testCases := []struct {
+		name              string
+		isValid           bool
+		options           []Option[validatingResource]
+		expectedReused    int64
+		expectedEvictions int64
+	}{
+		{
+			name:           "with valid resource reuses it",
+			isValid:        true,
+			expectedReused: 1,
+		},
+		{
+			name:              "with invalid resource destroys it",
+			expectedEvictions: 1,
+		},
+		{
+			name:           "with compatibility mode does not validate",
+			options:        []Option[validatingResource]{CompatibilityV1[validatingResource]()},
+			expectedReused: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id := 0
+			creator := func(context.Context) (validatingResource, error) {
+				id++
+				return validatingResource{id: id, isValid: tc.isValid}, nil
+			}
+			pool := New(creator, maxIdleSize, maxIdleTime, tc.options...)
+			resource, _ := pool.Acquire(nil)
+			pool.Release(resource)
+
+			pool.Acquire(nil)
+
+			stats := pool.Stats()
+			assert.Equal(t, tc.expectedReused, stats.Reused)
+			assert.Equal(t, tc.expectedEvictions, stats.Evictions[EvictValidateFailed])
+		})
+	}
+}
+
+func TestNewPool_AcquireWithIsAliveMethod(t *testing.T) {
+	id := 0
+	creator := func(context.Context) (aliveResource, error) {
+		id++
+		return aliveResource{id: id, isAlive: id > 1}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	resource, _ = pool.Acquire(nil)
+
+	assert.Equal(t, aliveResource{id: 2, isAlive: true}, resource)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictValidateFailed])
+}
+
+func TestNewPool_AcquireWithValidatorOverridingValidateMethod(t *testing.T) {
+	creator := func(context.Context) (validatingResource, error) {
+		return validatingResource{id: 1}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime,
+		WithValidator(func(context.Context, validatingResource) error { return nil }),
+	)
+	resource, _ := pool.Acquire(nil)
+	pool.Release(resource)
+
+	pool.Acquire(nil)
+
+	assert.Equal(t, int64(1), pool.Stats().Reused)
+}
+
+func TestGetValidator_WithoutMethods(t *testing.T) {
+	assert.Nil(t, getValidator[MockResource]())
+}
+
+func TestNewPool_AcquireWithDisabledValidator(t *testing.T) {
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithValidator(func(context.Context, MockResource) error { panic("validator panic") }),
+		WithPanicContainment[MockResource](1),
+	)
+	first, _ := pool.Acquire(nil)
+	second, _ := pool.Acquire(nil)
+	pool.ReleaseAll([]MockResource{first, second})
+
+	resource, _ := pool.Acquire(nil)
+
+	assert.Contains(t, []MockResource{first, second}, resource)
+	assert.Equal(t, int64(1), pool.Stats().Evictions[EvictValidateFailed])
+}
@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+type versionPinKey struct{}
+
+// versionPin records the resource version used by the first acquire in a scope
+type versionPin struct {
+	mutex    sync.Mutex
+	version  string
+	isPinned bool
+}
+
+func (p *versionPin) get() (string, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.version, p.isPinned
+}
+
+func (p *versionPin) set(version string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.version = version
+	p.isPinned = true
+}
+
+// WithVersion reports the version (e.g. schema or credentials generation) of a
+// resource, enabling PinVersion scopes
+func WithVersion[T comparable](versioner func(T) string) Option[T] {
+	return func(n *NewPool[T]) {
+		n.versioner = versioner
+	}
+}
+
+// PinVersion starts a scope in which every acquire with the returned context
+// gets resources of the same version as the first one acquired in the scope.
+// Acquires wait for a matching resource to be released when the creator no
+// longer produces the pinned version. Pools without WithVersion ignore the pin.
+func PinVersion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, versionPinKey{}, &versionPin{})
+}
+
+// returns the version pin of the scope, if the pool tracks versions
+func (n *NewPool[T]) getVersionPin(ctx context.Context) *versionPin {
+	if n.versioner == nil || ctx == nil {
+		return nil
+	}
+
+	pin, _ := ctx.Value(versionPinKey{}).(*versionPin)
+	return pin
+}
+
+// acquires a resource matching the version pinned in the scope
+func (n *NewPool[T]) acquirePinned(ctx context.Context, pin *versionPin, wait *acquireWait) (T, error) {
+	version, isPinned := pin.get()
+	if !isPinned {
+		resource, err := n.acquire(ctx, nil, wait)
+		if err == nil {
+			pin.set(n.versioner(resource))
+		}
+		return resource, err
+	}
+
+	isPinnedVersion := func(resource T) bool {
+		return n.versioner(resource) == version
+	}
+
+	// the creator is tried once; after a rotation it keeps returning other versions
+	isCreated := false
+	for {
+		if !n.isAtCapacity() {
+			if resource, isSuccess := n.getIdleResourceWhere(ctx, isPinnedVersion); isSuccess {
+				n.stats.reused++
+				return resource, nil
+			}
+
+			if !isCreated {
+				resource, err := n.createResource(ctx)
+				if err != nil || isPinnedVersion(resource) {
+					return resource, err
+				}
+
+				// the creator moved on to another version; keep the new resource
+				// for other callers and wait for a pinned one to be released
+				entry := n.lock[resource]
+				n.markInactive(resource, entry)
+				n.returnIdle(resource, entry)
+				isCreated = true
+			}
+		}
+
+		if err := n.waitAcquire(ctx, wait); err != nil {
+			return *new(T), err
+		}
+		if n.isClosed {
+			return *new(T), ErrPoolClosed
+		}
+		n.deleteInvalidIdleResources()
+	}
+}
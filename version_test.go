@@ -0,0 +1,157 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type VersionedResource struct {
+	id      int
+	version string
+}
+
+func TestNewPool_AcquirePinned(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		pinnedVersion          string
+		creatorVersion         string
+		idleResourcePool       map[VersionedResource]*resourceEntry
+		expectedResource       VersionedResource
+		expectedError          error
+		expectedPinnedVersion  string
+		expectedIdlePoolLength int
+	}{
+		{
+			name:                  "with unpinned scope pins version of acquired resource",
+			creatorVersion:        "v2",
+			expectedResource:      VersionedResource{id: 1, version: "v2"},
+			expectedPinnedVersion: "v2",
+		},
+		{
+			name:           "with pinned scope reuses idle resource of pinned version",
+			pinnedVersion:  "v1",
+			creatorVersion: "v2",
+			idleResourcePool: map[VersionedResource]*resourceEntry{
+				VersionedResource{id: 7, version: "v1"}: {releasedAt: time.Now()},
+				VersionedResource{id: 8, version: "v2"}: {releasedAt: time.Now()},
+			},
+			expectedResource:       VersionedResource{id: 7, version: "v1"},
+			expectedPinnedVersion:  "v1",
+			expectedIdlePoolLength: 1,
+		},
+		{
+			name:           "with pinned scope creates resource of pinned version",
+			pinnedVersion:  "v1",
+			creatorVersion: "v1",
+			idleResourcePool: map[VersionedResource]*resourceEntry{
+				VersionedResource{id: 8, version: "v2"}: {releasedAt: time.Now()},
+			},
+			expectedResource:       VersionedResource{id: 1, version: "v1"},
+			expectedPinnedVersion:  "v1",
+			expectedIdlePoolLength: 1,
+		},
+		{
+			name:                   "with rotated creator waits for pinned version until ctx is done",
+			pinnedVersion:          "v1",
+			creatorVersion:         "v2",
+			expectedError:          ErrAcquireTimeout,
+			expectedPinnedVersion:  "v1",
+			expectedIdlePoolLength: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.idleResourcePool == nil {
+				tc.idleResourcePool = make(map[VersionedResource]*resourceEntry)
+			}
+
+			pool := NewPool[VersionedResource]{
+				creator:     getVersionedCreatorFunc(tc.creatorVersion),
+				maxIdleTime: maxIdleTime,
+				maxIdleSize: maxIdleSize,
+				mutex:       &sync.Mutex{},
+				lock:        make(map[VersionedResource]*resourceEntry),
+				unlock:      tc.idleResourcePool,
+				versioner:   getVersionedResourceVersion,
+			}
+
+			ctx, cancel := context.WithTimeout(PinVersion(context.Background()), 10*time.Millisecond)
+			defer cancel()
+			if tc.pinnedVersion != "" {
+				ctx.Value(versionPinKey{}).(*versionPin).set(tc.pinnedVersion)
+			}
+
+			resource, err := pool.Acquire(ctx)
+
+			assert.Equal(t, tc.expectedResource, resource)
+			assert.ErrorIs(t, err, tc.expectedError)
+			pinnedVersion, _ := ctx.Value(versionPinKey{}).(*versionPin).get()
+			assert.Equal(t, tc.expectedPinnedVersion, pinnedVersion)
+			assert.Equal(t, tc.expectedIdlePoolLength, len(pool.unlock))
+		})
+	}
+}
+
+func TestNewPool_AcquirePinnedWaitsForRelease(t *testing.T) {
+	pool := New(getVersionedCreatorFunc("v1"), maxIdleSize, maxIdleTime, WithVersion(getVersionedResourceVersion))
+
+	ctx := PinVersion(context.Background())
+	first, err := pool.Acquire(ctx)
+	assert.NoError(t, err)
+
+	pool.mutex.Lock()
+	pool.creator = getVersionedCreatorFunc("v2")
+	pool.mutex.Unlock()
+
+	acquired := make(chan VersionedResource)
+	go func() {
+		resource, _ := pool.Acquire(ctx)
+		acquired <- resource
+	}()
+
+	// waits until the second acquire parked the rotated resource
+	assert.Eventually(t, func() bool { return pool.NumIdle() == 1 }, time.Second, time.Millisecond)
+	pool.Release(first)
+
+	assert.Equal(t, first, <-acquired)
+}
+
+func TestNewPool_AcquireWithoutVersioner(t *testing.T) {
+	pool := New(getVersionedCreatorFunc("v1"), maxIdleSize, maxIdleTime)
+
+	ctx := PinVersion(context.Background())
+	ctx.Value(versionPinKey{}).(*versionPin).set("v0")
+
+	resource, err := pool.Acquire(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, VersionedResource{id: 1, version: "v1"}, resource)
+}
+
+func getVersionedCreatorFunc(version string) func(context.Context) (VersionedResource, error) {
+	id := 0
+	return func(ctx context.Context) (VersionedResource, error) {
+		id += 1
+		return VersionedResource{id: id, version: version}, nil
+	}
+}
+
+func getVersionedResourceVersion(resource VersionedResource) string {
+	return resource.version
+}
+
+func ExamplePinVersion() {
+	pool := New(getVersionedCreatorFunc("v1"), maxIdleSize, maxIdleTime, WithVersion(getVersionedResourceVersion))
+
+	ctx := PinVersion(context.Background())
+	first, _ := pool.Acquire(ctx)
+	second, _ := pool.Acquire(ctx)
+
+	fmt.Println(first.version == second.version)
+	// Output: true
+}
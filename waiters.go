@@ -0,0 +1,83 @@
+package pool
+
+import (
+	"context"
+	"time"
+)
+
+// WithMaxWaiters sheds load by capping the number of acquires waiting for a
+// resource: while maxWaiters acquires are waiting, further acquires which
+// would wait return ErrPoolExhausted right away
+func WithMaxWaiters[T comparable](maxWaiters int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.maxWaiters = maxWaiters
+	}
+}
+
+// counts an acquire as waiting, unless the waiters cap is reached; the pool
+// mutex must be held
+func (n *NewPool[T]) enterWait() error {
+	if n.maxWaiters > 0 && n.waiters >= n.maxWaiters {
+		n.stats.waitRejections++
+		return ErrPoolExhausted
+	}
+
+	n.waiters++
+	n.checkSaturation()
+	n.checkState()
+	return nil
+}
+
+// waits like wait as a counted waiter of an acquire, adding the wait time to
+// wait; the pool mutex must be held
+func (n *NewPool[T]) waitAcquire(ctx context.Context, wait *acquireWait) error {
+	if ctx == nil {
+		return ErrPoolExhausted
+	}
+	if err := n.enterWait(); err != nil {
+		return err
+	}
+
+	start := n.now()
+	err := n.wait(ctx)
+	n.waiters--
+	n.checkSaturation()
+	// a waiter handed a resource takes it next, so the pool does not leave
+	// the exhausted state in between
+	if err != nil {
+		n.checkState()
+	}
+	wait.add(n.now().Sub(start))
+	return err
+}
+
+// acquireWait is the time an acquire spent waiting, over all its waits
+type acquireWait struct {
+	duration time.Duration
+	isWaited bool
+	// createSeq is the number of creations finished by acquires before the
+	// acquire queued for the pool mutex, with WithCreateCoalescing
+	createSeq int64
+	// isCoalesced is set once the acquire shared a creation of another miss
+	isCoalesced bool
+}
+
+func (w *acquireWait) add(d time.Duration) {
+	w.duration += d
+	w.isWaited = true
+}
+
+// records the wait of an acquire in the stats, if it waited; the pool mutex
+// must be held
+func (n *NewPool[T]) recordWait(wait *acquireWait) {
+	if !wait.isWaited {
+		return
+	}
+
+	n.stats.waits++
+	n.stats.waitDuration += wait.duration
+	if wait.duration > n.stats.maxWait {
+		n.stats.maxWait = wait.duration
+	}
+	n.reportSlowAcquire(wait.duration)
+}
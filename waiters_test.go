@@ -0,0 +1,89 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_AcquireWithMaxWaiters(t *testing.T) {
+	testCases := []struct {
+		name               string
+		options            []Option[MockResource]
+		expectedError      error
+		expectedRejections int64
+	}{
+		{
+			name:          "below cap waits",
+			options:       []Option[MockResource]{WithMaxWaiters[MockResource](2)},
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			name:               "at cap fails fast",
+			options:            []Option[MockResource]{WithMaxWaiters[MockResource](1)},
+			expectedError:      ErrPoolExhausted,
+			expectedRejections: 1,
+		},
+		{
+			name: "at cap waiting for quota fails fast",
+			options: []Option[MockResource]{
+				WithMaxWaiters[MockResource](1),
+				WithQuota[MockResource](NewQuota(1)),
+			},
+			expectedError:      ErrPoolExhausted,
+			expectedRejections: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+				append(tc.options, WithMaxActive[MockResource](1))...,
+			)
+			resource, _ := pool.Acquire(nil)
+
+			acquired := make(chan MockResource)
+			go func() {
+				resource, _ := pool.Acquire(context.Background())
+				acquired <- resource
+			}()
+			assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := pool.Acquire(ctx)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedRejections, pool.Stats().WaitRejections)
+			pool.Release(resource)
+			assert.Equal(t, resource, <-acquired)
+			assert.Equal(t, 0, pool.Stats().Waiters)
+		})
+	}
+}
+
+func TestNewPool_AcquireWaitStats(t *testing.T) {
+	clock := &MockClock{now: time.Unix(0, 0)}
+	pool := New(getMockCreatorFunc(), maxIdleSize, maxIdleTime,
+		WithClock[MockResource](clock),
+		WithMaxActive[MockResource](1),
+	)
+	resource, _ := pool.Acquire(nil)
+
+	acquired := make(chan MockResource)
+	go func() {
+		resource, _ := pool.Acquire(context.Background())
+		acquired <- resource
+	}()
+	assert.Eventually(t, func() bool { return pool.Stats().Waiters == 1 }, time.Second, time.Millisecond)
+	clock.Advance(time.Second)
+	pool.Release(resource)
+	pool.Release(<-acquired)
+	pool.Acquire(nil)
+
+	stats := pool.Stats()
+	assert.Equal(t, int64(1), stats.Waits)
+	assert.Equal(t, time.Second, stats.WaitDuration)
+	assert.Equal(t, time.Second, stats.MaxWait)
+}
@@ -0,0 +1,114 @@
+package pool
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// WithWarmup creates size idle resources in the background when the pool is
+// constructed, up to maxIdleSize
+func WithWarmup[T comparable](size int) Option[T] {
+	return func(n *NewPool[T]) {
+		n.warmupSize = size
+	}
+}
+
+// WithStartupRamp spreads warmup creations over window instead of starting
+// them all at once. Each creation gets its own slot of the window and a random
+// offset within that slot, so instances deployed together do not dial the
+// backend in lockstep.
+func WithStartupRamp[T comparable](window time.Duration) Option[T] {
+	return func(n *NewPool[T]) {
+		n.rampWindow = window
+	}
+}
+
+// fills the idle pool with warmup resources, following the startup ramp
+func (n *NewPool[T]) warmup() {
+	defer func() {
+		n.mutex.Lock()
+		n.isWarming = false
+		n.mutex.Unlock()
+	}()
+
+	// auto sizing and memory pressure change the sizes at runtime
+	n.mutex.Lock()
+	size := n.warmupSize
+	if size > n.maxIdleSize {
+		size = n.maxIdleSize
+	}
+	// idle resources count toward the warmup when it restores the idle pool
+	size -= len(n.unlock)
+	n.mutex.Unlock()
+	if size <= 0 {
+		return
+	}
+
+	start := n.now()
+	for _, delay := range getRampDelays(size, n.rampWindow, rand.Int63n) {
+		n.sleep(start.Add(delay).Sub(n.now()))
+		n.sleep(n.reserveCreate())
+
+		n.mutex.Lock()
+		creator, generation := n.creator, n.generation
+		n.idleCreating++
+		n.mutex.Unlock()
+
+		createStart := n.now()
+		resource, err := n.create(context.Background(), creator)
+		entry := &resourceEntry{createdAt: n.now(), generation: generation}
+		elapsed := entry.createdAt.Sub(createStart)
+		var warmErr error
+		if err == nil {
+			warmErr = n.runWarmHook(resource, entry, elapsed)
+		}
+
+		n.mutex.Lock()
+		n.idleCreating--
+		// wakes the misses waiting for the creation, as it may not land idle
+		n.notifyWaiters()
+		if n.isClosed {
+			if err == nil {
+				n.evict(resource, entry, EvictClosed)
+			}
+			n.mutex.Unlock()
+			return
+		}
+		if err != nil {
+			n.log(LogWarn, "failed to create warmup resource",
+				Field{Key: "error", Value: n.recordCreateFailure(err, elapsed)},
+			)
+		} else {
+			n.recordCreate(resource, entry, elapsed)
+			if warmErr != nil {
+				n.log(LogWarn, "failed to warm resource", Field{Key: "error", Value: warmErr})
+				n.evict(resource, entry, EvictWarmFailed)
+			} else if n.isRetired(entry) {
+				n.evict(resource, entry, EvictRetired)
+			} else {
+				n.returnIdle(resource, entry)
+			}
+		}
+		n.mutex.Unlock()
+	}
+}
+
+// returns the start offsets of count creations spread over window; random
+// returns a value in [0, max)
+func getRampDelays(count int, window time.Duration, random func(max int64) int64) []time.Duration {
+	delays := make([]time.Duration, count)
+	if window <= 0 || count == 0 {
+		return delays
+	}
+
+	slot := int64(window) / int64(count)
+	for i := range delays {
+		delays[i] = time.Duration(int64(i) * slot)
+		if slot > 0 {
+			delays[i] += time.Duration(random(slot))
+		}
+	}
+
+	return delays
+}
@@ -0,0 +1,97 @@
+package pool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPool_Warmup(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		warmupSize             int
+		expectedIdlePoolLength int
+		expectedCreated        int64
+	}{
+		{
+			name:                   "with warmup size fills idle pool",
+			warmupSize:             2,
+			expectedIdlePoolLength: 2,
+			expectedCreated:        2,
+		},
+		{
+			name:                   "with warmup size above max idle size fills up to max idle size",
+			warmupSize:             10,
+			expectedIdlePoolLength: maxIdleSize,
+			expectedCreated:        maxIdleSize,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := New(
+				getMockCreatorFunc(),
+				maxIdleSize,
+				maxIdleTime,
+				WithWarmup[MockResource](tc.warmupSize),
+				WithStartupRamp[MockResource](10*time.Millisecond),
+			)
+
+			assert.Eventually(t, func() bool {
+				return pool.Stats().Created == tc.expectedCreated
+			}, time.Second, time.Millisecond)
+			assert.Equal(t, tc.expectedIdlePoolLength, pool.NumIdle())
+		})
+	}
+}
+
+func TestNewPool_WarmupCountsCreating(t *testing.T) {
+	unblock := make(chan struct{})
+	creator := func(context.Context) (MockResource, error) {
+		<-unblock
+		return MockResource{}, nil
+	}
+	pool := New(creator, maxIdleSize, maxIdleTime, WithWarmup[MockResource](1))
+
+	assert.Eventually(t, func() bool { return pool.Stats().Creating == 1 }, time.Second, time.Millisecond)
+	close(unblock)
+	assert.Eventually(t, func() bool { return pool.Stats().Creating == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, pool.NumIdle())
+}
+
+func TestGetRampDelays(t *testing.T) {
+	testCases := []struct {
+		name           string
+		count          int
+		window         time.Duration
+		random         func(int64) int64
+		expectedDelays []time.Duration
+	}{
+		{
+			name:           "without window starts all creations immediately",
+			count:          3,
+			expectedDelays: []time.Duration{0, 0, 0},
+		},
+		{
+			name:           "with window spreads creations over slots",
+			count:          4,
+			window:         time.Second,
+			random:         func(int64) int64 { return 0 },
+			expectedDelays: []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond},
+		},
+		{
+			name:           "with window jitters creations within their slot",
+			count:          2,
+			window:         time.Second,
+			random:         func(max int64) int64 { return max - 1 },
+			expectedDelays: []time.Duration{500*time.Millisecond - 1, time.Second - 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDelays, getRampDelays(tc.count, tc.window, tc.random))
+		})
+	}
+}
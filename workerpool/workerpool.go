@@ -0,0 +1,109 @@
+// Package workerpool runs tasks on pooled worker goroutines, so the sizing
+// and reaping of the resource pool apply to goroutines.
+package workerpool
+
+import (
+	"context"
+	pool "example/ptran"
+	"time"
+)
+
+// Pool runs submitted tasks on worker goroutines. A worker is acquired from
+// the pool for each task and released once the task returned; idle workers
+// are reaped like idle resources, when a Submit finds them past maxIdleTime.
+type Pool struct {
+	pool    *pool.NewPool[*Worker]
+	options []pool.Option[*Worker]
+}
+
+// Option configures optional worker pool behavior
+type Option func(*Pool)
+
+// WithMaxWorkers caps the number of workers running tasks. At capacity,
+// Submit waits for a task to finish until ctx is done; with a nil ctx it
+// returns pool.ErrPoolExhausted instead of waiting.
+func WithMaxWorkers(maxWorkers int) Option {
+	return func(p *Pool) {
+		p.options = append(p.options, pool.WithMaxActive[*Worker](maxWorkers))
+	}
+}
+
+// WithPoolOptions passes options to the underlying resource pool, e.g.
+// pool.WithWarmup
+func WithPoolOptions(options ...pool.Option[*Worker]) Option {
+	return func(p *Pool) {
+		p.options = append(p.options, options...)
+	}
+}
+
+// Worker is a goroutine running the tasks sent to it. It is the resource of
+// the underlying pool, e.g. for pool.WithWarmup[*workerpool.Worker].
+type Worker struct {
+	tasks chan func()
+}
+
+// runs tasks until the worker is destroyed, releasing it after each one
+func (w *Worker) run(p *Pool) {
+	for task := range w.tasks {
+		task()
+		p.pool.Release(w)
+	}
+}
+
+// runs task on an idle worker, or on a new one. Returns once the task
+// started, with pool.ErrPoolClosed after Close, or with the error of ctx if
+// it is done while waiting for a worker.
+func (p *Pool) Submit(ctx context.Context, task func()) error {
+	w, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.tasks <- task
+	return nil
+}
+
+// returns the statistics of the pool; active resources are running workers
+func (p *Pool) Stats() pool.Stats {
+	return p.pool.Stats()
+}
+
+// stops the idle workers and rejects further tasks; running tasks finish and
+// their workers stop afterwards
+func (p *Pool) Close() {
+	p.pool.Close()
+}
+
+// rejects further tasks and waits for the running ones to finish until ctx is
+// done
+func (p *Pool) CloseGraceful(ctx context.Context) error {
+	return p.pool.CloseGraceful(ctx)
+}
+
+// creates a worker pool
+func New(
+	// maxIdleSize is the number of maximum idle workers kept in the pool
+	maxIdleSize int,
+	// maxIdleTime is the maximum idle time for an idle worker to be stopped
+	maxIdleTime time.Duration,
+	options ...Option,
+) *Pool {
+	p := &Pool{}
+	for _, option := range options {
+		option(p)
+	}
+
+	// warmup workers start before p.pool is set, but only read it once they
+	// got a task
+	creator := func(context.Context) (*Worker, error) {
+		w := &Worker{tasks: make(chan func())}
+		go w.run(p)
+		return w, nil
+	}
+	destroyer := pool.WithDestroyer(func(w *Worker) error {
+		close(w.tasks)
+		return nil
+	})
+	p.pool = pool.New(creator, maxIdleSize, maxIdleTime, append([]pool.Option[*Worker]{destroyer}, p.options...)...)
+	return p
+}
@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	pool "example/ptran"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const maxIdleSize = 3
+const maxIdleTime = 5 * time.Second
+
+func TestPool_Submit(t *testing.T) {
+	workers := New(maxIdleSize, maxIdleTime)
+	done := make(chan struct{})
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, workers.Submit(nil, func() { done <- struct{}{} }))
+		<-done
+		assert.Eventually(t, func() bool { return workers.Stats().Idle == 1 }, time.Second, time.Millisecond)
+	}
+
+	assert.Equal(t, int64(1), workers.Stats().Reused)
+}
+
+func TestPool_SubmitWithMaxWorkers(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ctx           func() (context.Context, context.CancelFunc)
+		expectedError error
+	}{
+		{
+			name:          "with nil ctx fails fast",
+			ctx:           func() (context.Context, context.CancelFunc) { return nil, func() {} },
+			expectedError: pool.ErrPoolExhausted,
+		},
+		{
+			name: "with ctx waits until it is done",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workers := New(maxIdleSize, maxIdleTime, WithMaxWorkers(1))
+			block := make(chan struct{})
+			defer close(block)
+			workers.Submit(nil, func() { <-block })
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			err := workers.Submit(ctx, func() {})
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestPool_SubmitReapsIdleWorkers(t *testing.T) {
+	workers := New(maxIdleSize, 10*time.Millisecond)
+	done := make(chan struct{})
+	workers.Submit(nil, func() { close(done) })
+	<-done
+	assert.Eventually(t, func() bool { return workers.Stats().Idle == 1 }, time.Second, time.Millisecond)
+
+	time.Sleep(20 * time.Millisecond)
+	workers.Submit(nil, func() {})
+
+	stats := workers.Stats()
+	assert.Equal(t, int64(1), stats.Evictions[pool.EvictExpired])
+	assert.Equal(t, int64(0), stats.Reused)
+}
+
+func TestPool_CloseGraceful(t *testing.T) {
+	workers := New(maxIdleSize, maxIdleTime)
+	block := make(chan struct{})
+	var isFinished bool
+	workers.Submit(nil, func() {
+		<-block
+		isFinished = true
+	})
+
+	go close(block)
+	err := workers.CloseGraceful(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, isFinished)
+	assert.ErrorIs(t, workers.Submit(nil, func() {}), pool.ErrPoolClosed)
+	assert.Equal(t, 0, workers.Stats().Idle)
+}
@@ -0,0 +1,11 @@
+// Package x is the home of experimental packages, such as distributed
+// coordination and preemption. Auto sizing within one process is a stable
+// feature of the core, see WithAutoSizing.
+//
+// Packages under x/ are not covered by the module's compatibility promise:
+// their APIs may change or be removed in any release, and they are released
+// without deprecation periods. They build on the public API of the pool only,
+// and no stable package may import them (see TestStablePackagesDoNotImportX
+// in the module root), so an experiment can not leak into the core Pool API.
+// A package moves out of x/ once its API is considered stable.
+package x